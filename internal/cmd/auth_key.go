@@ -112,8 +112,18 @@ func listAuthkeysCommand() *cobra.Command {
 		SilenceUsage: true,
 	})
 
+	var pageSize int32
+	var pageToken string
+	var userID uint64
+	var descending bool
+
+	command.Flags().Int32Var(&pageSize, "page-size", 0, "Maximum number of auth keys to list, all auth keys are listed when not set.")
+	command.Flags().StringVar(&pageToken, "page-token", "", "Token of the next page, as printed when listing the previous page.")
+	command.Flags().Uint64Var(&userID, "user-id", 0, "Only list auth keys of this user.")
+	command.Flags().BoolVar(&descending, "desc", false, "List the most recently created auth keys first.")
+
 	command.RunE = func(cmd *cobra.Command, args []string) error {
-		req := &api.ListAuthKeysRequest{TailnetId: tc.TailnetID()}
+		req := &api.ListAuthKeysRequest{TailnetId: tc.TailnetID(), PageSize: pageSize, PageToken: pageToken, UserId: userID, Descending: descending}
 		resp, err := tc.Client().ListAuthKeys(cmd.Context(), connect.NewRequest(req))
 
 		if err != nil {
@@ -121,6 +131,7 @@ func listAuthkeysCommand() *cobra.Command {
 		}
 
 		printAuthKeyTable(resp.Msg.AuthKeys...)
+		printNextPageToken(resp.Msg.NextPageToken)
 
 		return nil
 	}
@@ -48,34 +48,26 @@ func setDNSConfigCommand() *cobra.Command {
 	var httpsCerts bool
 	var overrideLocalDNS bool
 	var searchDomains []string
+	var publishRecords bool
+	var overlayNameservers []string
+	var overlaySearchDomains []string
 
 	command.Flags().StringSliceVarP(&nameservers, "nameserver", "", []string{}, "Machines on your network will use these nameservers to resolve DNS queries.")
 	command.Flags().BoolVarP(&magicDNS, "magic-dns", "", false, "Enable MagicDNS for the specified Tailnet")
 	command.Flags().BoolVarP(&httpsCerts, "https-certs", "", false, "Enable HTTPS Certificates for the specified Tailnet")
 	command.Flags().BoolVarP(&overrideLocalDNS, "override-local-dns", "", false, "When enabled, connected clients ignore local DNS settings and always use the nameservers specified for this Tailnet")
 	command.Flags().StringSliceVarP(&searchDomains, "search-domain", "", []string{}, "Custom DNS search domains.")
+	command.Flags().BoolVarP(&publishRecords, "publish-records", "", false, "Publish the A and AAAA records of the machines to the configured DNS provider")
 	command.Flags().StringSliceVarP(&extraRecords, "extra-records", "", []string{}, "Extra DNS records. Eg: mail.domain.tld::100.123.4.5")
+	command.Flags().StringSliceVarP(&overlayNameservers, "overlay-nameserver", "", []string{}, "Nameservers only for machines matching the target. Eg: tag:k8s=cluster.local:10.96.0.10")
+	command.Flags().StringSliceVarP(&overlaySearchDomains, "overlay-search-domain", "", []string{}, "Search domains only for machines matching the target. Eg: group:eng=corp.internal")
 
 	command.RunE = func(cmd *cobra.Command, args []string) error {
-		var globalNameservers []string
-		var routes = make(map[string]*api.Routes)
+		globalNameservers, routes := parseNameservers(nameservers)
 
-		for _, n := range nameservers {
-			if strings.HasPrefix(n, `http://`) || strings.HasPrefix(n, `https://`) { // doh
-				globalNameservers = append(globalNameservers, n)
-				continue
-			}
-			split := strings.SplitN(n, ":", 3)
-			if len(split) == 2 {
-				r, ok := routes[split[0]]
-				if ok {
-					r.Routes = append(r.Routes, split[1])
-				} else {
-					routes[split[0]] = &api.Routes{Routes: []string{split[1]}}
-				}
-				continue
-			}
-			globalNameservers = append(globalNameservers, n)
+		overlays, err := parseDNSOverlays(overlayNameservers, overlaySearchDomains)
+		if err != nil {
+			return err
 		}
 
 		req := api.SetDNSConfigRequest{
@@ -88,6 +80,8 @@ func setDNSConfigCommand() *cobra.Command {
 				HttpsCerts:       httpsCerts,
 				SearchDomains:    searchDomains,
 				ExtraRecords:     extraRecords,
+				PublishRecords:   publishRecords,
+				Overlays:         overlays,
 			},
 		}
 		resp, err := tc.Client().SetDNSConfig(cmd.Context(), connect.NewRequest(&req))
@@ -111,6 +105,79 @@ func setDNSConfigCommand() *cobra.Command {
 	return command
 }
 
+func parseNameservers(nameservers []string) ([]string, map[string]*api.Routes) {
+	var globalNameservers []string
+	var routes = make(map[string]*api.Routes)
+
+	for _, n := range nameservers {
+		if strings.HasPrefix(n, `http://`) || strings.HasPrefix(n, `https://`) { // doh
+			globalNameservers = append(globalNameservers, n)
+			continue
+		}
+		split := strings.SplitN(n, ":", 3)
+		if len(split) == 2 {
+			r, ok := routes[split[0]]
+			if ok {
+				r.Routes = append(r.Routes, split[1])
+			} else {
+				routes[split[0]] = &api.Routes{Routes: []string{split[1]}}
+			}
+			continue
+		}
+		globalNameservers = append(globalNameservers, n)
+	}
+
+	return globalNameservers, routes
+}
+
+func parseDNSOverlays(nameservers []string, searchDomains []string) ([]*api.DNSOverlay, error) {
+	var targets []string
+	var targetNameservers = make(map[string][]string)
+	var targetSearchDomains = make(map[string][]string)
+
+	split := func(v string) (string, string, error) {
+		target, value, ok := strings.Cut(v, "=")
+		if !ok || target == "" || value == "" {
+			return "", "", fmt.Errorf("invalid overlay [%s], expected format <target>=<value>", v)
+		}
+		if _, ok := targetNameservers[target]; !ok {
+			if _, ok := targetSearchDomains[target]; !ok {
+				targets = append(targets, target)
+			}
+		}
+		return target, value, nil
+	}
+
+	for _, n := range nameservers {
+		target, value, err := split(n)
+		if err != nil {
+			return nil, err
+		}
+		targetNameservers[target] = append(targetNameservers[target], value)
+	}
+
+	for _, n := range searchDomains {
+		target, value, err := split(n)
+		if err != nil {
+			return nil, err
+		}
+		targetSearchDomains[target] = append(targetSearchDomains[target], value)
+	}
+
+	var overlays []*api.DNSOverlay
+	for _, t := range targets {
+		globalNameservers, routes := parseNameservers(targetNameservers[t])
+		overlays = append(overlays, &api.DNSOverlay{
+			Targets:       []string{t},
+			Nameservers:   globalNameservers,
+			Routes:        routes,
+			SearchDomains: targetSearchDomains[t],
+		})
+	}
+
+	return overlays, nil
+}
+
 func printDnsConfig(config *api.DNSConfig) {
 	w := new(tabwriter.Writer)
 	w.Init(os.Stdout, 8, 8, 1, '\t', 0)
@@ -119,6 +186,7 @@ func printDnsConfig(config *api.DNSConfig) {
 	fmt.Fprintf(w, "%s\t\t%v\n", "MagicDNS", config.MagicDns)
 	fmt.Fprintf(w, "%s\t\t%v\n", "HTTPS Certs", config.HttpsCerts)
 	fmt.Fprintf(w, "%s\t\t%v\n", "Override Local DNS", config.OverrideLocalDns)
+	fmt.Fprintf(w, "%s\t\t%v\n", "Publish Records", config.PublishRecords)
 
 	if config.MagicDns {
 		fmt.Fprintf(w, "MagicDNS\t%s\t%s\n", config.MagicDnsSuffix, "100.100.100.100")
@@ -157,4 +225,22 @@ func printDnsConfig(config *api.DNSConfig) {
 			fmt.Fprintf(w, "%s\t%s\t%s\n", "", t, "")
 		}
 	}
+
+	for _, o := range config.Overlays {
+		target := fmt.Sprintf("Overlay %s", strings.Join(o.Targets, ","))
+
+		for k, r := range o.Routes {
+			for _, t := range r.Routes {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", target, k, t)
+			}
+		}
+
+		for _, t := range o.Nameservers {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", target, "Global", t)
+		}
+
+		for _, t := range o.SearchDomains {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", target, "Search Domain", t)
+		}
+	}
 }
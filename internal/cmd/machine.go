@@ -7,10 +7,14 @@ import (
 	"github.com/nleeper/goment"
 	"github.com/rodaine/table"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"inet.af/netaddr"
 	"os"
+	"sort"
 	"strings"
+	"tailscale.com/util/cmpver"
 	"text/tabwriter"
+	"time"
 )
 
 func machineCommands() *cobra.Command {
@@ -33,6 +37,13 @@ func machineCommands() *cobra.Command {
 	command.AddCommand(disableExitNodeCommand())
 	command.AddCommand(disableMachineKeyExpiryCommand())
 	command.AddCommand(authorizeMachineCommand())
+	command.AddCommand(renameMachineCommand())
+	command.AddCommand(moveMachineCommand())
+	command.AddCommand(setMachineAliasesCommand())
+	command.AddCommand(setMachineTagsCommand())
+	command.AddCommand(machineVersionsCommand())
+	command.AddCommand(bulkMachinesCommand())
+	command.AddCommand(machineShareCommands())
 
 	return command
 }
@@ -83,8 +94,11 @@ func getMachineCommand() *cobra.Command {
 		fmt.Fprintf(w, "%s\t%d\n", "ID", m.Id)
 		fmt.Fprintf(w, "%s\t%s\n", "Machine name", m.Name)
 		fmt.Fprintf(w, "%s\t%s\n", "Creator", m.User.Name)
-		fmt.Fprintf(w, "%s\t%s\n", "OS", m.Os)
+		fmt.Fprintf(w, "%s\t%s\n", "OS", strings.TrimSpace(m.Os+" "+m.OsVersion))
 		fmt.Fprintf(w, "%s\t%s\n", "Tailscale version", m.ClientVersion)
+		if m.UpdateAvailable {
+			fmt.Fprintf(w, "%s\t%v\n", "Update available", m.UpdateAvailable)
+		}
 		fmt.Fprintf(w, "%s\t%s\n", "Tailscale IPv4", m.Ipv4)
 		fmt.Fprintf(w, "%s\t%s\n", "Tailscale IPv6", m.Ipv6)
 		fmt.Fprintf(w, "%s\t%s\n", "Last seen", lastSeen)
@@ -102,6 +116,14 @@ func getMachineCommand() *cobra.Command {
 			}
 		}
 
+		for i, t := range m.Aliases {
+			if i == 0 {
+				fmt.Fprintf(w, "%s\t%s\n", "DNS aliases", t)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\n", "", t)
+			}
+		}
+
 		for i, e := range m.ClientConnectivity.Endpoints {
 			if i == 0 {
 				fmt.Fprintf(w, "%s\t%s\n", "Endpoints", e)
@@ -110,6 +132,14 @@ func getMachineCommand() *cobra.Command {
 			}
 		}
 
+		if m.ClientConnectivity.PreferredDerp != 0 {
+			fmt.Fprintf(w, "%s\t%d\n", "Preferred DERP", m.ClientConnectivity.PreferredDerp)
+		}
+
+		if m.ClientConnectivity.MappingVariesByDestIp {
+			fmt.Fprintf(w, "%s\t%s\n", "NAT", "varies by destination (hard NAT)")
+		}
+
 		for i, t := range m.AdvertisedRoutes {
 			if i == 0 {
 				fmt.Fprintf(w, "%s\t%s\n", "Advertised routes", t)
@@ -227,15 +257,81 @@ func listMachinesCommand() *cobra.Command {
 		SilenceUsage: true,
 	})
 
+	var pageSize int32
+	var pageToken string
+	var tag string
+	var userID uint64
+	var connected bool
+	var expired bool
+	var authorized bool
+	var osName string
+	var namePrefix string
+	var seenWithin time.Duration
+	var notSeenWithin time.Duration
+	var orderBy string
+	var descending bool
+	var output string
+
+	command.Flags().Int32Var(&pageSize, "page-size", 0, "Maximum number of machines to list, all machines are listed when not set.")
+	command.Flags().StringVar(&pageToken, "page-token", "", "Token of the next page, as printed when listing the previous page.")
+	command.Flags().StringVar(&tag, "tag", "", "Only list machines with this tag.")
+	command.Flags().Uint64Var(&userID, "user-id", 0, "Only list machines of this user.")
+	command.Flags().BoolVar(&connected, "connected", false, "Only list connected machines, or disconnected machines with --connected=false.")
+	command.Flags().BoolVar(&expired, "expired", false, "Only list machines with an expired key, or with a valid key with --expired=false.")
+	command.Flags().BoolVar(&authorized, "authorized", false, "Only list authorized machines, or unauthorized machines with --authorized=false.")
+	command.Flags().StringVar(&osName, "os", "", "Only list machines running this operating system, e.g. linux.")
+	command.Flags().StringVar(&namePrefix, "name-prefix", "", "Only list machines with a name starting with this prefix.")
+	command.Flags().DurationVar(&seenWithin, "seen-within", 0, "Only list machines seen in this recent period, e.g. 24h.")
+	command.Flags().DurationVar(&notSeenWithin, "not-seen-within", 0, "Only list machines not seen in this recent period, e.g. 720h.")
+	command.Flags().StringVar(&orderBy, "order-by", "", "Order of the machines: name (default), last_seen or created_at.")
+	command.Flags().BoolVar(&descending, "desc", false, "List the machines in descending order.")
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format, 'wide' adds client and connectivity details.")
+
 	command.RunE = func(cmd *cobra.Command, args []string) error {
-		req := api.ListMachinesRequest{TailnetId: tc.TailnetID()}
+		req := api.ListMachinesRequest{
+			TailnetId:  tc.TailnetID(),
+			PageSize:   pageSize,
+			PageToken:  pageToken,
+			Tag:        tag,
+			UserId:     userID,
+			Os:         osName,
+			NamePrefix: namePrefix,
+			OrderBy:    orderBy,
+			Descending: descending,
+		}
+
+		if cmd.Flags().Changed("connected") {
+			req.Connected = &connected
+		}
+		if cmd.Flags().Changed("expired") {
+			req.Expired = &expired
+		}
+		if cmd.Flags().Changed("authorized") {
+			req.Authorized = &authorized
+		}
+		if seenWithin != 0 {
+			req.LastSeenAfter = timestamppb.New(time.Now().Add(-seenWithin))
+		}
+		if notSeenWithin != 0 {
+			req.LastSeenBefore = timestamppb.New(time.Now().Add(-notSeenWithin))
+		}
+
 		resp, err := tc.Client().ListMachines(cmd.Context(), connect.NewRequest(&req))
 
 		if err != nil {
 			return err
 		}
 
-		tbl := table.New("ID", "TAILNET", "NAME", "IPv4", "IPv6", "AUTHORIZED", "EPHEMERAL", "VERSION", "LAST_SEEN", "TAGS")
+		if output != "" && output != "wide" {
+			return fmt.Errorf("unsupported output format [%s]", output)
+		}
+
+		columns := []interface{}{"ID", "TAILNET", "NAME", "IPv4", "IPv6", "AUTHORIZED", "EPHEMERAL", "VERSION", "LAST_SEEN", "TAGS"}
+		if output == "wide" {
+			columns = append(columns, "OS", "OS_VERSION", "UPDATE_AVAILABLE", "DERP", "ENDPOINTS")
+		}
+
+		tbl := table.New(columns...)
 		for _, m := range resp.Msg.Machines {
 			var lastSeen = "N/A"
 			if m.Connected {
@@ -246,10 +342,16 @@ func listMachinesCommand() *cobra.Command {
 					lastSeen = mom.FromNow()
 				}
 			}
-			tbl.AddRow(m.Id, m.Tailnet.Name, m.Name, m.Ipv4, m.Ipv6, m.Authorized, m.Ephemeral, m.ClientVersion, lastSeen, strings.Join(m.Tags, ","))
+			row := []interface{}{m.Id, m.Tailnet.Name, m.Name, m.Ipv4, m.Ipv6, m.Authorized, m.Ephemeral, m.ClientVersion, lastSeen, strings.Join(m.Tags, ",")}
+			if output == "wide" {
+				row = append(row, m.Os, m.OsVersion, m.UpdateAvailable, m.ClientConnectivity.PreferredDerp, strings.Join(m.ClientConnectivity.Endpoints, ","))
+			}
+			tbl.AddRow(row...)
 		}
 		tbl.Print()
 
+		printNextPageToken(resp.Msg.NextPageToken)
+
 		return nil
 	}
 
@@ -454,6 +556,119 @@ func configureSetMachineKeyExpiryCommand(cmdTmpl *cobra.Command, disable bool) *
 	return command
 }
 
+func moveMachineCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "move",
+		Short:        "Move a given machine to another tailnet",
+		Example:      "ionscale machines move --machine-id 123456 --tailnet team-a",
+		SilenceUsage: true,
+	})
+
+	var machineID uint64
+
+	command.Flags().Uint64Var(&machineID, "machine-id", 0, "Machine ID")
+
+	_ = command.MarkFlagRequired("machine-id")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("tailnet") && !cmd.Flags().Changed("tailnet-id") {
+			return fmt.Errorf("flag --tailnet or --tailnet-id is required")
+		}
+
+		req := api.MoveMachineRequest{MachineId: machineID, TailnetId: tc.TailnetID()}
+		resp, err := tc.Client().MoveMachine(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Machine moved to tailnet %s as %s\n", resp.Msg.Machine.Tailnet.Name, resp.Msg.Machine.Name)
+
+		return nil
+	}
+
+	return command
+}
+
+func renameMachineCommand() *cobra.Command {
+	command, tc := prepareCommand(false, &cobra.Command{
+		Use:          "rename",
+		Short:        "Rename a given machine",
+		SilenceUsage: true,
+	})
+
+	var machineID uint64
+	var name string
+	var autoGenerate bool
+
+	command.Flags().Uint64Var(&machineID, "machine-id", 0, "Machine ID")
+	command.Flags().StringVar(&name, "name", "", "New name of the machine")
+	command.Flags().BoolVar(&autoGenerate, "auto-generate", false, "Generate the name from the hostname reported by the machine, and keep it up to date")
+
+	_ = command.MarkFlagRequired("machine-id")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		if name == "" && !autoGenerate {
+			return fmt.Errorf("flag --name or --auto-generate is required")
+		}
+
+		if name != "" && autoGenerate {
+			return fmt.Errorf("flags --name and --auto-generate are mutually exclusive")
+		}
+
+		req := api.RenameMachineRequest{MachineId: machineID, Name: name, AutoGenerateName: autoGenerate}
+		resp, err := tc.Client().RenameMachine(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Machine renamed to %s\n", resp.Msg.Machine.Name)
+
+		return nil
+	}
+
+	return command
+}
+
+func setMachineAliasesCommand() *cobra.Command {
+	command, tc := prepareCommand(false, &cobra.Command{
+		Use:          "set-aliases",
+		Short:        "Set the DNS aliases of a given machine",
+		SilenceUsage: true,
+	})
+
+	var machineID uint64
+	var aliases []string
+
+	command.Flags().Uint64Var(&machineID, "machine-id", 0, "Machine ID")
+	command.Flags().StringSliceVar(&aliases, "alias", []string{}, "List of DNS names resolving to the machine, an empty list removes all aliases")
+
+	_ = command.MarkFlagRequired("machine-id")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.SetMachineAliasesRequest{MachineId: machineID, Aliases: aliases}
+		resp, err := tc.Client().SetMachineAliases(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 8, 8, 0, '\t', 0)
+		defer w.Flush()
+
+		for i, t := range resp.Msg.Machine.Aliases {
+			if i == 0 {
+				fmt.Fprintf(w, "%s\t%s\n", "DNS aliases", t)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\n", "", t)
+			}
+		}
+
+		return nil
+	}
+
+	return command
+}
+
 func printMachinesRoutesResponse(msg *api.MachineRoutes) {
 	w := new(tabwriter.Writer)
 	w.Init(os.Stdout, 8, 8, 0, '\t', 0)
@@ -475,6 +690,14 @@ func printMachinesRoutesResponse(msg *api.MachineRoutes) {
 		}
 	}
 
+	header := "Primary routers"
+	for _, t := range msg.EnabledRoutes {
+		if primary, ok := msg.PrimaryRouters[t]; ok {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", header, t, primary)
+			header = ""
+		}
+	}
+
 	if msg.AdvertisedExitNode {
 		if msg.EnabledExitNode {
 			fmt.Fprintf(w, "%s\t%s\n", "Exit node", "enabled")
@@ -485,3 +708,95 @@ func printMachinesRoutesResponse(msg *api.MachineRoutes) {
 		fmt.Fprintf(w, "%s\t%s\n", "Exit node", "no")
 	}
 }
+
+func setMachineTagsCommand() *cobra.Command {
+	command, tc := prepareCommand(false, &cobra.Command{
+		Use:          "set-tags",
+		Short:        "Set the tags of a given machine",
+		SilenceUsage: true,
+	})
+
+	var machineID uint64
+	var tags []string
+
+	command.Flags().Uint64Var(&machineID, "machine-id", 0, "Machine ID")
+	command.Flags().StringSliceVar(&tags, "tag", []string{}, "List of tags for the machine, an empty list removes all tags and makes the current user the owner")
+
+	_ = command.MarkFlagRequired("machine-id")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.SetMachineTagsRequest{MachineId: machineID, Tags: tags}
+		resp, err := tc.Client().SetMachineTags(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 8, 8, 0, '\t', 0)
+		defer w.Flush()
+
+		fmt.Fprintf(w, "%s\t%s\n", "User", resp.Msg.Machine.User.Name)
+
+		for i, t := range resp.Msg.Machine.Tags {
+			if i == 0 {
+				fmt.Fprintf(w, "%s\t%s\n", "Tags", t)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\n", "", t)
+			}
+		}
+
+		return nil
+	}
+
+	return command
+}
+
+func machineVersionsCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "versions",
+		Short:        "Report the client versions running in the tailnet",
+		SilenceUsage: true,
+	})
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.ListMachinesRequest{TailnetId: tc.TailnetID()}
+		resp, err := tc.Client().ListMachines(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		type group struct {
+			version         string
+			updateAvailable bool
+			machines        []string
+		}
+
+		var groups []*group
+		byVersion := map[string]*group{}
+		for _, m := range resp.Msg.Machines {
+			version, _, _ := strings.Cut(m.ClientVersion, "-")
+			if version == "" {
+				version = "unknown"
+			}
+			g, ok := byVersion[version]
+			if !ok {
+				g = &group{version: version, updateAvailable: m.UpdateAvailable}
+				byVersion[version] = g
+				groups = append(groups, g)
+			}
+			g.machines = append(g.machines, m.Name)
+		}
+
+		sort.Slice(groups, func(i, j int) bool { return cmpver.Compare(groups[i].version, groups[j].version) > 0 })
+
+		tbl := table.New("VERSION", "UPDATE_AVAILABLE", "COUNT", "MACHINES")
+		for _, g := range groups {
+			tbl.AddRow(g.version, g.updateAvailable, len(g.machines), strings.Join(g.machines, ","))
+		}
+		tbl.Print()
+
+		return nil
+	}
+
+	return command
+}
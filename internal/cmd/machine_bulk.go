@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"time"
+)
+
+func bulkMachinesCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "bulk",
+		Short: "Apply an operation to all machines matching a selector",
+		Example: `ionscale machines bulk expire --tailnet example --user-id 123456
+ionscale machines bulk delete --tailnet example --tag tag:ci --not-seen-within 24h --dry-run`,
+		SilenceUsage: true,
+	}
+
+	command.AddCommand(bulkExpireMachinesCommand())
+	command.AddCommand(bulkDeleteMachinesCommand())
+	command.AddCommand(bulkAuthorizeMachinesCommand())
+	command.AddCommand(bulkEnableMachineRoutesCommand())
+
+	return command
+}
+
+type machineSelectorFlags struct {
+	userID        uint64
+	tag           string
+	name          string
+	seenWithin    time.Duration
+	notSeenWithin time.Duration
+	dryRun        bool
+}
+
+func (f *machineSelectorFlags) register(command *cobra.Command) {
+	command.Flags().Uint64Var(&f.userID, "user-id", 0, "Select the machines of this user.")
+	command.Flags().StringVar(&f.tag, "tag", "", "Select the machines with this tag.")
+	command.Flags().StringVar(&f.name, "name", "", "Select the machines with a name matching this glob pattern, e.g. 'ci-*'.")
+	command.Flags().DurationVar(&f.seenWithin, "seen-within", 0, "Select the machines seen in this recent period, e.g. 24h.")
+	command.Flags().DurationVar(&f.notSeenWithin, "not-seen-within", 0, "Select the machines not seen in this recent period, e.g. 720h.")
+	command.Flags().BoolVar(&f.dryRun, "dry-run", false, "List the selected machines without applying the operation.")
+}
+
+func (f *machineSelectorFlags) selector(tailnetID uint64) *api.MachineSelector {
+	selector := &api.MachineSelector{
+		TailnetId: tailnetID,
+		UserId:    f.userID,
+		Tag:       f.tag,
+		Name:      f.name,
+	}
+	if f.seenWithin != 0 {
+		selector.LastSeenAfter = timestamppb.New(time.Now().Add(-f.seenWithin))
+	}
+	if f.notSeenWithin != 0 {
+		selector.LastSeenBefore = timestamppb.New(time.Now().Add(-f.notSeenWithin))
+	}
+	return selector
+}
+
+func bulkExpireMachinesCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "expire",
+		Short:        "Expire the selected machines",
+		SilenceUsage: true,
+	})
+
+	var flags machineSelectorFlags
+	flags.register(command)
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.BulkExpireMachinesRequest{Selector: flags.selector(tc.TailnetID()), DryRun: flags.dryRun}
+		resp, err := tc.Client().BulkExpireMachines(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		printBulkMachines("expired", flags.dryRun, resp.Msg.Machines)
+
+		return nil
+	}
+
+	return command
+}
+
+func bulkDeleteMachinesCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "delete",
+		Short:        "Delete the selected machines",
+		SilenceUsage: true,
+	})
+
+	var flags machineSelectorFlags
+	flags.register(command)
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.BulkDeleteMachinesRequest{Selector: flags.selector(tc.TailnetID()), DryRun: flags.dryRun}
+		resp, err := tc.Client().BulkDeleteMachines(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		printBulkMachines("deleted", flags.dryRun, resp.Msg.Machines)
+
+		return nil
+	}
+
+	return command
+}
+
+func bulkAuthorizeMachinesCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "authorize",
+		Short:        "Authorize the selected machines",
+		SilenceUsage: true,
+	})
+
+	var flags machineSelectorFlags
+	flags.register(command)
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.BulkAuthorizeMachinesRequest{Selector: flags.selector(tc.TailnetID()), DryRun: flags.dryRun}
+		resp, err := tc.Client().BulkAuthorizeMachines(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		printBulkMachines("authorized", flags.dryRun, resp.Msg.Machines)
+
+		return nil
+	}
+
+	return command
+}
+
+func bulkEnableMachineRoutesCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "enable-routes",
+		Short:        "Enable the advertised routes of the selected machines",
+		SilenceUsage: true,
+	})
+
+	var flags machineSelectorFlags
+	var routes []string
+
+	flags.register(command)
+	command.Flags().StringSliceVar(&routes, "routes", []string{}, "The routes to enable when advertised, all advertised routes when not set.")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.BulkEnableMachineRoutesRequest{Selector: flags.selector(tc.TailnetID()), Routes: routes, DryRun: flags.dryRun}
+		resp, err := tc.Client().BulkEnableMachineRoutes(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		printBulkMachines("updated", flags.dryRun, resp.Msg.Machines)
+
+		return nil
+	}
+
+	return command
+}
+
+func printBulkMachines(action string, dryRun bool, machines []*api.Machine) {
+	if len(machines) == 0 {
+		fmt.Println("No machines selected")
+		return
+	}
+
+	tbl := table.New("ID", "NAME", "USER", "TAGS", "ROUTES")
+	for _, m := range machines {
+		tbl.AddRow(m.Id, m.Name, m.User.Name, strings.Join(m.Tags, ","), strings.Join(m.EnabledRoutes, ","))
+	}
+	tbl.Print()
+
+	if dryRun {
+		fmt.Printf("\n%d machine(s) would be %s, no changes were made\n", len(machines), action)
+	} else {
+		fmt.Printf("\n%d machine(s) %s\n", len(machines), action)
+	}
+}
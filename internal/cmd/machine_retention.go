@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func getMachineRetentionCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "get-machine-retention",
+		Short:        "Get the retention policy of inactive machines",
+		SilenceUsage: true,
+	})
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.GetMachineRetentionPolicyRequest{TailnetId: tc.TailnetID()}
+		resp, err := tc.Client().GetMachineRetentionPolicy(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		printMachineRetentionRules(resp.Msg.Rules)
+
+		return nil
+	}
+
+	return command
+}
+
+func setMachineRetentionCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:   "set-machine-retention",
+		Short: "Set the retention policy of inactive machines",
+		Example: `ionscale tailnet set-machine-retention --tailnet example --rule delete:untagged:90d
+ionscale tailnet set-machine-retention --tailnet example --rule expire:tag:server:14d --dry-run`,
+		SilenceUsage: true,
+	})
+
+	var rules []string
+	var dryRun bool
+
+	command.Flags().StringArrayVar(&rules, "rule", []string{}, "A rule as <action>:<selector>:<inactive for>, e.g. delete:untagged:90d. The selector is *, tagged, untagged or a tag. Omit to remove the policy.")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "List the machines the rules would apply to, without changing the policy")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		var apiRules []*api.MachineRetentionRule
+		for _, r := range rules {
+			rule, err := parseMachineRetentionRule(r)
+			if err != nil {
+				return err
+			}
+			apiRules = append(apiRules, rule)
+		}
+
+		if dryRun {
+			req := api.PreviewMachineRetentionPolicyRequest{TailnetId: tc.TailnetID(), Rules: apiRules}
+			resp, err := tc.Client().PreviewMachineRetentionPolicy(cmd.Context(), connect.NewRequest(&req))
+			if err != nil {
+				return err
+			}
+
+			printMachineRetentionCandidates(resp.Msg.Machines)
+
+			return nil
+		}
+
+		req := api.SetMachineRetentionPolicyRequest{TailnetId: tc.TailnetID(), Rules: apiRules}
+		resp, err := tc.Client().SetMachineRetentionPolicy(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		printMachineRetentionRules(resp.Msg.Rules)
+
+		return nil
+	}
+
+	return command
+}
+
+func previewMachineRetentionCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "preview-machine-retention",
+		Short:        "List the machines the current retention policy applies to",
+		SilenceUsage: true,
+	})
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.PreviewMachineRetentionPolicyRequest{TailnetId: tc.TailnetID()}
+		resp, err := tc.Client().PreviewMachineRetentionPolicy(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		printMachineRetentionCandidates(resp.Msg.Machines)
+
+		return nil
+	}
+
+	return command
+}
+
+func parseMachineRetentionRule(value string) (*api.MachineRetentionRule, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid rule '%s', expected <action>:<selector>:<inactive for>", value)
+	}
+
+	inactiveFor, err := parseDays(parts[len(parts)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule '%s': %w", value, err)
+	}
+
+	return &api.MachineRetentionRule{
+		Action:      parts[0],
+		Selector:    strings.Join(parts[1:len(parts)-1], ":"),
+		InactiveFor: durationpb.New(inactiveFor),
+	}, nil
+}
+
+// parseDays parses a duration, accepting a number of days like 90d next to the regular units.
+func parseDays(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s'", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func printMachineRetentionRules(rules []*api.MachineRetentionRule) {
+	if len(rules) == 0 {
+		fmt.Println("No machine retention policy configured")
+		return
+	}
+
+	tbl := table.New("ACTION", "SELECTOR", "INACTIVE FOR")
+	for _, r := range rules {
+		tbl.AddRow(r.Action, r.Selector, formatDays(r.InactiveFor.AsDuration()))
+	}
+	tbl.Print()
+}
+
+func printMachineRetentionCandidates(machines []*api.MachineRetentionCandidate) {
+	tbl := table.New("ID", "NAME", "ACTION", "LAST SEEN")
+	for _, m := range machines {
+		var lastSeen string
+		if m.LastSeen != nil {
+			lastSeen = m.LastSeen.AsTime().Format(time.RFC3339)
+		}
+		tbl.AddRow(m.MachineId, m.Name, m.Action, lastSeen)
+	}
+	tbl.Print()
+}
+
+func formatDays(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+func machineShareCommands() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "share",
+		Short: "Share machines with users or tailnets",
+		Example: `ionscale machines share create --machine-id 123456 --login-name john@example.com
+ionscale machines share create --machine-id 123456 --tailnet team-b
+ionscale machines share accept --code 9f3KbQz1LmXwPc7R`,
+		SilenceUsage: true,
+	}
+
+	command.AddCommand(createMachineShareCommand())
+	command.AddCommand(listMachineSharesCommand())
+	command.AddCommand(acceptMachineShareCommand())
+	command.AddCommand(deleteMachineShareCommand())
+
+	return command
+}
+
+func createMachineShareCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "create",
+		Short:        "Invite a user, or a tailnet with --tailnet, to access a given machine",
+		SilenceUsage: true,
+	})
+
+	var machineID uint64
+	var loginName string
+
+	command.Flags().Uint64Var(&machineID, "machine-id", 0, "Machine ID")
+	command.Flags().StringVar(&loginName, "login-name", "", "Only the user with this login name can accept the invite.")
+
+	_ = command.MarkFlagRequired("machine-id")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.CreateMachineShareRequest{MachineId: machineID, LoginName: loginName}
+		if cmd.Flags().Changed("tailnet") || cmd.Flags().Changed("tailnet-id") {
+			req.TailnetId = tc.TailnetID()
+		}
+
+		resp, err := tc.Client().CreateMachineShare(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Share the following link or code with the recipient:\n\n  %s\n  %s\n\n", resp.Msg.Share.InviteUrl, resp.Msg.Share.InviteCode)
+
+		return nil
+	}
+
+	return command
+}
+
+func listMachineSharesCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "list",
+		Short:        "List the shares of a given machine, or the machines shared with a tailnet",
+		SilenceUsage: true,
+	})
+
+	var machineID uint64
+
+	command.Flags().Uint64Var(&machineID, "machine-id", 0, "List the shares of this machine instead of the machines shared with the tailnet.")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.ListMachineSharesRequest{MachineId: machineID}
+		if machineID == 0 {
+			req.TailnetId = tc.TailnetID()
+		}
+
+		resp, err := tc.Client().ListMachineShares(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		tbl := table.New("ID", "MACHINE", "TAILNET", "INVITE", "RECIPIENT_TAILNET", "RECIPIENT_USER", "ACCEPTED_AT")
+		for _, s := range resp.Msg.Shares {
+			invite := s.InviteLoginName
+			if s.InviteTailnetId != 0 {
+				invite = fmt.Sprintf("tailnet %d", s.InviteTailnetId)
+			}
+
+			var recipientTailnet, recipientUser, acceptedAt = "", "", ""
+			if s.RecipientTailnet != nil {
+				recipientTailnet = s.RecipientTailnet.Name
+			}
+			if s.RecipientUser != nil {
+				recipientUser = s.RecipientUser.Name
+			}
+			if s.AcceptedAt != nil {
+				acceptedAt = s.AcceptedAt.AsTime().Format(time.RFC3339)
+			}
+
+			tbl.AddRow(s.Id, s.Machine.Name, s.Tailnet.Name, invite, recipientTailnet, recipientUser, acceptedAt)
+		}
+		tbl.Print()
+
+		return nil
+	}
+
+	return command
+}
+
+func acceptMachineShareCommand() *cobra.Command {
+	command, tc := prepareCommand(false, &cobra.Command{
+		Use:          "accept",
+		Short:        "Accept an invite to access a shared machine",
+		SilenceUsage: true,
+	})
+
+	var code string
+
+	command.Flags().StringVar(&code, "code", "", "Invite code")
+
+	_ = command.MarkFlagRequired("code")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.AcceptMachineShareRequest{Code: code}
+		resp, err := tc.Client().AcceptMachineShare(cmd.Context(), connect.NewRequest(&req))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Machine %s shared with tailnet %s\n", resp.Msg.Share.Machine.Name, resp.Msg.Share.RecipientTailnet.Name)
+
+		return nil
+	}
+
+	return command
+}
+
+func deleteMachineShareCommand() *cobra.Command {
+	command, tc := prepareCommand(false, &cobra.Command{
+		Use:          "delete",
+		Short:        "Revoke a share, or an invite that is not accepted yet",
+		SilenceUsage: true,
+	})
+
+	var shareID uint64
+
+	command.Flags().Uint64Var(&shareID, "share-id", 0, "Share ID")
+
+	_ = command.MarkFlagRequired("share-id")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.DeleteMachineShareRequest{ShareId: shareID}
+		if _, err := tc.Client().DeleteMachineShare(cmd.Context(), connect.NewRequest(&req)); err != nil {
+			return err
+		}
+
+		fmt.Println("Share deleted.")
+
+		return nil
+	}
+
+	return command
+}
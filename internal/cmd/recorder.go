@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"fmt"
+	"github.com/dustin/go-humanize"
+	"github.com/jsiebens/ionscale/internal/config"
 	"github.com/jsiebens/ionscale/pkg/ssh"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"os"
+	"strings"
 )
 
 func recorderCommand() *cobra.Command {
@@ -19,10 +26,72 @@ func recorderCommand() *cobra.Command {
 	command.Flags().StringVar(&t.Dir, "dst", "", "Directory where recordings will be saved.")
 	command.Flags().StringVar(&t.AuthKey, "auth-key", "", "")
 	command.Flags().StringVar(&t.Hostname, "hostname", "recorder", "")
+	command.Flags().Uint16Var(&t.Port, "port", 80, "Port on which the recorder receives recordings, advertised to the control server")
+
+	var retentionMaxSize string
+	var apiKeyFile string
+
+	command.Flags().StringVar(&apiKeyFile, "api-key-file", "", "File containing the key to access the API and web pages to search, download and play recordings. The key can also be specified via the IONSCALE_RECORDER_API_KEY environment variable, the API is disabled when no key is set")
+
+	command.Flags().StringVar(&t.Storage, "storage", ssh.StorageLocal, "Storage backend for the recordings: local or s3")
+	command.Flags().DurationVar(&t.RetentionMaxAge, "retention-max-age", 0, "Remove recordings older than this duration, e.g. 720h (0 keeps all recordings)")
+	command.Flags().StringVar(&retentionMaxSize, "retention-max-size", "", "Remove the oldest recordings when their total size exceeds this value, e.g. 10GB")
+	command.Flags().StringVar(&t.S3.Bucket, "s3-bucket", "", "Name of the S3 bucket where recordings will be saved")
+	command.Flags().StringVar(&t.S3.Prefix, "s3-prefix", "", "Key prefix for the recordings in the S3 bucket")
+	command.Flags().StringVar(&t.S3.Endpoint, "s3-endpoint", "", "Endpoint of an S3 compatible object storage, e.g. http://minio:9000")
+	command.Flags().StringVar(&t.S3.Region, "s3-region", "", "Region of the S3 bucket")
+	command.Flags().BoolVar(&t.S3.PathStyle, "s3-path-style", false, "Use path-style addressing, required by most S3 compatible object storages")
+
+	command.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if retentionMaxSize != "" {
+			size, err := humanize.ParseBytes(retentionMaxSize)
+			if err != nil {
+				return fmt.Errorf("invalid value for flag --retention-max-size: %w", err)
+			}
+			t.RetentionMaxSize = size
+		}
+
+		t.ApiKey = config.GetString("IONSCALE_RECORDER_API_KEY", "")
+		if apiKeyFile != "" {
+			content, err := os.ReadFile(apiKeyFile)
+			if err != nil {
+				return fmt.Errorf("unable to read api key file: %w", err)
+			}
+			t.ApiKey = strings.TrimSpace(string(content))
+		}
+
+		if t.Storage == ssh.StorageS3 && t.S3.Bucket == "" {
+			return fmt.Errorf("flag --s3-bucket is required when using s3 storage")
+		}
+
+		return nil
+	}
 
 	command.RunE = func(command *cobra.Command, args []string) error {
+		if err := setupRecorderLogging(); err != nil {
+			return err
+		}
+
 		return ssh.Start(command.Context(), t)
 	}
 
 	return command
 }
+
+func setupRecorderLogging() error {
+	pc := zap.NewProductionConfig()
+	pc.DisableStacktrace = true
+	pc.OutputPaths = []string{"stdout"}
+	pc.Encoding = "console"
+	pc.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	pc.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	logger, err := pc.Build()
+	if err != nil {
+		return err
+	}
+
+	zap.ReplaceGlobals(logger.Named("recorder"))
+
+	return nil
+}
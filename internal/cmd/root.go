@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +18,7 @@ func Command() *cobra.Command {
 	rootCmd.AddCommand(userCommands())
 	rootCmd.AddCommand(systemCommand())
 	rootCmd.AddCommand(recorderCommand())
+	rootCmd.AddCommand(sshCommands())
 
 	return rootCmd
 }
@@ -30,3 +32,9 @@ func rootCommand() *cobra.Command {
 		Use: "ionscale",
 	}
 }
+
+func printNextPageToken(token string) {
+	if token != "" {
+		fmt.Printf("\nMore results available, continue with --page-token %s\n", token)
+	}
+}
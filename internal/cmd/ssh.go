@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func sshCommands() *cobra.Command {
+	command := &cobra.Command{
+		Use:          "ssh",
+		Short:        "Manage Tailscale SSH requests and session events",
+		SilenceUsage: true,
+	}
+
+	command.AddCommand(approveSSHRequestCommand())
+	command.AddCommand(listSSHSessionEventsCommand())
+
+	return command
+}
+
+func approveSSHRequestCommand() *cobra.Command {
+	command, tc := prepareCommand(false, &cobra.Command{
+		Use:          "approve",
+		Short:        "Approve or reject an SSH session waiting for the approval of a second person",
+		SilenceUsage: true,
+	})
+
+	var key string
+	var reject bool
+
+	command.Flags().StringVar(&key, "key", "", "Key of the SSH request, as shown in the approval URL.")
+	command.Flags().BoolVar(&reject, "reject", false, "Reject the SSH request instead of approving it.")
+
+	_ = command.MarkFlagRequired("key")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := api.ApproveSSHRequestRequest{Key: key, Reject: reject}
+		if _, err := tc.Client().ApproveSSHRequest(cmd.Context(), connect.NewRequest(&req)); err != nil {
+			return err
+		}
+
+		if reject {
+			fmt.Println("SSH request rejected.")
+		} else {
+			fmt.Println("SSH request approved.")
+		}
+
+		return nil
+	}
+
+	return command
+}
+
+func listSSHSessionEventsCommand() *cobra.Command {
+	command, tc := prepareCommand(true, &cobra.Command{
+		Use:          "events",
+		Short:        "List the SSH session events of a tailnet, most recent first",
+		SilenceUsage: true,
+	})
+
+	var machineID uint64
+	var since time.Duration
+	var limit uint32
+
+	command.Flags().Uint64Var(&machineID, "machine-id", 0, "Only list the events where this machine is the source or the destination.")
+	command.Flags().DurationVar(&since, "since", 0, "Only list the events of this recent period, e.g. 168h for the last week.")
+	command.Flags().Uint32Var(&limit, "limit", 100, "Maximum number of events to list, 0 lists all events.")
+
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		req := &api.ListSSHSessionEventsRequest{TailnetId: tc.TailnetID(), MachineId: machineID, Limit: limit}
+		if since != 0 {
+			req.Since = timestamppb.New(time.Now().Add(-since))
+		}
+
+		resp, err := tc.Client().ListSSHSessionEvents(cmd.Context(), connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+
+		tbl := table.New("TIME", "TYPE", "SOURCE", "DESTINATION", "SSH_USER", "LOCAL_USER", "DECISION", "RECORDING")
+		for _, e := range resp.Msg.Events {
+			tbl.AddRow(e.CreatedAt.AsTime().Local().Format("2006-01-02 15:04:05"), e.Type, e.SrcMachineName, e.DstMachineName, e.SshUser, e.LocalUser, e.Decision, e.Recording)
+		}
+		tbl.Print()
+
+		return nil
+	}
+
+	return command
+}
@@ -45,6 +45,9 @@ func tailnetCommand() *cobra.Command {
 	command.AddCommand(getDERPMap())
 	command.AddCommand(setDERPMap())
 	command.AddCommand(resetDERPMap())
+	command.AddCommand(getMachineRetentionCommand())
+	command.AddCommand(setMachineRetentionCommand())
+	command.AddCommand(previewMachineRetentionCommand())
 
 	return command
 }
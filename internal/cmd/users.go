@@ -29,8 +29,27 @@ func listUsersCommand() *cobra.Command {
 		SilenceUsage: true,
 	})
 
+	var pageSize int32
+	var pageToken string
+	var namePrefix string
+	var orderBy string
+	var descending bool
+
+	command.Flags().Int32Var(&pageSize, "page-size", 0, "Maximum number of users to list, all users are listed when not set.")
+	command.Flags().StringVar(&pageToken, "page-token", "", "Token of the next page, as printed when listing the previous page.")
+	command.Flags().StringVar(&namePrefix, "name-prefix", "", "Only list users with a name starting with this prefix.")
+	command.Flags().StringVar(&orderBy, "order-by", "", "Order of the users: id (default) or name.")
+	command.Flags().BoolVar(&descending, "desc", false, "List the users in descending order.")
+
 	command.RunE = func(cmd *cobra.Command, args []string) error {
-		req := api.ListUsersRequest{TailnetId: tc.TailnetID()}
+		req := api.ListUsersRequest{
+			TailnetId:  tc.TailnetID(),
+			PageSize:   pageSize,
+			PageToken:  pageToken,
+			NamePrefix: namePrefix,
+			OrderBy:    orderBy,
+			Descending: descending,
+		}
 		resp, err := tc.Client().ListUsers(cmd.Context(), connect.NewRequest(&req))
 
 		if err != nil {
@@ -43,6 +62,8 @@ func listUsersCommand() *cobra.Command {
 		}
 		tbl.Print()
 
+		printNextPageToken(resp.Msg.NextPageToken)
+
 		return nil
 	}
 
@@ -14,14 +14,24 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"tailscale.com/tailcfg"
 	tkey "tailscale.com/types/key"
 	"time"
 )
 
 const (
-	defaultKeepAliveInterval = 1 * time.Minute
-	defaultMagicDNSSuffix    = "ionscale.net"
+	defaultKeepAliveInterval     = 1 * time.Minute
+	defaultMagicDNSSuffix        = "ionscale.net"
+	defaultDNSPropagationTimeout = 5 * time.Minute
+	defaultSSHApprovalTimeout    = 10 * time.Minute
+)
+
+const (
+	NotificationChannelSMTP    = "smtp"
+	NotificationChannelWebhook = "webhook"
+	NotificationChannelSlack   = "slack"
 )
 
 var (
@@ -119,8 +129,14 @@ func defaultConfig() *Config {
 		PollNet: PollNet{
 			KeepAliveInterval: defaultKeepAliveInterval,
 		},
+		Auth: Auth{
+			SSHApprovalTimeout: defaultSSHApprovalTimeout,
+		},
 		DNS: DNS{
 			MagicDNSSuffix: defaultMagicDNSSuffix,
+			Provider: DNSProvider{
+				PropagationTimeout: defaultDNSPropagationTimeout,
+			},
 		},
 		DERP: DERP{
 			Server: DERPServer{
@@ -130,6 +146,11 @@ func defaultConfig() *Config {
 				RegionName: "ionscale Embedded DERP",
 			},
 		},
+		Notifications: Notifications{
+			KeyExpiry: KeyExpiryNotifications{
+				Thresholds: []time.Duration{7 * 24 * time.Hour, 24 * time.Hour},
+			},
+		},
 		Logging: Logging{
 			Level: "info",
 		},
@@ -143,19 +164,21 @@ type ServerKeys struct {
 }
 
 type Config struct {
-	ListenAddr        string   `yaml:"listen_addr,omitempty" env:"LISTEN_ADDR"`
-	StunListenAddr    string   `yaml:"stun_listen_addr,omitempty" env:"STUN_LISTEN_ADDR"`
-	MetricsListenAddr string   `yaml:"metrics_listen_addr,omitempty" env:"METRICS_LISTEN_ADDR"`
-	PublicAddr        string   `yaml:"public_addr,omitempty" env:"PUBLIC_ADDR"`
-	StunPublicAddr    string   `yaml:"stun_public_addr,omitempty" env:"STUN_PUBLIC_ADDR"`
-	Tls               Tls      `yaml:"tls,omitempty" envPrefix:"TLS_"`
-	PollNet           PollNet  `yaml:"poll_net,omitempty" envPrefix:"POLL_NET_"`
-	Keys              Keys     `yaml:"keys,omitempty" envPrefix:"KEYS_"`
-	Database          Database `yaml:"database,omitempty" envPrefix:"DB_"`
-	Auth              Auth     `yaml:"auth,omitempty" envPrefix:"AUTH_"`
-	DNS               DNS      `yaml:"dns,omitempty"`
-	DERP              DERP     `yaml:"derp,omitempty" envPrefix:"DERP_"`
-	Logging           Logging  `yaml:"logging,omitempty" envPrefix:"LOGGING_"`
+	ListenAddr        string         `yaml:"listen_addr,omitempty" env:"LISTEN_ADDR"`
+	StunListenAddr    string         `yaml:"stun_listen_addr,omitempty" env:"STUN_LISTEN_ADDR"`
+	MetricsListenAddr string         `yaml:"metrics_listen_addr,omitempty" env:"METRICS_LISTEN_ADDR"`
+	PublicAddr        string         `yaml:"public_addr,omitempty" env:"PUBLIC_ADDR"`
+	StunPublicAddr    string         `yaml:"stun_public_addr,omitempty" env:"STUN_PUBLIC_ADDR"`
+	Tls               Tls            `yaml:"tls,omitempty" envPrefix:"TLS_"`
+	PollNet           PollNet        `yaml:"poll_net,omitempty" envPrefix:"POLL_NET_"`
+	Keys              Keys           `yaml:"keys,omitempty" envPrefix:"KEYS_"`
+	Database          Database       `yaml:"database,omitempty" envPrefix:"DB_"`
+	Auth              Auth           `yaml:"auth,omitempty" envPrefix:"AUTH_"`
+	DNS               DNS            `yaml:"dns,omitempty"`
+	DERP              DERP           `yaml:"derp,omitempty" envPrefix:"DERP_"`
+	Notifications     Notifications  `yaml:"notifications,omitempty"`
+	ClientVersions    ClientVersions `yaml:"client_versions,omitempty" envPrefix:"CLIENT_VERSIONS_"`
+	Logging           Logging        `yaml:"logging,omitempty" envPrefix:"LOGGING_"`
 
 	PublicUrl *url.URL `yaml:"-"`
 
@@ -201,8 +224,9 @@ type Keys struct {
 }
 
 type Auth struct {
-	Provider          AuthProvider      `yaml:"provider,omitempty" envPrefix:"PROVIDER_"`
-	SystemAdminPolicy SystemAdminPolicy `yaml:"system_admins"`
+	Provider           AuthProvider      `yaml:"provider,omitempty" envPrefix:"PROVIDER_"`
+	SystemAdminPolicy  SystemAdminPolicy `yaml:"system_admins"`
+	SSHApprovalTimeout time.Duration     `yaml:"ssh_approval_timeout,omitempty" env:"SSH_APPROVAL_TIMEOUT"`
 }
 
 type AuthProvider struct {
@@ -218,9 +242,10 @@ type DNS struct {
 }
 
 type DNSProvider struct {
-	Name          string            `yaml:"name"`
-	Zone          string            `yaml:"zone"`
-	Configuration map[string]string `yaml:"config"`
+	Name               string            `yaml:"name"`
+	Zone               string            `yaml:"zone"`
+	Configuration      map[string]string `yaml:"config"`
+	PropagationTimeout time.Duration     `yaml:"propagation_timeout"`
 }
 
 type SystemAdminPolicy struct {
@@ -241,6 +266,38 @@ type DERPServer struct {
 	RegionName string `yaml:"region_name,omitempty"`
 }
 
+type Notifications struct {
+	KeyExpiry KeyExpiryNotifications `yaml:"key_expiry,omitempty"`
+	Channels  []NotificationChannel  `yaml:"channels,omitempty"`
+}
+
+// ClientVersions are the latest released client versions per release track, machines running an older version have an update available.
+type ClientVersions struct {
+	Stable   string `yaml:"stable,omitempty" env:"STABLE"`
+	Unstable string `yaml:"unstable,omitempty" env:"UNSTABLE"`
+}
+
+type KeyExpiryNotifications struct {
+	// Thresholds are the remaining key lifetimes at which a machine is notified, once per threshold.
+	Thresholds []time.Duration `yaml:"thresholds,omitempty"`
+}
+
+type NotificationChannel struct {
+	Type string `yaml:"type"`
+
+	// webhook and slack
+	Url string `yaml:"url,omitempty"`
+
+	// smtp
+	Host        string   `yaml:"host,omitempty"`
+	Port        int      `yaml:"port,omitempty"`
+	Username    string   `yaml:"username,omitempty"`
+	Password    string   `yaml:"password,omitempty"`
+	From        string   `yaml:"from,omitempty"`
+	To          []string `yaml:"to,omitempty"`
+	NotifyOwner bool     `yaml:"notify_owner,omitempty"`
+}
+
 func (c *Config) Validate() (*Config, error) {
 	publicWebUrl, webHost, webPort, err := validatePublicAddr(c.PublicAddr)
 	if err != nil {
@@ -261,9 +318,79 @@ func (c *Config) Validate() (*Config, error) {
 		c.stunPort = stunPort
 	}
 
+	if err := c.DNS.Provider.validate(); err != nil {
+		return nil, fmt.Errorf("dns provider: %w", err)
+	}
+
+	if err := c.Notifications.validate(); err != nil {
+		return nil, fmt.Errorf("notifications: %w", err)
+	}
+
+	if err := c.ClientVersions.validate(); err != nil {
+		return nil, fmt.Errorf("client versions: %w", err)
+	}
+
 	return c, nil
 }
 
+func (p *DNSProvider) validate() error {
+	if p.PropagationTimeout <= 0 {
+		return fmt.Errorf("invalid propagation timeout [%s]", p.PropagationTimeout)
+	}
+	return nil
+}
+
+var clientVersionPattern = regexp.MustCompile(`^\d+\.(\d+)\.\d+$`)
+
+func (c *ClientVersions) validate() error {
+	// unstable releases of the client have an odd minor version
+	for _, v := range []struct {
+		track    string
+		version  string
+		unstable bool
+	}{{"stable", c.Stable, false}, {"unstable", c.Unstable, true}} {
+		if v.version == "" {
+			continue
+		}
+		m := clientVersionPattern.FindStringSubmatch(v.version)
+		if m == nil {
+			return fmt.Errorf("invalid %s version [%s]", v.track, v.version)
+		}
+		if minor, _ := strconv.Atoi(m[1]); (minor%2 == 1) != v.unstable {
+			return fmt.Errorf("version [%s] is not on the %s release track", v.version, v.track)
+		}
+	}
+	return nil
+}
+
+func (n *Notifications) validate() error {
+	for _, t := range n.KeyExpiry.Thresholds {
+		if t <= 0 {
+			return fmt.Errorf("invalid key expiry threshold [%s]", t)
+		}
+	}
+
+	for i, ch := range n.Channels {
+		switch ch.Type {
+		case NotificationChannelWebhook, NotificationChannelSlack:
+			if len(ch.Url) == 0 {
+				return fmt.Errorf("channel %d: url is required", i)
+			}
+		case NotificationChannelSMTP:
+			if len(ch.Host) == 0 || len(ch.From) == 0 {
+				return fmt.Errorf("channel %d: host and from are required", i)
+			}
+			if len(ch.To) == 0 && !ch.NotifyOwner {
+				return fmt.Errorf("channel %d: no recipients configured", i)
+			}
+		default:
+			return fmt.Errorf("channel %d: unknown type [%s]", i, ch.Type)
+		}
+	}
+
+	return nil
+}
+
 func (c *Config) CreateUrl(format string, a ...interface{}) string {
 	path := fmt.Sprintf(format, a...)
 	u := url.URL{
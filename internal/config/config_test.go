@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestClientVersionsValidate(t *testing.T) {
+	valid := []ClientVersions{
+		{},
+		{Stable: "1.66.4"},
+		{Unstable: "1.67.10"},
+		{Stable: "1.66.4", Unstable: "1.67.10"},
+	}
+	for _, v := range valid {
+		require.NoError(t, v.validate())
+	}
+
+	invalid := []ClientVersions{
+		{Stable: "latest"},
+		{Stable: "1.66"},
+		{Stable: "1.66.4-t1234"},
+		{Stable: "1.67.10"},
+		{Unstable: "1.66.4"},
+	}
+	for _, v := range invalid {
+		require.Error(t, v.validate())
+	}
+}
+
+func TestDNSProviderValidate(t *testing.T) {
+	require.NoError(t, (&DNSProvider{PropagationTimeout: defaultDNSPropagationTimeout}).validate())
+	require.Error(t, (&DNSProvider{PropagationTimeout: 0}).validate())
+	require.Error(t, (&DNSProvider{PropagationTimeout: -time.Second}).validate())
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// RequestRecheckInterval is the interval at which a waiter re-reads a pending request without being notified,
+// it only covers notifications that were missed, e.g. while the connection relaying them between instances was down.
+const RequestRecheckInterval = 30 * time.Second
+
+// RequestNotifier is a publish/subscribe for state changes of pending requests.
+// The repository stays the source of truth, subscribers only re-read the request when woken up.
+type RequestNotifier interface {
+	Subscribe(topic string) (<-chan struct{}, func())
+	Notify(topic string)
+}
+
+func SSHActionRequestTopic(key string) string {
+	return "ssh-action/" + key
+}
+
+func RegistrationRequestTopic(machineKey string) string {
+	return "registration/" + machineKey
+}
+
+func AuthenticationRequestTopic(key string) string {
+	return "authentication/" + key
+}
+
+// NewRequestNotifier returns a notifier reaching the waiters of this ionscale instance only.
+func NewRequestNotifier() RequestNotifier {
+	return newRequestNotifier()
+}
+
+func newRequestNotifier() *requestNotifier {
+	return &requestNotifier{
+		subscribers: map[string]map[chan struct{}]struct{}{},
+	}
+}
+
+type requestNotifier struct {
+	sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+func (n *requestNotifier) Subscribe(topic string) (<-chan struct{}, func()) {
+	n.Lock()
+	defer n.Unlock()
+
+	ch := make(chan struct{}, 1)
+
+	if ss := n.subscribers[topic]; ss == nil {
+		n.subscribers[topic] = map[chan struct{}]struct{}{ch: {}}
+	} else {
+		ss[ch] = struct{}{}
+	}
+
+	unsubscribe := func() {
+		n.Lock()
+		defer n.Unlock()
+
+		if ss := n.subscribers[topic]; ss != nil {
+			delete(ss, ch)
+			if len(ss) == 0 {
+				delete(n.subscribers, topic)
+			}
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+func (n *requestNotifier) Notify(topic string) {
+	n.Lock()
+	defer n.Unlock()
+
+	for ch := range n.subscribers[topic] {
+		// a pending wakeup is enough, the subscriber re-reads the latest state anyway
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"time"
+)
+
+const requestNotifierChannel = "ionscale_requests"
+
+// NewPostgresRequestNotifier returns a notifier relaying the notifications through postgres LISTEN/NOTIFY,
+// so that the waiters of all ionscale instances sharing the database are woken up.
+func NewPostgresRequestNotifier(ctx context.Context, db *sql.DB, url string) RequestNotifier {
+	n := &pgRequestNotifier{
+		local: newRequestNotifier(),
+		db:    db,
+		url:   url,
+	}
+
+	go n.listen(ctx)
+
+	return n
+}
+
+type pgRequestNotifier struct {
+	local *requestNotifier
+	db    *sql.DB
+	url   string
+}
+
+func (n *pgRequestNotifier) Subscribe(topic string) (<-chan struct{}, func()) {
+	return n.local.Subscribe(topic)
+}
+
+func (n *pgRequestNotifier) Notify(topic string) {
+	// waiters of this instance are woken up right away, the notification received back from postgres is harmless
+	n.local.Notify(topic)
+
+	if _, err := n.db.ExecContext(context.Background(), "SELECT pg_notify($1, $2)", requestNotifierChannel, topic); err != nil {
+		zap.L().Error("unable to publish request notification", zap.String("topic", topic), zap.Error(err))
+	}
+}
+
+func (n *pgRequestNotifier) listen(ctx context.Context) {
+	backoff := time.Second
+
+	for {
+		err := n.receive(ctx, func() { backoff = time.Second })
+		if ctx.Err() != nil {
+			return
+		}
+
+		zap.L().Error("request notifications interrupted, reconnecting", zap.Duration("backoff", backoff), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, time.Minute)
+	}
+}
+
+func (n *pgRequestNotifier) receive(ctx context.Context, connected func()) error {
+	conn, err := pgx.Connect(ctx, n.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+requestNotifierChannel); err != nil {
+		return err
+	}
+
+	connected()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		n.local.Notify(notification.Payload)
+	}
+}
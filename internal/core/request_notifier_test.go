@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRequestNotifier_Notify(t *testing.T) {
+	n := NewRequestNotifier()
+
+	a1, unsubscribeA1 := n.Subscribe("a")
+	defer unsubscribeA1()
+	a2, unsubscribeA2 := n.Subscribe("a")
+	defer unsubscribeA2()
+	b, unsubscribeB := n.Subscribe("b")
+	defer unsubscribeB()
+
+	n.Notify("a")
+
+	assert.True(t, woken(a1, time.Second))
+	assert.True(t, woken(a2, time.Second))
+	assert.False(t, woken(b, 10*time.Millisecond))
+}
+
+func TestRequestNotifier_NotifyDoesNotBlock(t *testing.T) {
+	n := NewRequestNotifier()
+
+	ch, unsubscribe := n.Subscribe("a")
+	defer unsubscribe()
+
+	// pending wakeups are coalesced, a slow subscriber doesn't block the notifier
+	n.Notify("a")
+	n.Notify("a")
+	n.Notify("a")
+
+	assert.True(t, woken(ch, time.Second))
+	assert.False(t, woken(ch, 10*time.Millisecond))
+}
+
+func TestRequestNotifier_Unsubscribe(t *testing.T) {
+	n := NewRequestNotifier().(*requestNotifier)
+
+	ch, unsubscribe := n.Subscribe("a")
+	unsubscribe()
+
+	n.Notify("a")
+
+	assert.False(t, woken(ch, 10*time.Millisecond))
+	assert.Empty(t, n.subscribers)
+
+	// unsubscribing twice is harmless
+	unsubscribe()
+}
+
+func TestRequestNotifier_Timeout(t *testing.T) {
+	n := NewRequestNotifier()
+
+	ch, unsubscribe := n.Subscribe("a")
+	defer unsubscribe()
+
+	// without a notification, for example when it was missed while reconnecting to the database,
+	// the waiter falls back to re-reading the request at the recheck interval
+	tick := time.NewTicker(10 * time.Millisecond)
+	defer tick.Stop()
+
+	select {
+	case <-ch:
+		assert.Fail(t, "unexpected notification")
+	case <-tick.C:
+	}
+}
+
+// TestPostgresRequestNotifier runs against a postgres database when IONSCALE_TEST_POSTGRES_URL is set.
+func TestPostgresRequestNotifier(t *testing.T) {
+	url := os.Getenv("IONSCALE_TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("IONSCALE_TEST_POSTGRES_URL not set")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := sql.Open("pgx", url)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// two instances sharing the same database
+	a := NewPostgresRequestNotifier(ctx, db, url)
+	b := NewPostgresRequestNotifier(ctx, db, url)
+
+	ch, unsubscribe := b.Subscribe("a")
+	defer unsubscribe()
+
+	// the listener connects in the background, notify until the other instance is woken up
+	assert.Eventually(t, func() bool {
+		a.Notify("a")
+		return woken(ch, 100*time.Millisecond)
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func woken(ch <-chan struct{}, timeout time.Duration) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"net/netip"
 	"slices"
 	"sync"
 	"time"
@@ -12,20 +13,25 @@ type PollMapSessionManager interface {
 	Register(tailnetID uint64, machineID uint64, ch chan *Ping)
 	Deregister(tailnetID uint64, machineID uint64)
 	HasSession(tailnetID uint64, machineID uint64) bool
+	ListSessions(tailnetID uint64) []uint64
 	NotifyAll(tailnetID uint64, ignoreMachineIDs ...uint64)
+	PrimaryRouter(tailnetID uint64, prefix netip.Prefix, machineIDs []uint64) uint64
+	CurrentPrimaryRouter(tailnetID uint64, prefix netip.Prefix) (uint64, bool)
 }
 
 func NewPollMapSessionManager() PollMapSessionManager {
 	return &pollMapSessionManager{
-		data:   map[uint64]map[uint64]chan *Ping{},
-		timers: map[uint64]*time.Timer{},
+		data:      map[uint64]map[uint64]chan *Ping{},
+		timers:    map[uint64]*time.Timer{},
+		primaries: map[uint64]map[netip.Prefix]uint64{},
 	}
 }
 
 type pollMapSessionManager struct {
 	sync.RWMutex
-	data   map[uint64]map[uint64]chan *Ping
-	timers map[uint64]*time.Timer
+	data      map[uint64]map[uint64]chan *Ping
+	timers    map[uint64]*time.Timer
+	primaries map[uint64]map[netip.Prefix]uint64
 }
 
 func (n *pollMapSessionManager) Register(tailnetID uint64, machineID uint64, ch chan *Ping) {
@@ -61,6 +67,12 @@ func (n *pollMapSessionManager) Deregister(tailnetID uint64, machineID uint64) {
 
 	if ss := n.data[tailnetID]; ss != nil {
 		delete(ss, machineID)
+
+		// the primary routers are only kept while the tailnet has sessions
+		if len(ss) == 0 {
+			delete(n.data, tailnetID)
+			delete(n.primaries, tailnetID)
+		}
 	}
 
 	t, ok := n.timers[machineID]
@@ -84,6 +96,22 @@ func (n *pollMapSessionManager) HasSession(tailnetID uint64, machineID uint64) b
 	n.RLock()
 	defer n.RUnlock()
 
+	return n.hasSession(tailnetID, machineID)
+}
+
+// ListSessions returns the ids of the machines of the tailnet with a session.
+func (n *pollMapSessionManager) ListSessions(tailnetID uint64) []uint64 {
+	n.RLock()
+	defer n.RUnlock()
+
+	var result []uint64
+	for id := range n.data[tailnetID] {
+		result = append(result, id)
+	}
+	return result
+}
+
+func (n *pollMapSessionManager) hasSession(tailnetID uint64, machineID uint64) bool {
 	if ss := n.data[tailnetID]; ss != nil {
 		if _, ok := ss[machineID]; ok {
 			return true
@@ -109,3 +137,55 @@ func (n *pollMapSessionManager) NotifyAll(tailnetID uint64, ignoreMachineIDs ...
 		}
 	}
 }
+
+// CurrentPrimaryRouter returns the primary router of the route elected by the latest PrimaryRouter call, without changing it.
+func (n *pollMapSessionManager) CurrentPrimaryRouter(tailnetID uint64, prefix netip.Prefix) (uint64, bool) {
+	n.RLock()
+	defer n.RUnlock()
+
+	current, ok := n.primaries[tailnetID][prefix]
+	return current, ok
+}
+
+// PrimaryRouter returns which of the machines serving the route is the primary router.
+// The primary is kept as long as it has a session, otherwise a connected machine takes over
+// and all machines of the tailnet are notified to pick up the change.
+func (n *pollMapSessionManager) PrimaryRouter(tailnetID uint64, prefix netip.Prefix, machineIDs []uint64) uint64 {
+	if len(machineIDs) == 0 {
+		return 0
+	}
+
+	n.Lock()
+	defer n.Unlock()
+
+	current, ok := n.primaries[tailnetID][prefix]
+	if ok && slices.Contains(machineIDs, current) && n.hasSession(tailnetID, current) {
+		return current
+	}
+
+	candidates := slices.Clone(machineIDs)
+	slices.Sort(candidates)
+
+	next := candidates[0]
+	if ok && slices.Contains(candidates, current) {
+		next = current
+	}
+
+	for _, c := range candidates {
+		if n.hasSession(tailnetID, c) {
+			next = c
+			break
+		}
+	}
+
+	if n.primaries[tailnetID] == nil {
+		n.primaries[tailnetID] = map[netip.Prefix]uint64{}
+	}
+	n.primaries[tailnetID][prefix] = next
+
+	if ok && current != next {
+		go n.NotifyAll(tailnetID)
+	}
+
+	return next
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestPrimaryRouter(t *testing.T) {
+	n := NewPollMapSessionManager().(*pollMapSessionManager)
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+	routers := []uint64{3, 1, 2}
+
+	sessions := map[uint64]chan *Ping{}
+	connect := func(id uint64) {
+		sessions[id] = make(chan *Ping, 1)
+		n.Register(1, id, sessions[id])
+	}
+
+	// without sessions the lowest machine id is used
+	assert.Equal(t, uint64(1), n.PrimaryRouter(1, prefix, routers))
+	assert.Equal(t, uint64(0), n.PrimaryRouter(1, prefix, nil))
+
+	connect(2)
+	connect(3)
+	assert.Equal(t, uint64(2), n.PrimaryRouter(1, prefix, routers))
+
+	// stickiness: a machine with a lower id coming online doesn't take over
+	connect(1)
+	assert.Equal(t, uint64(2), n.PrimaryRouter(1, prefix, routers))
+
+	// failover: the primary goes offline, another connected router takes over and the tailnet is notified
+	drain(sessions)
+	n.Deregister(1, 2)
+	assert.Equal(t, uint64(1), n.PrimaryRouter(1, prefix, routers))
+	assertNotified(t, sessions[1])
+	assertNotified(t, sessions[3])
+
+	// the primary is no longer advertising the route
+	assert.Equal(t, uint64(3), n.PrimaryRouter(1, prefix, []uint64{2, 3}))
+
+	// failback: the previous primary returns, the current primary is kept until it goes offline
+	connect(2)
+	assert.Equal(t, uint64(3), n.PrimaryRouter(1, prefix, routers))
+	n.Deregister(1, 3)
+	assert.Equal(t, uint64(1), n.PrimaryRouter(1, prefix, routers))
+
+	// looking up the primary doesn't elect a new one
+	n.Deregister(1, 1)
+	current, ok := n.CurrentPrimaryRouter(1, prefix)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), current)
+	assert.Equal(t, uint64(2), n.PrimaryRouter(1, prefix, routers))
+
+	_, ok = n.CurrentPrimaryRouter(1, netip.MustParsePrefix("10.1.0.0/24"))
+	assert.False(t, ok)
+
+	// other prefixes and tailnets have their own primary
+	assert.Equal(t, uint64(2), n.PrimaryRouter(1, netip.MustParsePrefix("10.1.0.0/24"), []uint64{2, 3}))
+	assert.Equal(t, uint64(1), n.PrimaryRouter(2, prefix, routers))
+}
+
+func TestPrimaryRouter_RemovedWithLastSession(t *testing.T) {
+	n := NewPollMapSessionManager().(*pollMapSessionManager)
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+
+	n.Register(1, 1, make(chan *Ping, 1))
+	n.Register(1, 2, make(chan *Ping, 1))
+	assert.Equal(t, uint64(1), n.PrimaryRouter(1, prefix, []uint64{1, 2}))
+
+	n.Deregister(1, 1)
+	assert.Contains(t, n.primaries, uint64(1))
+
+	n.Deregister(1, 2)
+	assert.NotContains(t, n.primaries, uint64(1))
+	assert.NotContains(t, n.data, uint64(1))
+}
+
+func drain(sessions map[uint64]chan *Ping) {
+	for _, ch := range sessions {
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
+
+func assertNotified(t *testing.T, ch chan *Ping) {
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		assert.Fail(t, "session not notified")
+	}
+}
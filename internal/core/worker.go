@@ -2,19 +2,29 @@ package core
 
 import (
 	"context"
+	"github.com/jsiebens/ionscale/internal/dns"
 	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/notification"
+	"github.com/jsiebens/ionscale/internal/util"
+	"go.uber.org/zap"
+	"slices"
 	"time"
 )
 
 const (
-	ticker            = 10 * time.Minute
-	inactivityTimeout = 30 * time.Minute
+	ticker                = 10 * time.Minute
+	inactivityTimeout     = 30 * time.Minute
+	dnsChallengeRecordTTL = 1 * time.Hour
 )
 
-func StartWorker(repository domain.Repository, sessionManager PollMapSessionManager) {
+func StartWorker(repository domain.Repository, sessionManager PollMapSessionManager, dnsProvider dns.Provider, dnsPublisher dns.Publisher, notifier notification.Notifier, keyExpiryThresholds []time.Duration) {
 	r := &worker{
-		sessionManager: sessionManager,
-		repository:     repository,
+		sessionManager:      sessionManager,
+		repository:          repository,
+		dnsProvider:         dnsProvider,
+		dnsPublisher:        dnsPublisher,
+		notifier:            notifier,
+		keyExpiryThresholds: keyExpiryThresholds,
 	}
 
 	go r.start()
@@ -23,13 +33,26 @@ func StartWorker(repository domain.Repository, sessionManager PollMapSessionMana
 type worker struct {
 	sessionManager PollMapSessionManager
 	repository     domain.Repository
+	dnsProvider    dns.Provider
+	dnsPublisher   dns.Publisher
+
+	notifier            notification.Notifier
+	keyExpiryThresholds []time.Duration
 }
 
 func (r *worker) start() {
 	r.deleteInactiveEphemeralNodes()
+	r.applyMachineRetentionPolicies()
+	r.notifyExpiringMachines()
+	r.deleteStaleDNSChallengeRecords()
+	r.syncPublishedDNSRecords()
 	t := time.NewTicker(ticker)
 	for range t.C {
 		r.deleteInactiveEphemeralNodes()
+		r.applyMachineRetentionPolicies()
+		r.notifyExpiringMachines()
+		r.deleteStaleDNSChallengeRecords()
+		r.syncPublishedDNSRecords()
 	}
 }
 
@@ -44,8 +67,17 @@ func (r *worker) deleteInactiveEphemeralNodes() {
 	}
 
 	var removedNodes = make(map[uint64][]uint64)
+	var sharingTailnets = make(map[uint64][]uint64)
 	for _, m := range machines {
 		if now.After(m.LastSeen.Add(inactivityTimeout)) {
+			if _, ok := sharingTailnets[m.TailnetID]; !ok {
+				ids, err := r.repository.ListSharingTailnets(ctx, m.TailnetID)
+				if err != nil {
+					continue
+				}
+				sharingTailnets[m.TailnetID] = ids
+			}
+
 			ok, err := r.repository.DeleteMachine(ctx, m.ID)
 			if err != nil {
 				continue
@@ -59,6 +91,222 @@ func (r *worker) deleteInactiveEphemeralNodes() {
 	if len(removedNodes) != 0 {
 		for i, _ := range removedNodes {
 			r.sessionManager.NotifyAll(i)
+			r.notifySharingTailnets(sharingTailnets[i])
+			r.dnsPublisher.SyncTailnet(i)
+		}
+	}
+}
+
+func (r *worker) applyMachineRetentionPolicies() {
+	ctx := context.Background()
+
+	tailnets, err := r.repository.ListTailnets(ctx)
+	if err != nil {
+		return
+	}
+
+	isConnected := func(m *domain.Machine) bool { return r.sessionManager.HasSession(m.TailnetID, m.ID) }
+
+	for _, t := range tailnets {
+		if len(t.MachineRetentionPolicy.Rules) == 0 {
+			continue
 		}
+
+		machines, err := r.repository.ListMachineByTailnet(ctx, t.ID)
+		if err != nil {
+			continue
+		}
+
+		candidates := t.MachineRetentionPolicy.Evaluate(machines, time.Now().UTC(), isConnected)
+
+		sharingTailnets, err := r.repository.ListSharingTailnets(ctx, t.ID)
+		if err != nil {
+			continue
+		}
+
+		var changed bool
+		for _, c := range candidates {
+			if r.applyMachineRetention(ctx, c) {
+				changed = true
+			}
+		}
+
+		if changed {
+			r.sessionManager.NotifyAll(t.ID)
+			r.notifySharingTailnets(sharingTailnets)
+			r.dnsPublisher.SyncTailnet(t.ID)
+		}
+	}
+}
+
+// notifySharingTailnets notifies the tailnets that share a machine with a changed tailnet, or receive one of its machines.
+func (r *worker) notifySharingTailnets(tailnetIDs []uint64) {
+	for _, id := range tailnetIDs {
+		r.sessionManager.NotifyAll(id)
+	}
+}
+
+func (r *worker) applyMachineRetention(ctx context.Context, c domain.MachineRetentionCandidate) bool {
+	m := c.Machine
+	fields := []zap.Field{
+		zap.Uint64("tailnet", m.TailnetID),
+		zap.Uint64("machine", m.ID),
+		zap.String("name", m.CompleteName()),
+	}
+	if m.LastSeen != nil {
+		fields = append(fields, zap.Time("last_seen", *m.LastSeen))
+	}
+
+	switch c.Action {
+	case domain.MachineRetentionDelete:
+		ok, err := r.repository.DeleteMachine(ctx, m.ID)
+		if err != nil {
+			zap.L().Error("unable to delete machine by retention policy", append(fields, zap.Error(err))...)
+			return false
+		}
+		if ok {
+			zap.L().Info("machine deleted by retention policy", fields...)
+		}
+		return ok
+	case domain.MachineRetentionExpire:
+		m.ExpiresAt = time.Unix(123, 0)
+		m.KeyExpiryDisabled = false
+		if err := r.repository.SaveMachine(ctx, &m); err != nil {
+			zap.L().Error("unable to expire machine by retention policy", append(fields, zap.Error(err))...)
+			return false
+		}
+		zap.L().Info("machine expired by retention policy", fields...)
+		return true
+	}
+
+	return false
+}
+
+func (r *worker) notifyExpiringMachines() {
+	if !r.notifier.Enabled() || len(r.keyExpiryThresholds) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	// notifications of keys that expired a while ago are no longer needed
+	if err := r.repository.DeleteKeyExpiryNotificationsBefore(ctx, now.Add(-24*time.Hour)); err != nil {
+		zap.L().Error("unable to delete key expiry notifications", zap.Error(err))
+	}
+
+	machines, err := r.repository.ListMachinesExpiringBefore(ctx, now.Add(slices.Max(r.keyExpiryThresholds)))
+	if err != nil {
+		zap.L().Error("unable to list expiring machines", zap.Error(err))
+		return
+	}
+
+	for _, m := range machines {
+		threshold, ok := m.KeyExpiryThreshold(now, r.keyExpiryThresholds)
+		if !ok {
+			continue
+		}
+
+		msg := notification.NewKeyExpiryMessage(m.TailnetID, m.Tailnet.Name, m.ID, m.CompleteName(), m.User.Name, m.ExpiresAt)
+		for _, c := range r.notifier.Channels() {
+			r.sendKeyExpiryNotification(ctx, c, &m, threshold, msg)
+		}
+	}
+}
+
+// sendKeyExpiryNotification delivers the message through the channel, unless it was delivered there before.
+// The notification is stored before it is sent, so that it is sent only once, also when multiple instances
+// share the database. When sending fails, the notification is removed again and retried on the next run,
+// only for the failing channel. A message is delivered at most once per channel, as a crash between storing
+// and sending the notification drops it.
+func (r *worker) sendKeyExpiryNotification(ctx context.Context, c notification.Channel, m *domain.Machine, threshold time.Duration, msg *notification.Message) {
+	n := &domain.KeyExpiryNotification{
+		ID:        util.NextID(),
+		MachineID: m.ID,
+		ExpiresAt: m.ExpiresAt,
+		Threshold: threshold,
+		Channel:   c.ID(),
+	}
+
+	created, err := r.repository.CreateKeyExpiryNotification(ctx, n)
+	if err != nil {
+		zap.L().Error("unable to store key expiry notification", zap.Uint64("machine", m.ID), zap.String("channel", c.ID()), zap.Error(err))
+		return
+	}
+	if !created {
+		return
+	}
+
+	if err := c.Send(ctx, msg); err != nil {
+		zap.L().Error("unable to send key expiry notification", zap.Uint64("machine", m.ID), zap.String("channel", c.ID()), zap.Error(err))
+		if err := r.repository.DeleteKeyExpiryNotification(ctx, n.ID); err != nil {
+			zap.L().Error("unable to delete key expiry notification", zap.Uint64("machine", m.ID), zap.String("channel", c.ID()), zap.Error(err))
+		}
+		return
+	}
+
+	zap.L().Info("key expiry notification sent",
+		zap.Uint64("tailnet", m.TailnetID),
+		zap.Uint64("machine", m.ID),
+		zap.String("name", m.CompleteName()),
+		zap.String("channel", c.ID()),
+		zap.Time("expires_at", m.ExpiresAt),
+		zap.Duration("threshold", threshold),
+	)
+}
+
+func (r *worker) deleteStaleDNSChallengeRecords() {
+	if r.dnsProvider == nil {
+		return
+	}
+
+	ctx := context.Background()
+
+	checkpoint := time.Now().UTC().Add(-dnsChallengeRecordTTL)
+	records, err := r.repository.ListDNSChallengeRecordsCreatedBefore(ctx, checkpoint)
+	if err != nil {
+		return
+	}
+
+	for _, record := range records {
+		if err := r.dnsProvider.DeleteRecord(ctx, record.Type, record.Name, record.Value); err != nil {
+			continue
+		}
+		_ = r.repository.DeleteDNSChallengeRecord(ctx, record.ID)
+	}
+}
+
+func (r *worker) syncPublishedDNSRecords() {
+	if r.dnsProvider == nil {
+		return
+	}
+
+	ctx := context.Background()
+
+	var tailnetIDs = make(map[uint64]bool)
+
+	tailnets, err := r.repository.ListTailnets(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, t := range tailnets {
+		if t.DNSConfig.PublishRecords {
+			tailnetIDs[t.ID] = true
+		}
+	}
+
+	// include tailnets with records still published, e.g. after publishing got disabled or the tailnet was removed
+	published, err := r.repository.ListPublishedDNSRecordTailnets(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, id := range published {
+		tailnetIDs[id] = true
+	}
+
+	for id := range tailnetIDs {
+		r.dnsPublisher.SyncTailnet(id)
 	}
 }
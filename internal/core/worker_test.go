@@ -0,0 +1,217 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/database"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/notification"
+	"github.com/jsiebens/ionscale/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"slices"
+	"tailscale.com/types/key"
+	"testing"
+	"time"
+)
+
+func TestWorker_DeleteStaleDNSChallengeRecords(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+	provider := &fakeDNSProvider{records: map[string]bool{}, failing: map[string]bool{}}
+
+	now := time.Now().UTC()
+	stale := createChallengeRecord(t, repository, provider, "_acme-challenge.stale.example.com", now.Add(-2*time.Hour))
+	failing := createChallengeRecord(t, repository, provider, "_acme-challenge.failing.example.com", now.Add(-2*time.Hour))
+	recent := createChallengeRecord(t, repository, provider, "_acme-challenge.recent.example.com", now.Add(-10*time.Minute))
+
+	provider.failing[failing.Name] = true
+
+	w := &worker{repository: repository, dnsProvider: provider}
+	w.deleteStaleDNSChallengeRecords()
+
+	// only the stale records are removed, a record failing to be removed at the provider is retried later
+	assert.False(t, provider.records[stale.Name])
+	assert.True(t, provider.records[failing.Name])
+	assert.True(t, provider.records[recent.Name])
+
+	remaining, err := repository.ListDNSChallengeRecordsCreatedBefore(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	var names []string
+	for _, r := range remaining {
+		names = append(names, r.Name)
+	}
+	assert.ElementsMatch(t, []string{failing.Name, recent.Name}, names)
+
+	provider.failing[failing.Name] = false
+	w.deleteStaleDNSChallengeRecords()
+	assert.False(t, provider.records[failing.Name])
+}
+
+func TestWorker_DeleteStaleDNSChallengeRecordsWithoutProvider(t *testing.T) {
+	repository := openTestRepository(t)
+
+	w := &worker{repository: repository}
+	w.deleteStaleDNSChallengeRecords()
+}
+
+func TestWorker_NotifyExpiringMachines(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := &domain.Tailnet{ID: util.NextID(), Name: "example"}
+	require.NoError(t, repository.SaveTailnet(ctx, tailnet))
+	user, _, err := repository.GetOrCreateServiceUser(ctx, tailnet)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	laptop := createExpiringMachine(t, repository, tailnet, user, "laptop", now.Add(12*time.Hour))
+	server := createExpiringMachine(t, repository, tailnet, user, "server", now.Add(3*24*time.Hour))
+	createExpiringMachine(t, repository, tailnet, user, "desktop", now.Add(30*24*time.Hour))
+
+	a := &fakeChannel{id: "a"}
+	b := &fakeChannel{id: "b"}
+	notifier := &fakeNotifier{channels: []notification.Channel{a, b}}
+	thresholds := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour}
+
+	w := &worker{repository: repository, notifier: notifier, keyExpiryThresholds: thresholds}
+	w.notifyExpiringMachines()
+
+	// only machines within a threshold are notified, through every channel
+	assert.ElementsMatch(t, []uint64{laptop.ID, server.ID}, a.machines())
+	assert.ElementsMatch(t, []uint64{laptop.ID, server.ID}, b.machines())
+
+	// notified once per threshold, also after a restart
+	w.notifyExpiringMachines()
+	w = &worker{repository: repository, notifier: notifier, keyExpiryThresholds: thresholds}
+	w.notifyExpiringMachines()
+	assert.Len(t, a.sent, 2)
+	assert.Len(t, b.sent, 2)
+
+	// the next threshold is notified again, a failing channel is retried without sending twice through the others
+	server.ExpiresAt = now.Add(12 * time.Hour)
+	require.NoError(t, repository.SaveMachine(ctx, server))
+	b.failing = true
+
+	w.notifyExpiringMachines()
+	assert.Equal(t, []uint64{laptop.ID, server.ID, server.ID}, sorted(a.machines()))
+	assert.Equal(t, []uint64{laptop.ID, server.ID}, sorted(b.machines()))
+
+	b.failing = false
+	w.notifyExpiringMachines()
+	w.notifyExpiringMachines()
+	assert.Equal(t, []uint64{laptop.ID, server.ID, server.ID}, sorted(a.machines()))
+	assert.Equal(t, []uint64{laptop.ID, server.ID, server.ID}, sorted(b.machines()))
+}
+
+func TestWorker_NotifyExpiringMachinesWithoutChannels(t *testing.T) {
+	repository := openTestRepository(t)
+
+	w := &worker{repository: repository, notifier: &fakeNotifier{}, keyExpiryThresholds: []time.Duration{24 * time.Hour}}
+	w.notifyExpiringMachines()
+}
+
+func openTestRepository(t *testing.T) domain.Repository {
+	t.Setenv("IONSCALE_MACHINE_ID", "1")
+
+	_, repository, err := database.OpenDB(&config.Database{
+		Type:         "sqlite",
+		Url:          t.TempDir() + "/ionscale.db?_pragma=busy_timeout(5000)",
+		MaxOpenConns: 1,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	return repository
+}
+
+func createChallengeRecord(t *testing.T, repository domain.Repository, provider *fakeDNSProvider, name string, createdAt time.Time) *domain.DNSChallengeRecord {
+	record := &domain.DNSChallengeRecord{
+		ID:        util.NextID(),
+		Type:      "TXT",
+		Name:      name,
+		Value:     "token",
+		CreatedAt: createdAt,
+	}
+	require.NoError(t, provider.SetRecord(context.Background(), record.Type, record.Name, record.Value))
+	require.NoError(t, repository.SaveDNSChallengeRecord(context.Background(), record))
+	return record
+}
+
+type fakeDNSProvider struct {
+	records map[string]bool
+	failing map[string]bool
+}
+
+func (f *fakeDNSProvider) SetRecord(_ context.Context, _, recordName, _ string) error {
+	f.records[recordName] = true
+	return nil
+}
+
+func (f *fakeDNSProvider) DeleteRecord(_ context.Context, _, recordName, _ string) error {
+	if f.failing[recordName] {
+		return errors.New("provider unavailable")
+	}
+	delete(f.records, recordName)
+	return nil
+}
+
+func createExpiringMachine(t *testing.T, repository domain.Repository, tailnet *domain.Tailnet, user *domain.User, name string, expiresAt time.Time) *domain.Machine {
+	m := &domain.Machine{
+		ID:         util.NextID(),
+		Name:       name,
+		MachineKey: key.NewMachine().Public().String(),
+		NodeKey:    key.NewNode().Public().String(),
+		Authorized: true,
+		CreatedAt:  time.Now().UTC(),
+		ExpiresAt:  expiresAt,
+		TailnetID:  tailnet.ID,
+		UserID:     user.ID,
+	}
+	require.NoError(t, repository.SaveMachine(context.Background(), m))
+	return m
+}
+
+func sorted(v []uint64) []uint64 {
+	slices.Sort(v)
+	return v
+}
+
+type fakeNotifier struct {
+	channels []notification.Channel
+}
+
+func (f *fakeNotifier) Enabled() bool {
+	return len(f.channels) != 0
+}
+
+func (f *fakeNotifier) Channels() []notification.Channel {
+	return f.channels
+}
+
+type fakeChannel struct {
+	id      string
+	failing bool
+	sent    []*notification.Message
+}
+
+func (f *fakeChannel) ID() string {
+	return f.id
+}
+
+func (f *fakeChannel) Send(_ context.Context, m *notification.Message) error {
+	if f.failing {
+		return errors.New("channel unavailable")
+	}
+	f.sent = append(f.sent, m)
+	return nil
+}
+
+func (f *fakeChannel) machines() []uint64 {
+	var ids []uint64
+	for _, m := range f.sent {
+		ids = append(ids, m.MachineID)
+	}
+	return ids
+}
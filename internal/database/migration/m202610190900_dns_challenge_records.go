@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+	"time"
+)
+
+func m202610190900_dns_challenge_records() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610190900",
+		Migrate: func(db *gorm.DB) error {
+			type DNSChallengeRecord struct {
+				ID        uint64 `gorm:"primaryKey;autoIncrement:false"`
+				Type      string
+				Name      string
+				Value     string
+				CreatedAt time.Time `gorm:"index"`
+			}
+
+			return db.AutoMigrate(
+				&DNSChallengeRecord{},
+			)
+		},
+		Rollback: nil,
+	}
+}
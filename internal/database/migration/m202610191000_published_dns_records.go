@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func m202610191000_published_dns_records() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191000",
+		Migrate: func(db *gorm.DB) error {
+			type PublishedDNSRecord struct {
+				ID        uint64 `gorm:"primaryKey;autoIncrement:false"`
+				TailnetID uint64 `gorm:"index"`
+				MachineID uint64
+				Type      string
+				Name      string
+				Value     string
+			}
+
+			return db.AutoMigrate(
+				&PublishedDNSRecord{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,23 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"gorm.io/gorm"
+)
+
+func m202610191100_machine_aliases() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191100",
+		Migrate: func(db *gorm.DB) error {
+			type Machine struct {
+				Aliases domain.Tags
+			}
+
+			return db.AutoMigrate(
+				&Machine{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,22 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func m202610191200_machine_custom_name() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191200",
+		Migrate: func(db *gorm.DB) error {
+			type Machine struct {
+				CustomName bool
+			}
+
+			return db.AutoMigrate(
+				&Machine{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,46 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+func m202610191300_ssh_approvals() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191300",
+		Migrate: func(db *gorm.DB) error {
+			type SSHActionRequest struct {
+				TailnetID     uint64
+				SSHUser       string
+				LocalUser     string
+				Approvers     domain.Tags
+				Authenticated bool
+				ApprovedBy    string
+				ExpiresAt     *time.Time
+			}
+
+			type SSHApproval struct {
+				ID           uint64 `gorm:"primary_key"`
+				TailnetID    uint64
+				RequestKey   string
+				SrcMachineID uint64
+				DstMachineID uint64
+				SSHUser      string
+				LocalUser    string
+				Approvers    domain.Tags
+				Decision     string
+				DecidedBy    string
+				RequestedAt  time.Time
+				DecidedAt    time.Time
+			}
+
+			return db.AutoMigrate(
+				&SSHActionRequest{},
+				&SSHApproval{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,23 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"gorm.io/gorm"
+)
+
+func m202610191400_account_attributes() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191400",
+		Migrate: func(db *gorm.DB) error {
+			type Account struct {
+				Attributes domain.AccountAttributes
+			}
+
+			return db.AutoMigrate(
+				&Account{},
+			)
+		},
+		Rollback: nil,
+	}
+}
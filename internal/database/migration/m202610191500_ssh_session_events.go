@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+	"time"
+)
+
+func m202610191500_ssh_session_events() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191500",
+		Migrate: func(db *gorm.DB) error {
+			type SSHSessionEvent struct {
+				ID             uint64 `gorm:"primary_key"`
+				TailnetID      uint64 `gorm:"index"`
+				Type           string
+				ConnectionID   string
+				SrcMachineID   uint64
+				SrcMachineName string
+				DstMachineID   uint64
+				DstMachineName string
+				SSHUser        string
+				LocalUser      string
+				Decision       string
+				Recording      string
+				CreatedAt      time.Time
+			}
+
+			return db.AutoMigrate(
+				&SSHSessionEvent{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,41 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+	"time"
+)
+
+func m202610191600_tailnet_key_authority() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191600",
+		Migrate: func(db *gorm.DB) error {
+			type Machine struct {
+				KeySignature []byte
+			}
+
+			type TailnetKeyAuthority struct {
+				TailnetID         uint64 `gorm:"primary_key;autoIncrement:false"`
+				State             string
+				Head              string
+				DisablementSecret []byte
+				CreatedAt         time.Time
+				UpdatedAt         time.Time
+			}
+
+			type TailnetKeyAuthorityAUM struct {
+				TailnetID uint64 `gorm:"primary_key;autoIncrement:false"`
+				Hash      string `gorm:"primary_key"`
+				Data      []byte
+				Idx       int
+			}
+
+			return db.AutoMigrate(
+				&Machine{},
+				&TailnetKeyAuthority{},
+				&TailnetKeyAuthorityAUM{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,23 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"gorm.io/gorm"
+)
+
+func m202610191700_machine_retention_policy() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191700",
+		Migrate: func(db *gorm.DB) error {
+			type Tailnet struct {
+				MachineRetentionPolicy domain.MachineRetentionPolicy
+			}
+
+			return db.AutoMigrate(
+				&Tailnet{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+	"time"
+)
+
+func m202610191800_key_expiry_notifications() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191800",
+		Migrate: func(db *gorm.DB) error {
+			type KeyExpiryNotification struct {
+				ID        uint64    `gorm:"primaryKey;autoIncrement:false"`
+				MachineID uint64    `gorm:"uniqueIndex:idx_key_expiry_notification"`
+				ExpiresAt time.Time `gorm:"uniqueIndex:idx_key_expiry_notification"`
+				Threshold int64     `gorm:"uniqueIndex:idx_key_expiry_notification"`
+				CreatedAt time.Time
+			}
+
+			return db.AutoMigrate(
+				&KeyExpiryNotification{},
+			)
+		},
+		Rollback: nil,
+	}
+}
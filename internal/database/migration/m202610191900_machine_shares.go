@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+	"time"
+)
+
+func m202610191900_machine_shares() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191900",
+		Migrate: func(db *gorm.DB) error {
+			type MachineShare struct {
+				ID                 uint64 `gorm:"primaryKey;autoIncrement:false"`
+				Code               string `gorm:"uniqueIndex"`
+				MachineID          uint64 `gorm:"index"`
+				TailnetID          uint64 `gorm:"index"`
+				InviteLoginName    string
+				InviteTailnetID    uint64
+				RecipientTailnetID uint64 `gorm:"index"`
+				RecipientUserID    uint64
+				AcceptedAt         *time.Time
+				CreatedAt          time.Time
+			}
+
+			return db.AutoMigrate(
+				&MachineShare{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,22 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func m202610192000_tailnet_key_authority_initiator() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610192000",
+		Migrate: func(db *gorm.DB) error {
+			type TailnetKeyAuthority struct {
+				InitiatedBy uint64
+			}
+
+			return db.AutoMigrate(
+				&TailnetKeyAuthority{},
+			)
+		},
+		Rollback: nil,
+	}
+}
@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+	"time"
+)
+
+func m202610192100_key_expiry_notification_channels() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610192100",
+		Migrate: func(db *gorm.DB) error {
+			type KeyExpiryNotification struct {
+				ID        uint64    `gorm:"primaryKey;autoIncrement:false"`
+				MachineID uint64    `gorm:"uniqueIndex:idx_key_expiry_notification_channel"`
+				ExpiresAt time.Time `gorm:"uniqueIndex:idx_key_expiry_notification_channel"`
+				Threshold int64     `gorm:"uniqueIndex:idx_key_expiry_notification_channel"`
+				Channel   string    `gorm:"uniqueIndex:idx_key_expiry_notification_channel"`
+				CreatedAt time.Time
+			}
+
+			// notifications are recorded per channel now
+			if db.Migrator().HasIndex(&KeyExpiryNotification{}, "idx_key_expiry_notification") {
+				if err := db.Migrator().DropIndex(&KeyExpiryNotification{}, "idx_key_expiry_notification"); err != nil {
+					return err
+				}
+			}
+
+			return db.AutoMigrate(
+				&KeyExpiryNotification{},
+			)
+		},
+		Rollback: nil,
+	}
+}
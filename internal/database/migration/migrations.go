@@ -21,6 +21,19 @@ func Migrations() []*gormigrate.Migration {
 		m202401061400_machine_indeces(),
 		m202402120800_user_last_authenticated(),
 		m202403130830_json_to_text(),
+		m202610190900_dns_challenge_records(),
+		m202610191000_published_dns_records(),
+		m202610191100_machine_aliases(),
+		m202610191200_machine_custom_name(),
+		m202610191300_ssh_approvals(),
+		m202610191400_account_attributes(),
+		m202610191500_ssh_session_events(),
+		m202610191600_tailnet_key_authority(),
+		m202610191700_machine_retention_policy(),
+		m202610191800_key_expiry_notifications(),
+		m202610191900_machine_shares(),
+		m202610192000_tailnet_key_authority_initiator(),
+		m202610192100_key_expiry_notification_channels(),
 	}
 	return migrations
 }
@@ -0,0 +1,78 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+// propagationResolver looks up the records used to check the propagation of a TXT record.
+type propagationResolver interface {
+	LookupNS(ctx context.Context, zone string) ([]*net.NS, error)
+	LookupTXT(ctx context.Context, nameserver, name string) ([]string, error)
+}
+
+var defaultPropagationResolver propagationResolver = &netPropagationResolver{}
+
+// CheckTXTPropagation queries the authoritative nameservers of the zone directly,
+// bypassing any caching resolvers, and reports whether all of them serve the given TXT value.
+func CheckTXTPropagation(ctx context.Context, zone, name, value string) (bool, error) {
+	return checkTXTPropagation(ctx, defaultPropagationResolver, zone, name, value)
+}
+
+func checkTXTPropagation(ctx context.Context, resolver propagationResolver, zone, name, value string) (bool, error) {
+	nameservers, err := authoritativeNameservers(ctx, resolver, zone)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ns := range nameservers {
+		records, err := resolver.LookupTXT(ctx, ns, fqdn(name))
+		if err != nil {
+			return false, nil
+		}
+		if !slices.Contains(records, value) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func authoritativeNameservers(ctx context.Context, resolver propagationResolver, zone string) ([]string, error) {
+	records, err := resolver.LookupNS(ctx, fqdn(zone))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no authoritative nameservers found for zone [%s]", zone)
+	}
+
+	var result []string
+	for _, r := range records {
+		result = append(result, net.JoinHostPort(strings.TrimSuffix(r.Host, "."), "53"))
+	}
+
+	return result, nil
+}
+
+type netPropagationResolver struct {
+}
+
+func (r *netPropagationResolver) LookupNS(ctx context.Context, zone string) ([]*net.NS, error) {
+	return net.DefaultResolver.LookupNS(ctx, zone)
+}
+
+func (r *netPropagationResolver) LookupTXT(ctx context.Context, nameserver, name string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, nameserver)
+		},
+	}
+	return resolver.LookupTXT(ctx, name)
+}
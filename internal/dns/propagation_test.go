@@ -0,0 +1,71 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+)
+
+func TestCheckTXTPropagation(t *testing.T) {
+	ctx := context.Background()
+	name := "_acme-challenge.web.example.com"
+
+	resolver := &fakePropagationResolver{
+		ns: map[string][]*net.NS{"example.com.": {{Host: "ns1.example.com."}, {Host: "ns2.example.com."}}},
+		txt: map[string][]string{
+			"ns1.example.com:53/_acme-challenge.web.example.com.": {"other", "token"},
+			"ns2.example.com:53/_acme-challenge.web.example.com.": {"token"},
+		},
+	}
+
+	propagated, err := checkTXTPropagation(ctx, resolver, "example.com", name, "token")
+	require.NoError(t, err)
+	assert.True(t, propagated)
+
+	// the value must be served by all authoritative nameservers
+	resolver.txt["ns2.example.com:53/_acme-challenge.web.example.com."] = []string{"old"}
+	propagated, err = checkTXTPropagation(ctx, resolver, "example.com", name, "token")
+	require.NoError(t, err)
+	assert.False(t, propagated)
+
+	// a failing nameserver is not propagated yet, the check is retried
+	delete(resolver.txt, "ns2.example.com:53/_acme-challenge.web.example.com.")
+	propagated, err = checkTXTPropagation(ctx, resolver, "example.com", name, "token")
+	require.NoError(t, err)
+	assert.False(t, propagated)
+}
+
+func TestCheckTXTPropagation_NoNameservers(t *testing.T) {
+	ctx := context.Background()
+
+	resolver := &fakePropagationResolver{ns: map[string][]*net.NS{"example.com.": {}}}
+	_, err := checkTXTPropagation(ctx, resolver, "example.com", "_acme-challenge.example.com", "token")
+	assert.Error(t, err)
+
+	_, err = checkTXTPropagation(ctx, resolver, "unknown.com", "_acme-challenge.unknown.com", "token")
+	assert.Error(t, err)
+}
+
+type fakePropagationResolver struct {
+	ns  map[string][]*net.NS
+	txt map[string][]string
+}
+
+func (f *fakePropagationResolver) LookupNS(_ context.Context, zone string) ([]*net.NS, error) {
+	records, ok := f.ns[zone]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+func (f *fakePropagationResolver) LookupTXT(_ context.Context, nameserver, name string) ([]string, error) {
+	records, ok := f.txt[nameserver+"/"+name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
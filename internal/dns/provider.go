@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"github.com/imdario/mergo"
 	"github.com/jsiebens/ionscale/internal/config"
-	"github.com/jsiebens/ionscale/internal/mapping"
+	"github.com/jsiebens/ionscale/internal/util"
 	"github.com/libdns/azure"
 	"github.com/libdns/cloudflare"
 	"github.com/libdns/digitalocean"
@@ -18,6 +18,7 @@ import (
 
 type Provider interface {
 	SetRecord(ctx context.Context, recordType, recordName, value string) error
+	DeleteRecord(ctx context.Context, recordType, recordName, value string) error
 }
 
 func NewProvider(config config.DNS) (Provider, error) {
@@ -26,7 +27,7 @@ func NewProvider(config config.DNS) (Provider, error) {
 		return nil, nil
 	}
 
-	if !strings.HasSuffix(config.MagicDNSSuffix, p.Zone) {
+	if !inZone(config.MagicDNSSuffix, p.Zone) {
 		return nil, fmt.Errorf("invalid MagicDNS suffix [%s], not part of zone [%s]", config.MagicDNSSuffix, p.Zone)
 	}
 
@@ -48,7 +49,7 @@ func NewProvider(config config.DNS) (Provider, error) {
 
 func configureAzureProvider(zone string, values map[string]string) (Provider, error) {
 	p := &azure.Provider{}
-	if err := mapping.CopyViaJson(values, p); err != nil {
+	if err := util.CopyViaJson(values, p); err != nil {
 		return nil, err
 	}
 
@@ -65,12 +66,12 @@ func configureAzureProvider(zone string, values map[string]string) (Provider, er
 		return nil, err
 	}
 
-	return &externalProvider{zone: fqdn(zone), setter: p}, nil
+	return &externalProvider{zone: fqdn(zone), provider: p}, nil
 }
 
 func configureCloudflareProvider(zone string, values map[string]string) (Provider, error) {
 	p := &cloudflare.Provider{}
-	if err := mapping.CopyViaJson(values, p); err != nil {
+	if err := util.CopyViaJson(values, p); err != nil {
 		return nil, err
 	}
 
@@ -83,12 +84,12 @@ func configureCloudflareProvider(zone string, values map[string]string) (Provide
 		return nil, err
 	}
 
-	return &externalProvider{zone: fqdn(zone), setter: p}, nil
+	return &externalProvider{zone: fqdn(zone), provider: p}, nil
 }
 
 func configureDigitalOceanProvider(zone string, values map[string]string) (Provider, error) {
 	p := &digitalocean.Provider{}
-	if err := mapping.CopyViaJson(values, p); err != nil {
+	if err := util.CopyViaJson(values, p); err != nil {
 		return nil, err
 	}
 
@@ -101,12 +102,12 @@ func configureDigitalOceanProvider(zone string, values map[string]string) (Provi
 		return nil, err
 	}
 
-	return &externalProvider{zone: fqdn(zone), setter: p}, nil
+	return &externalProvider{zone: fqdn(zone), provider: p}, nil
 }
 
 func configureGoogleCloudDNSProvider(zone string, values map[string]string) (Provider, error) {
 	p := &googleclouddns.Provider{}
-	if err := mapping.CopyViaJson(values, p); err != nil {
+	if err := util.CopyViaJson(values, p); err != nil {
 		return nil, err
 	}
 
@@ -120,12 +121,12 @@ func configureGoogleCloudDNSProvider(zone string, values map[string]string) (Pro
 		return nil, err
 	}
 
-	return &externalProvider{zone: fqdn(zone), setter: p}, nil
+	return &externalProvider{zone: fqdn(zone), provider: p}, nil
 }
 
 func configureRoute53Provider(zone string, values map[string]string) (Provider, error) {
 	p := &route53.Provider{}
-	if err := mapping.CopyViaJson(values, p); err != nil {
+	if err := util.CopyViaJson(values, p); err != nil {
 		return nil, err
 	}
 
@@ -145,16 +146,21 @@ func configureRoute53Provider(zone string, values map[string]string) (Provider,
 		return nil, err
 	}
 
-	return &externalProvider{zone: fqdn(zone), setter: p}, nil
+	return &externalProvider{zone: fqdn(zone), provider: p}, nil
+}
+
+type libdnsProvider interface {
+	libdns.RecordSetter
+	libdns.RecordDeleter
 }
 
 type externalProvider struct {
-	zone   string
-	setter libdns.RecordSetter
+	zone     string
+	provider libdnsProvider
 }
 
 func (p *externalProvider) SetRecord(ctx context.Context, recordType, recordName, value string) error {
-	_, err := p.setter.SetRecords(ctx, p.zone, []libdns.Record{{
+	_, err := p.provider.SetRecords(ctx, p.zone, []libdns.Record{{
 		Type:  recordType,
 		Name:  libdns.RelativeName(recordName, p.zone),
 		Value: value,
@@ -163,6 +169,25 @@ func (p *externalProvider) SetRecord(ctx context.Context, recordType, recordName
 	return err
 }
 
+func (p *externalProvider) DeleteRecord(ctx context.Context, recordType, recordName, value string) error {
+	_, err := p.provider.DeleteRecords(ctx, p.zone, []libdns.Record{{
+		Type:  recordType,
+		Name:  libdns.RelativeName(recordName, p.zone),
+		Value: value,
+	}})
+	return err
+}
+
+// inZone reports whether the name is the zone itself or one of its subdomains.
+func inZone(name, zone string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	if name == "" || zone == "" {
+		return false
+	}
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
 func fqdn(v string) string {
 	if strings.HasSuffix(v, ".") {
 		return v
@@ -0,0 +1,35 @@
+package dns
+
+import (
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInZone(t *testing.T) {
+	assert.True(t, inZone("ts.example.com", "example.com"))
+	assert.True(t, inZone("ts.example.com.", "example.com."))
+	assert.True(t, inZone("example.com", "example.com."))
+	assert.True(t, inZone("TS.Example.com", "example.COM"))
+
+	assert.False(t, inZone("ts.myexample.com", "example.com"))
+	assert.False(t, inZone("example.com", "ts.example.com"))
+	assert.False(t, inZone("", "example.com"))
+	assert.False(t, inZone("ts.example.com", ""))
+}
+
+func TestNewProvider_MagicDNSSuffixNotInZone(t *testing.T) {
+	_, err := NewProvider(config.DNS{
+		MagicDNSSuffix: "ts.myexample.com",
+		Provider:       config.DNSProvider{Name: "cloudflare", Zone: "example.com"},
+	})
+	require.Error(t, err)
+
+	p, err := NewProvider(config.DNS{
+		MagicDNSSuffix: "ts.example.com",
+		Provider:       config.DNSProvider{Name: "cloudflare", Zone: "example.com"},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, p)
+}
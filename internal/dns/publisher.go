@@ -0,0 +1,150 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/util"
+	"go.uber.org/zap"
+	"sync"
+)
+
+// Publisher keeps the A and AAAA records of the machines in a tailnet in sync with the
+// external DNS provider, for tailnets that have opted in to publishing their MagicDNS records.
+type Publisher interface {
+	SyncTailnet(tailnetID uint64)
+}
+
+func NewPublisher(provider Provider, repository domain.Repository) Publisher {
+	if provider == nil {
+		return &noopPublisher{}
+	}
+
+	return &publisher{
+		provider:   provider,
+		repository: repository,
+	}
+}
+
+type noopPublisher struct {
+}
+
+func (n *noopPublisher) SyncTailnet(uint64) {
+}
+
+type publisher struct {
+	sync.Mutex
+	provider   Provider
+	repository domain.Repository
+}
+
+type recordKey struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+func (p *publisher) SyncTailnet(tailnetID uint64) {
+	go func() {
+		p.Lock()
+		defer p.Unlock()
+
+		if err := p.syncTailnet(context.Background(), tailnetID); err != nil {
+			zap.L().Error("unable to publish dns records", zap.Uint64("tailnet_id", tailnetID), zap.Error(err))
+		}
+	}()
+}
+
+func (p *publisher) syncTailnet(ctx context.Context, tailnetID uint64) error {
+	desired, err := p.desiredRecords(ctx, tailnetID)
+	if err != nil {
+		return err
+	}
+
+	current, err := p.repository.ListPublishedDNSRecordsByTailnet(ctx, tailnetID)
+	if err != nil {
+		return err
+	}
+
+	var published = make(map[recordKey]bool)
+
+	// remove stale records first, a name that moved to another machine is then re-created with the new address
+	for _, r := range current {
+		k := recordKey{Type: r.Type, Name: r.Name, Value: r.Value}
+		if machineID, ok := desired[k]; ok && machineID == r.MachineID {
+			published[k] = true
+			continue
+		}
+
+		if err := p.provider.DeleteRecord(ctx, r.Type, r.Name, r.Value); err != nil {
+			return err
+		}
+
+		if err := p.repository.DeletePublishedDNSRecord(ctx, r.ID); err != nil {
+			return err
+		}
+	}
+
+	for k, machineID := range desired {
+		if published[k] {
+			continue
+		}
+
+		if err := p.provider.SetRecord(ctx, k.Type, k.Name, k.Value); err != nil {
+			return err
+		}
+
+		record := &domain.PublishedDNSRecord{
+			ID:        util.NextID(),
+			TailnetID: tailnetID,
+			MachineID: machineID,
+			Type:      k.Type,
+			Name:      k.Name,
+			Value:     k.Value,
+		}
+
+		if err := p.repository.SavePublishedDNSRecord(ctx, record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *publisher) desiredRecords(ctx context.Context, tailnetID uint64) (map[recordKey]uint64, error) {
+	var result = make(map[recordKey]uint64)
+
+	tailnet, err := p.repository.GetTailnet(ctx, tailnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tailnet == nil || !tailnet.DNSConfig.MagicDNS || !tailnet.DNSConfig.PublishRecords {
+		return result, nil
+	}
+
+	machines, err := p.repository.ListMachineByTailnet(ctx, tailnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	tailnetDomain := domain.SanitizeTailnetName(tailnet.Name)
+
+	for _, m := range machines {
+		if !m.Authorized {
+			continue
+		}
+
+		name := fmt.Sprintf("%s.%s.%s", m.CompleteName(), tailnetDomain, config.MagicDNSSuffix())
+
+		if m.IPv4.Addr != nil {
+			result[recordKey{Type: "A", Name: name, Value: m.IPv4.String()}] = m.ID
+		}
+		if m.IPv6.Addr != nil {
+			result[recordKey{Type: "AAAA", Name: name, Value: m.IPv6.String()}] = m.ID
+		}
+	}
+
+	return result, nil
+}
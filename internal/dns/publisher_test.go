@@ -0,0 +1,161 @@
+package dns
+
+import (
+	"context"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/database"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/util"
+	"github.com/libdns/libdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/netip"
+	"sync"
+	"tailscale.com/types/key"
+	"testing"
+	"time"
+)
+
+func TestPublisher_SyncTailnet(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+	records := newFakeLibdnsProvider()
+	p := &publisher{
+		provider:   &externalProvider{zone: fqdn(config.MagicDNSSuffix()), provider: records},
+		repository: repository,
+	}
+
+	tailnet := &domain.Tailnet{
+		ID:        util.NextID(),
+		Name:      "example.com",
+		IAMPolicy: domain.NewHuJSON(&domain.IAMPolicy{}),
+		ACLPolicy: domain.NewHuJSON(&domain.ACLPolicy{}),
+		DNSConfig: domain.DNSConfig{MagicDNS: true, PublishRecords: true},
+	}
+	require.NoError(t, repository.SaveTailnet(ctx, tailnet))
+
+	web := createTestMachine(t, repository, tailnet, "web", "100.64.0.1", "fd7a:115c:a1e0::1")
+	db := createTestMachine(t, repository, tailnet, "db", "100.64.0.2", "fd7a:115c:a1e0::2")
+
+	require.NoError(t, p.syncTailnet(ctx, tailnet.ID))
+	assert.ElementsMatch(t, []string{
+		"A web.example.com 100.64.0.1",
+		"AAAA web.example.com fd7a:115c:a1e0::1",
+		"A db.example.com 100.64.0.2",
+		"AAAA db.example.com fd7a:115c:a1e0::2",
+	}, records.list())
+
+	published, err := repository.ListPublishedDNSRecordsByTailnet(ctx, tailnet.ID)
+	require.NoError(t, err)
+	assert.Len(t, published, 4)
+
+	// a synchronization without changes doesn't touch the provider
+	records.calls = 0
+	require.NoError(t, p.syncTailnet(ctx, tailnet.ID))
+	assert.Equal(t, 0, records.calls)
+
+	// a changed address replaces the record, a deleted machine removes its records
+	newIP := netip.MustParseAddr("100.64.0.3")
+	web.IPv4 = domain.IP{Addr: &newIP}
+	require.NoError(t, repository.SaveMachine(ctx, web))
+	_, err = repository.DeleteMachine(ctx, db.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, p.syncTailnet(ctx, tailnet.ID))
+	assert.ElementsMatch(t, []string{
+		"A web.example.com 100.64.0.3",
+		"AAAA web.example.com fd7a:115c:a1e0::1",
+	}, records.list())
+
+	// disabling the publication removes all records
+	tailnet.DNSConfig.PublishRecords = false
+	require.NoError(t, repository.SaveTailnet(ctx, tailnet))
+
+	require.NoError(t, p.syncTailnet(ctx, tailnet.ID))
+	assert.Empty(t, records.list())
+
+	published, err = repository.ListPublishedDNSRecordsByTailnet(ctx, tailnet.ID)
+	require.NoError(t, err)
+	assert.Empty(t, published)
+}
+
+func openTestRepository(t *testing.T) domain.Repository {
+	t.Setenv("IONSCALE_MACHINE_ID", "1")
+
+	_, repository, err := database.OpenDB(&config.Database{
+		Type:         "sqlite",
+		Url:          t.TempDir() + "/ionscale.db?_pragma=busy_timeout(5000)",
+		MaxOpenConns: 1,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	return repository
+}
+
+func createTestMachine(t *testing.T, repository domain.Repository, tailnet *domain.Tailnet, name string, ipv4 string, ipv6 string) *domain.Machine {
+	user, _, err := repository.GetOrCreateServiceUser(context.Background(), tailnet)
+	require.NoError(t, err)
+
+	v4, v6 := netip.MustParseAddr(ipv4), netip.MustParseAddr(ipv6)
+	m := &domain.Machine{
+		ID:         util.NextID(),
+		Name:       name,
+		MachineKey: key.NewMachine().Public().String(),
+		NodeKey:    key.NewNode().Public().String(),
+		IPv4:       domain.IP{Addr: &v4},
+		IPv6:       domain.IP{Addr: &v6},
+		Authorized: true,
+		Tags:       domain.Tags{"tag:server"},
+		CreatedAt:  time.Now().UTC(),
+		ExpiresAt:  time.Now().UTC().Add(24 * time.Hour),
+		TailnetID:  tailnet.ID,
+		UserID:     user.ID,
+	}
+	require.NoError(t, repository.SaveMachine(context.Background(), m))
+	return m
+}
+
+// fakeLibdnsProvider keeps the records of a single zone in memory.
+type fakeLibdnsProvider struct {
+	sync.Mutex
+	records map[libdns.Record]bool
+	calls   int
+}
+
+func newFakeLibdnsProvider() *fakeLibdnsProvider {
+	return &fakeLibdnsProvider{records: map[libdns.Record]bool{}}
+}
+
+func (f *fakeLibdnsProvider) SetRecords(_ context.Context, _ string, records []libdns.Record) ([]libdns.Record, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.calls++
+	for _, r := range records {
+		f.records[libdns.Record{Type: r.Type, Name: r.Name, Value: r.Value}] = true
+	}
+	return records, nil
+}
+
+func (f *fakeLibdnsProvider) DeleteRecords(_ context.Context, _ string, records []libdns.Record) ([]libdns.Record, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.calls++
+	for _, r := range records {
+		delete(f.records, libdns.Record{Type: r.Type, Name: r.Name, Value: r.Value})
+	}
+	return records, nil
+}
+
+func (f *fakeLibdnsProvider) list() []string {
+	f.Lock()
+	defer f.Unlock()
+
+	var result []string
+	for r := range f.records {
+		result = append(result, r.Type+" "+r.Name+" "+r.Value)
+	}
+	return result
+}
@@ -2,9 +2,13 @@ package domain
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/jsiebens/ionscale/internal/util"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 	"time"
 )
 
@@ -12,12 +16,45 @@ type AccountRepository interface {
 	GetAccount(ctx context.Context, accountID uint64) (*Account, error)
 	GetOrCreateAccount(ctx context.Context, externalID, loginName string) (*Account, bool, error)
 	SetAccountLastAuthenticated(ctx context.Context, accountID uint64) error
+	SetAccountAttributes(ctx context.Context, accountID uint64, attributes AccountAttributes) error
 }
 
 type Account struct {
 	ID         uint64 `gorm:"primary_key"`
 	ExternalID string
 	LoginName  string
+	Attributes AccountAttributes
+}
+
+// AccountAttributes are the claims of the identity provider stored on the account at login, e.g. posix_username.
+type AccountAttributes map[string]string
+
+func (i *AccountAttributes) Scan(destination interface{}) error {
+	switch value := destination.(type) {
+	case []byte:
+		return json.Unmarshal(value, i)
+	case string:
+		return json.Unmarshal([]byte(value), i)
+	default:
+		return fmt.Errorf("unexpected data type %T", destination)
+	}
+}
+
+func (i AccountAttributes) Value() (driver.Value, error) {
+	bytes, err := json.Marshal(i)
+	return bytes, err
+}
+
+func (AccountAttributes) GormDataType() string {
+	return "json"
+}
+
+func (AccountAttributes) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return "JSON"
+	}
+	return ""
 }
 
 func (r *repository) GetOrCreateAccount(ctx context.Context, externalID, loginName string) (*Account, bool, error) {
@@ -64,3 +101,16 @@ func (r *repository) SetAccountLastAuthenticated(ctx context.Context, accountID
 
 	return nil
 }
+
+func (r *repository) SetAccountAttributes(ctx context.Context, accountID uint64, attributes AccountAttributes) error {
+	tx := r.withContext(ctx).
+		Model(Account{}).
+		Where("id = ?", accountID).
+		Updates(map[string]interface{}{"attributes": attributes})
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
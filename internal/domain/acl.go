@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"tailscale.com/tailcfg"
+	"time"
 )
 
 const (
@@ -24,6 +25,7 @@ const (
 	AutoGroupTagged    = "autogroup:tagged"
 	AutoGroupInternet  = "autogroup:internet"
 	AutoGroupDangerAll = "autogroup:danger-all"
+	AutoGroupRecorder  = "autogroup:recorder"
 )
 
 type AutoApprovers struct {
@@ -45,11 +47,55 @@ func (a *ACLPolicy) Equal(x *ACLPolicy) bool {
 	return reflect.DeepEqual(a, x)
 }
 
-func (a ACLPolicy) FindAutoApprovedIPs(routableIPs []netip.Prefix, tags []string, u *User) []netip.Prefix {
-	if a.AutoApprovers == nil || len(routableIPs) == 0 {
+// Validate checks the values of the policy that are not verified when parsing it.
+func (a ACLPolicy) Validate() error {
+	var result *multierror.Error
+	for i, rule := range a.SSH {
+		if rule.SessionDuration != "" {
+			if d, err := time.ParseDuration(rule.SessionDuration); err != nil || d <= 0 {
+				result = multierror.Append(result, fmt.Errorf("ssh rule %d: invalid session duration [%s]", i, rule.SessionDuration))
+			}
+		}
+	}
+	for i, attr := range a.NodeAttrs {
+		for c, values := range attr.App {
+			if strings.TrimSpace(string(c)) == "" {
+				result = multierror.Append(result, fmt.Errorf("node attribute %d: empty capability name", i))
+			}
+			for _, v := range values {
+				if !json.Valid([]byte(v)) {
+					result = multierror.Append(result, fmt.Errorf("node attribute %d: invalid value for capability [%s]", i, c))
+				}
+			}
+		}
+	}
+	for i, c := range a.ACLPolicy.AppConnectors {
+		if err := validateAppConnector(c); err != nil {
+			result = multierror.Append(result, fmt.Errorf("app connector %d: %w", i, err))
+		}
+	}
+	for _, tag := range a.Recorders {
+		if !strings.HasPrefix(tag, "tag:") {
+			result = multierror.Append(result, fmt.Errorf("recorders: [%s] is not a tag", tag))
+		}
+	}
+	return result.ErrorOrNil()
+}
+
+// FindAutoApprovedIPs returns the advertised routes approved by the auto approvers of the policy, and the routes
+// learned by an app connector. The subnet routes are the routes served by the subnet routers of the tailnet,
+// the addresses within these are never approved as learned routes.
+func (a ACLPolicy) FindAutoApprovedIPs(routableIPs []netip.Prefix, tags []string, u *User, subnetRoutes []netip.Prefix) []netip.Prefix {
+	if len(routableIPs) == 0 {
 		return nil
 	}
 
+	var result = a.findAppConnectorRoutes(routableIPs, tags, subnetRoutes)
+
+	if a.AutoApprovers == nil {
+		return result
+	}
+
 	matches := func(values []string) bool {
 		for _, alias := range values {
 			if alias == u.Name {
@@ -97,8 +143,10 @@ func (a ACLPolicy) FindAutoApprovedIPs(routableIPs []netip.Prefix, tags []string
 		}
 	}
 
-	var result []netip.Prefix
 	for _, c := range routableIPs {
+		if slices.Contains(result, c) {
+			continue
+		}
 		if c.Bits() == 0 && matches(a.AutoApprovers.ExitNode) {
 			result = append(result, c)
 		}
@@ -120,6 +168,17 @@ func (a ACLPolicy) CheckTagOwners(tags []string, p *User) error {
 	return result.ErrorOrNil()
 }
 
+// CheckTagsDefined returns an error for each tag without tag owners in the policy.
+func (a ACLPolicy) CheckTagsDefined(tags []string) error {
+	var result *multierror.Error
+	for _, t := range tags {
+		if _, ok := a.TagOwners[t]; !ok {
+			result = multierror.Append(result, fmt.Errorf("tag [%s] is not defined in the tag owners", t))
+		}
+	}
+	return result.ErrorOrNil()
+}
+
 func (a ACLPolicy) isTagOwner(tag string, p *User) bool {
 	if p.UserType == UserTypeService {
 		return true
@@ -140,38 +199,30 @@ func (a ACLPolicy) isTagOwner(tag string, p *User) bool {
 	return false
 }
 
-func (a ACLPolicy) NodeCapabilities(m *Machine) []tailcfg.NodeCapability {
-	var result = &StringSet{}
-
-	matches := func(targets []string) bool {
-		for _, alias := range targets {
-			if alias == "*" {
+// IsSSHApprover reports whether the user with the given login name is listed in the approvers,
+// either directly or as a member of one of the groups.
+func (a ACLPolicy) IsSSHApprover(approvers []string, name string) bool {
+	for _, alias := range approvers {
+		if strings.HasPrefix(alias, "group:") {
+			if group, ok := a.Groups[alias]; ok && slices.Contains(group, name) {
 				return true
 			}
-
-			if strings.Contains(alias, "@") && !m.HasTags() && m.HasUser(alias) {
-				return true
-			}
-
-			if strings.HasPrefix(alias, "tag:") && m.HasTag(alias) {
-				return true
-			}
-
-			if strings.HasPrefix(alias, "group:") && !m.HasTags() {
-				for _, u := range a.Groups[alias] {
-					if m.HasUser(u) {
-						return true
-					}
-				}
-			}
+		} else if alias == name {
+			return true
 		}
-
-		return false
 	}
+	return false
+}
+
+func (a ACLPolicy) NodeCapabilities(m *Machine) []tailcfg.NodeCapability {
+	var result = &StringSet{}
 
 	for _, nodeAddr := range a.NodeAttrs {
-		if matches(nodeAddr.Target) {
+		if a.matchesTarget(m, nodeAddr.Target) {
 			result.Add(nodeAddr.Attr...)
+			for c := range nodeAddr.App {
+				result.Add(string(c))
+			}
 		}
 	}
 
@@ -184,6 +235,50 @@ func (a ACLPolicy) NodeCapabilities(m *Machine) []tailcfg.NodeCapability {
 	return caps
 }
 
+// NodeCapMap returns the capabilities granted to the machine with their values,
+// the values of all matching node attributes are merged per capability.
+func (a ACLPolicy) NodeCapMap(m *Machine) tailcfg.NodeCapMap {
+	var result = make(tailcfg.NodeCapMap)
+
+	for _, c := range a.NodeCapabilities(m) {
+		result[c] = []tailcfg.RawMessage{}
+	}
+
+	for _, nodeAddr := range a.NodeAttrs {
+		if a.matchesTarget(m, nodeAddr.Target) {
+			for c, values := range nodeAddr.App {
+				result[c] = append(result[c], values...)
+			}
+		}
+	}
+
+	return result
+}
+
+// matchesTarget reports whether the machine is selected by any of the given
+// targets, being a wildcard, a user, a tag or a group.
+func (a ACLPolicy) matchesTarget(m *Machine, targets []string) bool {
+	for _, alias := range targets {
+		if alias == "*" {
+			return true
+		}
+
+		if strings.Contains(alias, "@") && !m.HasTags() && m.HasUser(alias) {
+			return true
+		}
+
+		if strings.HasPrefix(alias, "tag:") && m.HasTag(alias) {
+			return true
+		}
+
+		if strings.HasPrefix(alias, "group:") && a.isGroupMember(alias, m) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (a ACLPolicy) parsePortRanges(s string) ([]tailcfg.PortRange, error) {
 	if s == "*" {
 		return []tailcfg.PortRange{tailcfg.PortRangeAny}, nil
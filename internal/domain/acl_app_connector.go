@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jsiebens/ionscale/pkg/client/ionscale"
+	"net/netip"
+	"slices"
+	"strings"
+	"tailscale.com/net/tsaddr"
+	"tailscale.com/tailcfg"
+	"tailscale.com/types/appctype"
+	"tailscale.com/util/dnsname"
+)
+
+// AppConnectorCapability delivers the app connector configurations to the connector nodes.
+const AppConnectorCapability tailcfg.NodeCapability = "tailscale.com/app-connectors"
+
+// AppConnectorsFor returns the app connectors served by the machine, as values of the app connector capability.
+func (a ACLPolicy) AppConnectorsFor(m *Machine) []tailcfg.RawMessage {
+	var result []tailcfg.RawMessage
+	for _, c := range a.ACLPolicy.AppConnectors {
+		if !isAppConnectorFor(c, m.Tags) {
+			continue
+		}
+
+		attr := appctype.AppConnectorAttr{
+			Name:       c.Name,
+			Domains:    c.Domains,
+			Connectors: c.Connectors,
+		}
+
+		raw, err := json.Marshal(attr)
+		if err != nil {
+			continue
+		}
+		result = append(result, tailcfg.RawMessage(raw))
+	}
+	return result
+}
+
+// findAppConnectorRoutes returns the routes learned by an app connector while resolving the domains of its apps.
+// Only single public addresses are approved, so that a connector can't take over the addresses of the tailnet
+// or the addresses of the subnets served by subnet routers.
+func (a ACLPolicy) findAppConnectorRoutes(routableIPs []netip.Prefix, tags []string, subnetRoutes []netip.Prefix) []netip.Prefix {
+	if !slices.ContainsFunc(a.ACLPolicy.AppConnectors, func(c ionscale.ACLAppConnector) bool { return isAppConnectorFor(c, tags) }) {
+		return nil
+	}
+
+	var result []netip.Prefix
+	for _, r := range routableIPs {
+		if isAppConnectorRoute(r, subnetRoutes) && !slices.Contains(result, r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func isAppConnectorRoute(r netip.Prefix, subnetRoutes []netip.Prefix) bool {
+	if !r.IsSingleIP() {
+		return false
+	}
+
+	addr := r.Addr()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || tsaddr.IsTailscaleIP(addr) {
+		return false
+	}
+
+	return !slices.ContainsFunc(subnetRoutes, func(s netip.Prefix) bool { return s.Contains(addr) })
+}
+
+// isAppConnectorFor reports whether a node with the given tags is a connector of the app,
+// the wildcard selects all tagged nodes.
+func isAppConnectorFor(c ionscale.ACLAppConnector, tags []string) bool {
+	for _, connector := range c.Connectors {
+		if connector == "*" && len(tags) != 0 {
+			return true
+		}
+		for _, t := range tags {
+			if connector == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validateAppConnector(c ionscale.ACLAppConnector) error {
+	if len(c.Connectors) == 0 {
+		return fmt.Errorf("no connectors")
+	}
+
+	for _, connector := range c.Connectors {
+		if connector != "*" && !strings.HasPrefix(connector, "tag:") {
+			return fmt.Errorf("invalid connector [%s], must be a tag", connector)
+		}
+	}
+
+	if len(c.Domains) == 0 {
+		return fmt.Errorf("no domains")
+	}
+
+	for _, d := range c.Domains {
+		if _, err := dnsname.ToFQDN(strings.TrimPrefix(d, "*.")); err != nil {
+			return fmt.Errorf("invalid domain [%s]", d)
+		}
+	}
+
+	return nil
+}
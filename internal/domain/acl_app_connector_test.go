@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/jsiebens/ionscale/pkg/client/ionscale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/netip"
+	"tailscale.com/types/appctype"
+	"testing"
+)
+
+func TestACLPolicy_AppConnectorsFor(t *testing.T) {
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			AppConnectors: []ionscale.ACLAppConnector{
+				{
+					Name:       "github",
+					Connectors: []string{"tag:github-connector"},
+					Domains:    []string{"github.com", "*.github.com"},
+				},
+				{
+					Name:       "all",
+					Connectors: []string{"*"},
+					Domains:    []string{"example.com"},
+				},
+			},
+		},
+	}
+
+	attrs := func(m *Machine) []appctype.AppConnectorAttr {
+		var result []appctype.AppConnectorAttr
+		for _, raw := range policy.AppConnectorsFor(m) {
+			var attr appctype.AppConnectorAttr
+			require.NoError(t, json.Unmarshal([]byte(raw), &attr))
+			result = append(result, attr)
+		}
+		return result
+	}
+
+	assert.Equal(t, []appctype.AppConnectorAttr{
+		{Name: "github", Connectors: []string{"tag:github-connector"}, Domains: []string{"github.com", "*.github.com"}},
+		{Name: "all", Connectors: []string{"*"}, Domains: []string{"example.com"}},
+	}, attrs(createMachine("john@example.com", "tag:github-connector")))
+
+	assert.Equal(t, []appctype.AppConnectorAttr{
+		{Name: "all", Connectors: []string{"*"}, Domains: []string{"example.com"}},
+	}, attrs(createMachine("john@example.com", "tag:web")))
+
+	assert.Nil(t, attrs(createMachine("john@example.com")))
+}
+
+func TestACLPolicy_FindAutoApprovedIPsForAppConnectors(t *testing.T) {
+	learned1 := netip.MustParsePrefix("140.82.112.3/32")
+	learned2 := netip.MustParsePrefix("2606:50c0:8000::154/128")
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			AppConnectors: []ionscale.ACLAppConnector{
+				{
+					Name:       "github",
+					Connectors: []string{"tag:github-connector"},
+					Domains:    []string{"github.com"},
+				},
+			},
+		},
+	}
+
+	routes := []netip.Prefix{
+		learned1,
+		netip.MustParsePrefix("140.82.112.0/20"),
+		learned2,
+		netip.MustParsePrefix("10.160.0.12/32"),
+		netip.MustParsePrefix("192.168.1.4/32"),
+		netip.MustParsePrefix("fd7a:115c:a1e0::1/128"),
+		netip.MustParsePrefix("100.64.0.5/32"),
+		netip.MustParsePrefix("127.0.0.1/32"),
+		netip.MustParsePrefix("203.0.113.10/32"),
+		netip.MustParsePrefix("0.0.0.0/0"),
+	}
+
+	subnetRoutes := []netip.Prefix{netip.MustParsePrefix("203.0.113.0/24")}
+
+	// only the single public addresses learned by a connector are approved
+	assert.Equal(t, []netip.Prefix{learned1, learned2}, policy.FindAutoApprovedIPs(routes, []string{"tag:github-connector"}, &User{}, subnetRoutes))
+	assert.Nil(t, policy.FindAutoApprovedIPs(routes, []string{"tag:web"}, &User{}, subnetRoutes))
+	assert.Nil(t, policy.FindAutoApprovedIPs(routes, nil, &User{Name: "john@example.com"}, subnetRoutes))
+
+	// addresses served by a subnet router are not taken over by a connector
+	learned3 := netip.MustParsePrefix("203.0.113.10/32")
+	assert.Equal(t, []netip.Prefix{learned1, learned2, learned3}, policy.FindAutoApprovedIPs(routes, []string{"tag:github-connector"}, &User{}, nil))
+
+	policy.AutoApprovers = &ionscale.ACLAutoApprovers{
+		Routes: map[string][]string{
+			"140.82.112.0/20": {"tag:github-connector"},
+			"10.160.0.0/20":   {"tag:github-connector"},
+		},
+	}
+
+	// auto approvers still approve the advertised ranges, without duplicating the learned routes
+	assert.Equal(t,
+		[]netip.Prefix{learned1, learned2, netip.MustParsePrefix("140.82.112.0/20"), netip.MustParsePrefix("10.160.0.12/32")},
+		policy.FindAutoApprovedIPs(routes, []string{"tag:github-connector"}, &User{}, subnetRoutes),
+	)
+	assert.Nil(t, policy.FindAutoApprovedIPs(routes, []string{"tag:web"}, &User{}, subnetRoutes))
+}
+
+func TestMachines_SubnetRoutes(t *testing.T) {
+	subnet1 := netip.MustParsePrefix("10.160.0.0/20")
+	subnet2 := netip.MustParsePrefix("192.168.0.0/24")
+
+	machines := Machines{
+		{AllowIPs: []netip.Prefix{subnet1, netip.MustParsePrefix("0.0.0.0/0"), netip.MustParsePrefix("::/0")}},
+		{AutoAllowIPs: []netip.Prefix{subnet2, netip.MustParsePrefix("140.82.112.3/32")}},
+		{AllowIPs: []netip.Prefix{subnet1}},
+		{},
+	}
+
+	assert.Equal(t, []netip.Prefix{subnet1, subnet2}, machines.SubnetRoutes())
+}
+
+func TestACLPolicy_ValidateAppConnectors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		connector ionscale.ACLAppConnector
+		valid     bool
+	}{
+		{
+			name:      "valid",
+			connector: ionscale.ACLAppConnector{Name: "github", Connectors: []string{"tag:connector"}, Domains: []string{"github.com", "*.github.com"}},
+			valid:     true,
+		},
+		{
+			name:      "wildcard connector",
+			connector: ionscale.ACLAppConnector{Name: "github", Connectors: []string{"*"}, Domains: []string{"github.com"}},
+			valid:     true,
+		},
+		{
+			name:      "no connectors",
+			connector: ionscale.ACLAppConnector{Name: "github", Domains: []string{"github.com"}},
+		},
+		{
+			name:      "user as connector",
+			connector: ionscale.ACLAppConnector{Name: "github", Connectors: []string{"john@example.com"}, Domains: []string{"github.com"}},
+		},
+		{
+			name:      "no domains",
+			connector: ionscale.ACLAppConnector{Name: "github", Connectors: []string{"tag:connector"}},
+		},
+		{
+			name:      "invalid domain",
+			connector: ionscale.ACLAppConnector{Name: "github", Connectors: []string{"tag:connector"}, Domains: []string{"github..com"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := ACLPolicy{ionscale.ACLPolicy{AppConnectors: []ionscale.ACLAppConnector{tc.connector}}}
+			if tc.valid {
+				assert.NoError(t, policy.Validate())
+			} else {
+				assert.Error(t, policy.Validate())
+			}
+		})
+	}
+}
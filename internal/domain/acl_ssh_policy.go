@@ -1,22 +1,29 @@
 package domain
 
 import (
+	"fmt"
 	"github.com/jsiebens/ionscale/pkg/client/ionscale"
 	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"tailscale.com/tailcfg"
+	"time"
 )
 
-func (a ACLPolicy) BuildSSHPolicy(srcs []Machine, dst *Machine) *tailcfg.SSHPolicy {
+// BuildSSHPolicy creates the SSH policy for the destination machine, recorders for which isHealthy
+// reports false are left out, unless none of them is healthy. A nil isHealthy treats all recorders as healthy.
+func (a ACLPolicy) BuildSSHPolicy(srcs []Machine, dst *Machine, isHealthy func(m *Machine) bool) *tailcfg.SSHPolicy {
 	var rules []*tailcfg.SSHRule
 
-	expandSrcAliases := func(aliases []string, action string, u *User) []*tailcfg.SSHPrincipal {
+	expandSrcAliases := func(machines []Machine, aliases []string, action string, u *User) []*tailcfg.SSHPrincipal {
 		var allSrcIPsSet = &StringSet{}
 		for _, alias := range aliases {
 			if strings.HasPrefix(alias, "tag:") && action == "check" {
 				continue
 			}
-			for _, src := range srcs {
+			for _, src := range machines {
 				srcIPs := a.expandSSHSrcAlias(&src, alias, u)
 				allSrcIPsSet.Add(srcIPs...)
 			}
@@ -31,17 +38,29 @@ func (a ACLPolicy) BuildSSHPolicy(srcs []Machine, dst *Machine) *tailcfg.SSHPoli
 	}
 
 	expandRecorderAliases := func(aliases []string) []netip.AddrPort {
-		result := make([]netip.AddrPort, 0)
+		all := make([]netip.AddrPort, 0)
+		healthy := make([]netip.AddrPort, 0)
+		seen := map[uint64]bool{}
 
 		for _, alias := range aliases {
 			for _, src := range append(srcs, *dst) {
-				if src.HasTag(alias) {
-					result = append(result, netip.AddrPortFrom(*src.IPv4.Addr, 80))
+				if a.isRecorderAlias(&src, alias) && !seen[src.ID] {
+					seen[src.ID] = true
+					addrs := src.RecorderAddrs()
+					all = append(all, addrs...)
+					if isHealthy == nil || isHealthy(&src) {
+						healthy = append(healthy, addrs...)
+					}
 				}
 			}
 		}
 
-		return result
+		// without any healthy recorder, list them all and let the enforcement of the rule decide
+		if len(healthy) == 0 {
+			return all
+		}
+
+		return healthy
 	}
 
 	for _, rule := range a.SSH {
@@ -49,21 +68,40 @@ func (a ACLPolicy) BuildSSHPolicy(srcs []Machine, dst *Machine) *tailcfg.SSHPoli
 			continue
 		}
 
-		var action = &tailcfg.SSHAction{
-			Accept:                   true,
-			AllowAgentForwarding:     true,
-			AllowLocalPortForwarding: true,
+		// a rule with invalid options grants no access, instead of a session without limits
+		options, err := sshSessionOptions(rule)
+		if err != nil {
+			continue
 		}
 
+		var action = options.Accept()
+
 		if rule.Action == "check" {
 			action = &tailcfg.SSHAction{
 				HoldAndDelegate: "https://unused/machine/ssh/action/$SRC_NODE_ID/to/$DST_NODE_ID/" + safeCheckPeriod(rule.CheckPeriod),
 			}
+
+			// the options of the session are applied when the check is completed
+			query := url.Values{}
+			options.Encode(query)
+
+			if len(rule.Approvers) != 0 {
+				query["approvers"] = rule.Approvers
+			}
+
+			// the ssh users are expanded by the destination node, and are kept in the audit of the check
+			action.HoldAndDelegate = action.HoldAndDelegate + "?ssh_user=$SSH_USER&local_user=$LOCAL_USER"
+
+			if len(query) != 0 {
+				action.HoldAndDelegate = action.HoldAndDelegate + "&" + query.Encode()
+			}
 		}
 
+		action.Message = sshMessage(rule.Message)
+
 		if len(rule.Recorder) != 0 {
 			action.Recorders = expandRecorderAliases(rule.Recorder)
-			action.Message = "# This session is being recorded.\n"
+			action.Message = action.Message + "# This session is being recorded.\n"
 			if rule.EnforceRecorder {
 				action.OnRecordingFailure = &tailcfg.SSHRecorderFailureAction{
 					RejectSessionWithMessage:    "# Session rejected: failed to start session recording.",
@@ -72,27 +110,38 @@ func (a ACLPolicy) BuildSSHPolicy(srcs []Machine, dst *Machine) *tailcfg.SSHPoli
 			}
 		}
 
-		selfUsers, otherUsers := a.expandSSHDstToSSHUsers(dst, rule)
+		appendRules := func(machines []Machine, selfUsers, otherUsers map[string]string) {
+			if len(selfUsers) != 0 {
+				principals := expandSrcAliases(machines, rule.Source, rule.Action, &dst.User)
+				if len(principals) != 0 {
+					rules = append(rules, &tailcfg.SSHRule{
+						Principals: principals,
+						SSHUsers:   selfUsers,
+						Action:     action,
+					})
+				}
+			}
 
-		if len(selfUsers) != 0 {
-			principals := expandSrcAliases(rule.Source, rule.Action, &dst.User)
-			if len(principals) != 0 {
-				rules = append(rules, &tailcfg.SSHRule{
-					Principals: principals,
-					SSHUsers:   selfUsers,
-					Action:     action,
-				})
+			if len(otherUsers) != 0 {
+				principals := expandSrcAliases(machines, rule.Source, rule.Action, nil)
+				if len(principals) != 0 {
+					rules = append(rules, &tailcfg.SSHRule{
+						Principals: principals,
+						SSHUsers:   otherUsers,
+						Action:     action,
+					})
+				}
 			}
 		}
 
-		if len(otherUsers) != 0 {
-			principals := expandSrcAliases(rule.Source, rule.Action, nil)
-			if len(principals) != 0 {
-				rules = append(rules, &tailcfg.SSHRule{
-					Principals: principals,
-					SSHUsers:   otherUsers,
-					Action:     action,
-				})
+		selfUsers, otherUsers := a.expandSSHDstToSSHUsers(dst, rule, nil)
+		appendRules(srcs, selfUsers, otherUsers)
+
+		// users mapped from the identity of the connecting user result in a rule per source user
+		if hasSSHUserMappings(rule.Users) {
+			for _, g := range groupMachinesByUser(srcs) {
+				selfUsers, otherUsers := a.expandSSHDstToSSHUsers(dst, rule, &g[0].User)
+				appendRules(g, selfUsers, otherUsers)
 			}
 		}
 	}
@@ -140,8 +189,15 @@ func (a ACLPolicy) expandSSHSrcAlias(m *Machine, alias string, dstUser *User) []
 	return []string{}
 }
 
-func (a ACLPolicy) expandSSHDstToSSHUsers(m *Machine, rule ionscale.ACLSSH) (map[string]string, map[string]string) {
-	users := buildSSHUsers(rule.Users)
+// expandSSHDstToSSHUsers returns the ssh users of the rule when the machine is a destination,
+// either the literal users or, when a source user is given, the users mapped from its identity.
+func (a ACLPolicy) expandSSHDstToSSHUsers(m *Machine, rule ionscale.ACLSSH, src *User) (map[string]string, map[string]string) {
+	var users map[string]string
+	if src == nil {
+		users = buildSSHUsers(rule.Users)
+	} else {
+		users = buildMappedSSHUsers(rule.Users, src)
+	}
 
 	var selfUsers map[string]string
 	var otherUsers map[string]string
@@ -163,6 +219,9 @@ func buildSSHUsers(users []string) map[string]string {
 	var autogroupNonRoot = false
 	m := make(map[string]string)
 	for _, u := range users {
+		if isSSHUserMapping(u) {
+			continue
+		}
 		if u == "autogroup:nonroot" {
 			m["*"] = "="
 			autogroupNonRoot = true
@@ -179,9 +238,225 @@ func buildSSHUsers(users []string) map[string]string {
 	return m
 }
 
+const (
+	sshUserMappingLocalPart = "localpart:"
+	sshUserMappingAttribute = "attr:"
+)
+
+var validLocalUser = regexp.MustCompile(`^[a-z_][a-z0-9_.-]*$`)
+
+func isSSHUserMapping(u string) bool {
+	return strings.HasPrefix(u, sshUserMappingLocalPart) || strings.HasPrefix(u, sshUserMappingAttribute)
+}
+
+func hasSSHUserMappings(users []string) bool {
+	return slices.ContainsFunc(users, isSSHUserMapping)
+}
+
+func buildMappedSSHUsers(users []string, src *User) map[string]string {
+	m := make(map[string]string)
+	for _, u := range users {
+		if local, ok := mapSSHUser(u, src); ok {
+			m[local] = local
+		}
+	}
+	return m
+}
+
+// mapSSHUser resolves the local user for the connecting user, e.g. "localpart:*@example.com"
+// maps john@example.com to john, "attr:posix_username" takes the attribute stored on the account.
+func mapSSHUser(mapping string, src *User) (string, bool) {
+	var local string
+
+	switch {
+	case strings.HasPrefix(mapping, sshUserMappingLocalPart):
+		_, domain, ok := strings.Cut(strings.TrimPrefix(mapping, sshUserMappingLocalPart), "*@")
+		if !ok {
+			return "", false
+		}
+		name, userDomain, ok := strings.Cut(src.Name, "@")
+		if !ok || !strings.EqualFold(userDomain, domain) {
+			return "", false
+		}
+		local = strings.ToLower(name)
+	case strings.HasPrefix(mapping, sshUserMappingAttribute):
+		if src.Account == nil {
+			return "", false
+		}
+		local = src.Account.Attributes[strings.TrimPrefix(mapping, sshUserMappingAttribute)]
+	default:
+		return "", false
+	}
+
+	if !validLocalUser.MatchString(local) {
+		return "", false
+	}
+
+	return local, true
+}
+
+// groupMachinesByUser groups the machines owned by a user, tagged machines have no identity to map.
+func groupMachinesByUser(machines []Machine) [][]Machine {
+	var result [][]Machine
+	index := map[uint64]int{}
+
+	for _, m := range machines {
+		if m.HasTags() {
+			continue
+		}
+		if i, ok := index[m.UserID]; ok {
+			result[i] = append(result[i], m)
+		} else {
+			index[m.UserID] = len(result)
+			result = append(result, []Machine{m})
+		}
+	}
+
+	return result
+}
+
 func safeCheckPeriod(period string) string {
 	if period == "" {
 		return "always"
 	}
 	return period
 }
+
+// isRecorderAlias reports whether the machine is a recorder for the alias of the recorder field of an SSH rule.
+// A tag selects all machines with that tag, autogroup:recorder selects the machines registered as a recorder
+// with one of the tags approved in the recorders of the policy.
+func (a ACLPolicy) isRecorderAlias(m *Machine, alias string) bool {
+	if alias == AutoGroupRecorder {
+		return a.IsApprovedRecorder(m)
+	}
+	return m.HasTag(alias)
+}
+
+// IsApprovedRecorder reports whether the machine registered as a recorder, with a tag approved by the policy.
+func (a ACLPolicy) IsApprovedRecorder(m *Machine) bool {
+	if !m.IsRecorder() {
+		return false
+	}
+	for _, tag := range a.Recorders {
+		if m.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSessionRecorder reports whether the policy sends recordings to the machine, as an approved recorder
+// or with a tag in the recorder field of an SSH rule.
+func (a ACLPolicy) IsSessionRecorder(m *Machine) bool {
+	if !m.IsRecorder() {
+		return false
+	}
+	if a.IsApprovedRecorder(m) {
+		return true
+	}
+	for _, rule := range a.SSH {
+		for _, alias := range rule.Recorder {
+			if alias != AutoGroupRecorder && m.HasTag(alias) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SSHSessionOptions are the per-rule options of an accepted SSH session.
+type SSHSessionOptions struct {
+	SessionDuration           time.Duration
+	AllowAgentForwarding      bool
+	AllowLocalPortForwarding  bool
+	AllowRemotePortForwarding bool
+}
+
+func sshSessionOptions(rule ionscale.ACLSSH) (SSHSessionOptions, error) {
+	duration, err := parseSessionDuration(rule.SessionDuration)
+	if err != nil {
+		return SSHSessionOptions{}, err
+	}
+
+	return SSHSessionOptions{
+		SessionDuration:           duration,
+		AllowAgentForwarding:      boolOrDefault(rule.AllowAgentForwarding, true),
+		AllowLocalPortForwarding:  boolOrDefault(rule.AllowLocalPortForwarding, true),
+		AllowRemotePortForwarding: rule.AllowRemotePortForwarding,
+	}, nil
+}
+
+// ParseSSHSessionOptions reads the options back from the query of a check url,
+// missing values fall back to the defaults.
+func ParseSSHSessionOptions(query url.Values) (SSHSessionOptions, error) {
+	duration, err := parseSessionDuration(query.Get("session_duration"))
+	if err != nil {
+		return SSHSessionOptions{}, err
+	}
+
+	return SSHSessionOptions{
+		SessionDuration:           duration,
+		AllowAgentForwarding:      query.Get("agent_forwarding") != "false",
+		AllowLocalPortForwarding:  query.Get("local_port_forwarding") != "false",
+		AllowRemotePortForwarding: query.Get("remote_port_forwarding") == "true",
+	}, nil
+}
+
+// parseSessionDuration parses an optional session duration, an empty value means the session has no limit.
+func parseSessionDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("invalid session duration [%s]", value)
+	}
+
+	return duration, nil
+}
+
+// Encode adds the options deviating from the defaults to the query of a check url.
+func (o SSHSessionOptions) Encode(query url.Values) {
+	if o.SessionDuration != 0 {
+		query.Set("session_duration", o.SessionDuration.String())
+	}
+	if !o.AllowAgentForwarding {
+		query.Set("agent_forwarding", "false")
+	}
+	if !o.AllowLocalPortForwarding {
+		query.Set("local_port_forwarding", "false")
+	}
+	if o.AllowRemotePortForwarding {
+		query.Set("remote_port_forwarding", "true")
+	}
+}
+
+// Accept creates the action accepting a session with these options.
+func (o SSHSessionOptions) Accept() *tailcfg.SSHAction {
+	return &tailcfg.SSHAction{
+		Accept:                    true,
+		SessionDuration:           o.SessionDuration,
+		AllowAgentForwarding:      o.AllowAgentForwarding,
+		AllowLocalPortForwarding:  o.AllowLocalPortForwarding,
+		AllowRemotePortForwarding: o.AllowRemotePortForwarding,
+	}
+}
+
+func sshMessage(message string) string {
+	if message == "" || strings.HasSuffix(message, "\n") {
+		return message
+	}
+	return message + "\n"
+}
+
+func boolOrDefault(v *bool, def bool) bool {
+	if v == nil {
+		return def
+	}
+	return *v
+}
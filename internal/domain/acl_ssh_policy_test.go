@@ -3,8 +3,10 @@ package domain
 import (
 	"github.com/jsiebens/ionscale/pkg/client/ionscale"
 	"github.com/stretchr/testify/assert"
+	"net/url"
 	"tailscale.com/tailcfg"
 	"testing"
+	"time"
 )
 
 func TestACLPolicy_BuildSSHPolicy_(t *testing.T) {
@@ -26,7 +28,7 @@ func TestACLPolicy_BuildSSHPolicy_(t *testing.T) {
 
 	dst := createMachine("john@example.com")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 	expectedRules := []*tailcfg.SSHRule{
 		{
 			Principals: []*tailcfg.SSHPrincipal{
@@ -72,7 +74,7 @@ func TestACLPolicy_BuildSSHPolicy_WithGroup(t *testing.T) {
 
 	dst := createMachine("john@example.com", "tag:web")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 	expectedRules := []*tailcfg.SSHRule{
 		{
 			Principals: []*tailcfg.SSHPrincipal{
@@ -113,7 +115,7 @@ func TestACLPolicy_BuildSSHPolicy_WithMatchingUsers(t *testing.T) {
 
 	dst := createMachine("john@example.com")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 	expectedRules := []*tailcfg.SSHRule{
 		{
 			Principals: sshPrincipalsFromMachines(*p1),
@@ -154,7 +156,7 @@ func TestACLPolicy_BuildSSHPolicy_WithMatchingUsersInGroup(t *testing.T) {
 
 	dst := createMachine("john@example.com")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 	expectedRules := []*tailcfg.SSHRule{
 		{
 			Principals: sshPrincipalsFromMachines(*p1),
@@ -192,7 +194,7 @@ func TestACLPolicy_BuildSSHPolicy_WithNoMatchingUsers(t *testing.T) {
 
 	dst := createMachine("john@example.com")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 
 	assert.Nil(t, actualRules.Rules)
 }
@@ -217,7 +219,7 @@ func TestACLPolicy_BuildSSHPolicy_WithTags(t *testing.T) {
 
 	dst := createMachine("john@example.com", "tag:web")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2, *p3}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2, *p3}, dst, nil)
 	expectedRules := []*tailcfg.SSHRule{
 		{
 			Principals: sshPrincipalsFromMachines(*p1, *p3),
@@ -255,7 +257,7 @@ func TestACLPolicy_BuildSSHPolicy_WithTagsInDstAndAutogroupMemberInSrc(t *testin
 
 	dst := createMachine("john@example.com", "tag:web")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2, *p3}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2, *p3}, dst, nil)
 	expectedRules := []*tailcfg.SSHRule{
 		{
 			Principals: sshPrincipalsFromMachines(*p1, *p2),
@@ -292,7 +294,7 @@ func TestACLPolicy_BuildSSHPolicy_WithUserInDstAndNonMatchingSrc(t *testing.T) {
 
 	dst := createMachine("john@example.com")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 
 	assert.Nil(t, actualRules.Rules)
 }
@@ -316,7 +318,7 @@ func TestACLPolicy_BuildSSHPolicy_WithUserInDstAndAutogroupMembersSrc(t *testing
 
 	dst := createMachine("john@example.com")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 	expectedRules := []*tailcfg.SSHRule{
 		{
 			Principals: sshPrincipalsFromMachines(*p1),
@@ -354,7 +356,7 @@ func TestACLPolicy_BuildSSHPolicy_WithAutogroupSelfAndTagSrc(t *testing.T) {
 
 	dst := createMachine("john@example.com")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 
 	assert.Nil(t, actualRules.Rules)
 }
@@ -378,11 +380,254 @@ func TestACLPolicy_BuildSSHPolicy_WithTagsAndActionCheck(t *testing.T) {
 
 	dst := createMachine("john@example.com", "tag:web")
 
-	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst)
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2}, dst, nil)
 
 	assert.Nil(t, actualRules.Rules)
 }
 
+func TestACLPolicy_BuildSSHPolicy_WithApprovers(t *testing.T) {
+	p1 := createMachine("john@example.com")
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			SSH: []ionscale.ACLSSH{
+				{
+					Action:      "check",
+					Source:      []string{"john@example.com"},
+					Destination: []string{"tag:prod"},
+					Users:       []string{"root"},
+					Approvers:   []string{"group:sre"},
+				},
+			},
+		},
+	}
+
+	dst := createMachine("jane@example.com", "tag:prod")
+
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1}, dst, nil)
+
+	assert.Len(t, actualRules.Rules, 1)
+	assert.Equal(t, "https://unused/machine/ssh/action/$SRC_NODE_ID/to/$DST_NODE_ID/always?ssh_user=$SSH_USER&local_user=$LOCAL_USER&approvers=group%3Asre", actualRules.Rules[0].Action.HoldAndDelegate)
+}
+
+func TestACLPolicy_BuildSSHPolicy_WithSessionOptions(t *testing.T) {
+	p1 := createMachine("john@example.com")
+	disabled := false
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			SSH: []ionscale.ACLSSH{
+				{
+					Action:                    "accept",
+					Source:                    []string{"john@example.com"},
+					Destination:               []string{"tag:prod"},
+					Users:                     []string{"root"},
+					SessionDuration:           "15m",
+					AllowAgentForwarding:      &disabled,
+					AllowLocalPortForwarding:  &disabled,
+					AllowRemotePortForwarding: true,
+					Message:                   "Production host, be careful!",
+				},
+			},
+		},
+	}
+
+	dst := createMachine("jane@example.com", "tag:prod")
+
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1}, dst, nil)
+
+	assert.Len(t, actualRules.Rules, 1)
+	assert.Equal(t, &tailcfg.SSHAction{
+		Accept:                    true,
+		Message:                   "Production host, be careful!\n",
+		SessionDuration:           15 * time.Minute,
+		AllowAgentForwarding:      false,
+		AllowLocalPortForwarding:  false,
+		AllowRemotePortForwarding: true,
+	}, actualRules.Rules[0].Action)
+}
+
+func TestACLPolicy_BuildSSHPolicy_WithSessionOptionsAndActionCheck(t *testing.T) {
+	p1 := createMachine("john@example.com")
+	disabled := false
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			SSH: []ionscale.ACLSSH{
+				{
+					Action:               "check",
+					Source:               []string{"john@example.com"},
+					Destination:          []string{"tag:prod"},
+					Users:                []string{"root"},
+					SessionDuration:      "1h",
+					AllowAgentForwarding: &disabled,
+				},
+			},
+		},
+	}
+
+	dst := createMachine("jane@example.com", "tag:prod")
+
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1}, dst, nil)
+
+	assert.Len(t, actualRules.Rules, 1)
+
+	action := actualRules.Rules[0].Action
+	assert.Equal(t, "https://unused/machine/ssh/action/$SRC_NODE_ID/to/$DST_NODE_ID/always?ssh_user=$SSH_USER&local_user=$LOCAL_USER&agent_forwarding=false&session_duration=1h0m0s", action.HoldAndDelegate)
+
+	u, err := url.Parse(action.HoldAndDelegate)
+	assert.NoError(t, err)
+	options, err := ParseSSHSessionOptions(u.Query())
+	assert.NoError(t, err)
+	assert.Equal(t, SSHSessionOptions{SessionDuration: time.Hour, AllowLocalPortForwarding: true}, options)
+}
+
+func TestACLPolicy_ValidateSessionDuration(t *testing.T) {
+	valid := ACLPolicy{ionscale.ACLPolicy{SSH: []ionscale.ACLSSH{{Action: "accept", SessionDuration: "30m"}}}}
+	invalid := ACLPolicy{ionscale.ACLPolicy{SSH: []ionscale.ACLSSH{{Action: "accept", SessionDuration: "forever"}}}}
+
+	assert.NoError(t, valid.Validate())
+	assert.Error(t, invalid.Validate())
+}
+
+func TestACLPolicy_BuildSSHPolicy_InvalidSessionDuration(t *testing.T) {
+	p1 := createMachine("john@example.com")
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			SSH: []ionscale.ACLSSH{
+				{
+					Action:          "accept",
+					Source:          []string{"john@example.com"},
+					Destination:     []string{"tag:prod"},
+					Users:           []string{"root"},
+					SessionDuration: "8hrs",
+				},
+			},
+		},
+	}
+
+	dst := createMachine("jane@example.com", "tag:prod")
+
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1}, dst, nil)
+
+	assert.Empty(t, actualRules.Rules)
+}
+
+func TestParseSSHSessionOptions_InvalidSessionDuration(t *testing.T) {
+	_, err := ParseSSHSessionOptions(url.Values{"session_duration": []string{"8hrs"}})
+	assert.Error(t, err)
+}
+
+func TestACLPolicy_BuildSSHPolicy_WithHealthyRecorders(t *testing.T) {
+	p1 := createMachine("john@example.com")
+	r1 := createMachine("john@example.com", "tag:recorder")
+	r1.ID = 1
+	r1.HostInfo.App = RecorderApp + ":8080"
+	r2 := createMachine("john@example.com", "tag:recorder")
+	r2.ID = 2
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			SSH: []ionscale.ACLSSH{
+				{
+					Action:      "accept",
+					Source:      []string{"autogroup:members"},
+					Destination: []string{"autogroup:self"},
+					Users:       []string{"root"},
+					Recorder:    []string{"tag:recorder"},
+				},
+			},
+		},
+	}
+
+	dst := createMachine("john@example.com")
+
+	healthy := func(m *Machine) bool { return m.ID == 1 }
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *r1, *r2}, dst, healthy)
+
+	assert.Len(t, actualRules.Rules, 1)
+	assert.Equal(t, r1.RecorderAddrs(), actualRules.Rules[0].Action.Recorders)
+	assert.Equal(t, uint16(8080), actualRules.Rules[0].Action.Recorders[1].Port())
+
+	// without any healthy recorder, all of them are listed
+	unhealthy := func(m *Machine) bool { return false }
+	actualRules = policy.BuildSSHPolicy([]Machine{*p1, *r1, *r2}, dst, unhealthy)
+
+	assert.Equal(t, append(r1.RecorderAddrs(), r2.RecorderAddrs()...), actualRules.Rules[0].Action.Recorders)
+}
+
+func TestACLPolicy_BuildSSHPolicy_WithDiscoveredRecorders(t *testing.T) {
+	p1 := createMachine("john@example.com")
+	r1 := createMachine("john@example.com", "tag:recorder")
+	r1.ID = 1
+	r1.HostInfo.App = RecorderApp + ":8080"
+	r2 := createMachine("john@example.com", "tag:other")
+	r2.ID = 2
+	r2.HostInfo.App = RecorderApp + ":8080"
+	r3 := createMachine("john@example.com", "tag:recorder")
+	r3.ID = 3
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			Recorders: []string{"tag:recorder"},
+			SSH: []ionscale.ACLSSH{
+				{
+					Action:      "accept",
+					Source:      []string{"autogroup:members"},
+					Destination: []string{"autogroup:self"},
+					Users:       []string{"root"},
+					Recorder:    []string{"autogroup:recorder"},
+				},
+			},
+		},
+	}
+
+	dst := createMachine("john@example.com")
+
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *r1, *r2, *r3}, dst, nil)
+
+	// only registered recorders with an approved tag are discovered
+	assert.Len(t, actualRules.Rules, 1)
+	assert.Equal(t, r1.RecorderAddrs(), actualRules.Rules[0].Action.Recorders)
+
+	assert.True(t, policy.IsApprovedRecorder(r1))
+	assert.False(t, policy.IsApprovedRecorder(r2))
+	assert.False(t, policy.IsApprovedRecorder(r3))
+}
+
+func TestACLPolicy_ValidateRecorders(t *testing.T) {
+	valid := ACLPolicy{ionscale.ACLPolicy{Recorders: []string{"tag:recorder"}}}
+	invalid := ACLPolicy{ionscale.ACLPolicy{Recorders: []string{"recorder"}}}
+
+	assert.NoError(t, valid.Validate())
+	assert.Error(t, invalid.Validate())
+}
+
+func TestACLPolicy_IsSessionRecorder(t *testing.T) {
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			Recorders: []string{"tag:recorder"},
+			SSH: []ionscale.ACLSSH{
+				{Action: "accept", Recorder: []string{"tag:audit"}},
+			},
+		},
+	}
+
+	approved := createMachine("john@example.com", "tag:recorder")
+	approved.HostInfo.App = RecorderApp
+	named := createMachine("john@example.com", "tag:audit")
+	named.HostInfo.App = RecorderApp
+	untagged := createMachine("john@example.com")
+	untagged.HostInfo.App = RecorderApp
+	unregistered := createMachine("john@example.com", "tag:audit")
+
+	assert.True(t, policy.IsSessionRecorder(approved))
+	assert.True(t, policy.IsSessionRecorder(named))
+	assert.False(t, policy.IsSessionRecorder(untagged))
+	assert.False(t, policy.IsSessionRecorder(unregistered))
+}
+
 func sshPrincipalsFromMachines(machines ...Machine) []*tailcfg.SSHPrincipal {
 	x := StringSet{}
 	for _, m := range machines {
@@ -397,3 +642,108 @@ func sshPrincipalsFromMachines(machines ...Machine) []*tailcfg.SSHPrincipal {
 
 	return result
 }
+
+func TestACLPolicy_BuildSSHPolicy_WithLocalPartMapping(t *testing.T) {
+	p1 := createMachine("john@example.com")
+	p1.UserID, p1.User.ID = 1, 1
+	p2 := createMachine("jane@example.com")
+	p2.UserID, p2.User.ID = 2, 2
+	p3 := createMachine("nick@other.com")
+	p3.UserID, p3.User.ID = 3, 3
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			SSH: []ionscale.ACLSSH{
+				{
+					Action:      "accept",
+					Source:      []string{"autogroup:members"},
+					Destination: []string{"tag:web"},
+					Users:       []string{"localpart:*@example.com"},
+				},
+			},
+		},
+	}
+
+	dst := createMachine("john@example.com", "tag:web")
+
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2, *p3}, dst, nil)
+
+	action := &tailcfg.SSHAction{
+		Accept:                   true,
+		AllowAgentForwarding:     true,
+		AllowLocalPortForwarding: true,
+	}
+
+	expectedRules := []*tailcfg.SSHRule{
+		{
+			Principals: []*tailcfg.SSHPrincipal{
+				{NodeIP: p1.IPv4.String()},
+				{NodeIP: p1.IPv6.String()},
+			},
+			SSHUsers: map[string]string{"john": "john"},
+			Action:   action,
+		},
+		{
+			Principals: []*tailcfg.SSHPrincipal{
+				{NodeIP: p2.IPv4.String()},
+				{NodeIP: p2.IPv6.String()},
+			},
+			SSHUsers: map[string]string{"jane": "jane"},
+			Action:   action,
+		},
+	}
+
+	assert.Equal(t, expectedRules, actualRules.Rules)
+}
+
+func TestACLPolicy_BuildSSHPolicy_WithAttributeMapping(t *testing.T) {
+	p1 := createMachine("john@example.com")
+	p1.UserID, p1.User.ID = 1, 1
+	p1.User.Account = &Account{Attributes: AccountAttributes{"posix_username": "jdoe"}}
+	p2 := createMachine("jane@example.com")
+	p2.UserID, p2.User.ID = 2, 2
+	p2.User.Account = &Account{Attributes: AccountAttributes{"posix_username": "Invalid User"}}
+	p3 := createMachine("nick@example.com")
+	p3.UserID, p3.User.ID = 3, 3
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			SSH: []ionscale.ACLSSH{
+				{
+					Action:      "accept",
+					Source:      []string{"autogroup:members"},
+					Destination: []string{"tag:web"},
+					Users:       []string{"root", "attr:posix_username"},
+				},
+			},
+		},
+	}
+
+	dst := createMachine("john@example.com", "tag:web")
+
+	actualRules := policy.BuildSSHPolicy([]Machine{*p1, *p2, *p3}, dst, nil)
+
+	action := &tailcfg.SSHAction{
+		Accept:                   true,
+		AllowAgentForwarding:     true,
+		AllowLocalPortForwarding: true,
+	}
+
+	expectedRules := []*tailcfg.SSHRule{
+		{
+			Principals: sshPrincipalsFromMachines(*p1, *p2, *p3),
+			SSHUsers:   map[string]string{"root": "root"},
+			Action:     action,
+		},
+		{
+			Principals: []*tailcfg.SSHPrincipal{
+				{NodeIP: p1.IPv4.String()},
+				{NodeIP: p1.IPv6.String()},
+			},
+			SSHUsers: map[string]string{"jdoe": "jdoe"},
+			Action:   action,
+		},
+	}
+
+	assert.Equal(t, expectedRules, actualRules.Rules)
+}
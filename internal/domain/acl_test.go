@@ -113,6 +113,68 @@ func TestACLPolicy_NodeAttributesWithUserAndTags(t *testing.T) {
 	assert.Equal(t, expectedAttrs, actualAttrs)
 }
 
+func TestACLPolicy_NodeCapMapWithValues(t *testing.T) {
+	p1 := createMachine("john@example.com", "tag:web")
+
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			NodeAttrs: []ionscale.ACLNodeAttrGrant{
+				{
+					Target: []string{"*"},
+					Attr:   []string{"attr1"},
+					App: tailcfg.NodeCapMap{
+						"example.com/cap/ports": []tailcfg.RawMessage{`[443]`},
+					},
+				},
+				{
+					Target: []string{"tag:web"},
+					App: tailcfg.NodeCapMap{
+						"example.com/cap/ports": []tailcfg.RawMessage{`[8443]`},
+						"example.com/cap/derp":  []tailcfg.RawMessage{`{"home":"fra"}`},
+					},
+				},
+				{
+					Target: []string{"tag:db"},
+					App: tailcfg.NodeCapMap{
+						"example.com/cap/ports": []tailcfg.RawMessage{`[5432]`},
+					},
+				},
+			},
+		},
+	}
+
+	expectedCapMap := tailcfg.NodeCapMap{
+		"attr1":                 []tailcfg.RawMessage{},
+		"example.com/cap/derp":  []tailcfg.RawMessage{`{"home":"fra"}`},
+		"example.com/cap/ports": []tailcfg.RawMessage{`[443]`, `[8443]`},
+	}
+	expectedAttrs := []tailcfg.NodeCapability{"attr1", "example.com/cap/derp", "example.com/cap/ports"}
+
+	assert.Equal(t, expectedCapMap, policy.NodeCapMap(p1))
+	assert.Equal(t, expectedAttrs, policy.NodeCapabilities(p1))
+}
+
+func TestACLPolicy_ValidateNodeAttrValues(t *testing.T) {
+	var policy ionscale.ACLPolicy
+	require.NoError(t, json.Unmarshal([]byte(`{"nodeAttrs":[{"target":["*"],"app":{"example.com/cap/ports":[[443],{"port":8443}]}}]}`), &policy))
+	assert.NoError(t, ACLPolicy{policy}.Validate())
+
+	invalid := ACLPolicy{
+		ionscale.ACLPolicy{
+			NodeAttrs: []ionscale.ACLNodeAttrGrant{
+				{
+					Target: []string{"*"},
+					App: tailcfg.NodeCapMap{
+						"example.com/cap/ports": []tailcfg.RawMessage{`[443`},
+						"":                      []tailcfg.RawMessage{`{}`},
+					},
+				},
+			},
+		},
+	}
+	assert.Error(t, invalid.Validate())
+}
+
 func TestACLPolicy_BuildFilterRulesEmptyACL(t *testing.T) {
 	p1 := createMachine("john@example.com")
 	p2 := createMachine("jane@example.com")
@@ -867,7 +929,7 @@ func TestACLPolicy_FindAutoApprovedIPsWhenNoAutoapproversAreSet(t *testing.T) {
 	route3 := netip.MustParsePrefix("10.162.0.0/20")
 
 	policy := ACLPolicy{}
-	assert.Nil(t, policy.FindAutoApprovedIPs([]netip.Prefix{route1, route2, route3}, nil, nil))
+	assert.Nil(t, policy.FindAutoApprovedIPs([]netip.Prefix{route1, route2, route3}, nil, nil, nil))
 }
 
 func TestACLPolicy_FindAutoApprovedIPs(t *testing.T) {
@@ -959,7 +1021,7 @@ func TestACLPolicy_FindAutoApprovedIPs(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			actualAllowedIPs := policy.FindAutoApprovedIPs(tc.routableIPs, tc.tag, &User{Name: tc.userName})
+			actualAllowedIPs := policy.FindAutoApprovedIPs(tc.routableIPs, tc.tag, &User{Name: tc.userName}, nil)
 			assert.Equal(t, tc.expected, actualAllowedIPs)
 		})
 	}
@@ -1090,3 +1152,16 @@ func TestACLPolicy_BuildFilterRulesWithAppGrants(t *testing.T) {
 
 	assert.Equal(t, expectedRules, actualRules)
 }
+
+func TestACLPolicy_CheckTagsDefined(t *testing.T) {
+	p := ACLPolicy{
+		ionscale.ACLPolicy{
+			TagOwners: map[string][]string{
+				"tag:web": {"john@example.com"},
+			},
+		},
+	}
+
+	assert.NoError(t, p.CheckTagsDefined([]string{"tag:web"}))
+	assert.Error(t, p.CheckTagsDefined([]string{"tag:web", "tag:ci"}))
+}
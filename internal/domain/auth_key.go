@@ -42,8 +42,7 @@ type AuthKeyRepository interface {
 	DeleteAuthKey(ctx context.Context, id uint64) (bool, error)
 	DeleteAuthKeysByTailnet(ctx context.Context, tailnetID uint64) error
 	DeleteAuthKeysByUser(ctx context.Context, userID uint64) error
-	ListAuthKeys(ctx context.Context, tailnetID uint64) ([]AuthKey, error)
-	ListAuthKeysByTailnetAndUser(ctx context.Context, tailnetID, userID uint64) ([]AuthKey, error)
+	ListAuthKeys(ctx context.Context, filter AuthKeyFilter) ([]AuthKey, error)
 	LoadAuthKey(ctx context.Context, key string) (*AuthKey, error)
 }
 
@@ -65,6 +64,19 @@ type AuthKey struct {
 	User   User
 }
 
+// AuthKeyFilter selects auth keys of a tailnet, ordered by id, criteria left empty are ignored.
+type AuthKeyFilter struct {
+	TailnetID uint64
+	UserID    uint64
+
+	// Descending lists the most recently created auth keys first.
+	Descending bool
+
+	// AfterID continues the listing after the auth key with the given id, in the order of the filter.
+	AfterID uint64
+	Limit   int
+}
+
 func (r *repository) GetAuthKey(ctx context.Context, authKeyId uint64) (*AuthKey, error) {
 	var t AuthKey
 	tx := r.withContext(ctx).
@@ -114,26 +126,31 @@ func (r *repository) DeleteAuthKeysByUser(ctx context.Context, userID uint64) er
 	return tx.Error
 }
 
-func (r *repository) ListAuthKeys(ctx context.Context, tailnetID uint64) ([]AuthKey, error) {
+func (r *repository) ListAuthKeys(ctx context.Context, filter AuthKeyFilter) ([]AuthKey, error) {
 	var authKeys = []AuthKey{}
 	tx := (r.withContext(ctx).
 		Preload("User").
 		Preload("Tailnet")).
-		Where("tailnet_id = ?", tailnetID).
-		Find(&authKeys)
-	if tx.Error != nil {
-		return nil, tx.Error
+		Where("tailnet_id = ?", filter.TailnetID)
+
+	if filter.UserID != 0 {
+		tx = tx.Where("user_id = ?", filter.UserID)
 	}
-	return authKeys, nil
-}
 
-func (r *repository) ListAuthKeysByTailnetAndUser(ctx context.Context, tailnetID, userID uint64) ([]AuthKey, error) {
-	var authKeys = []AuthKey{}
-	tx := (r.withContext(ctx).
-		Preload("User").
-		Preload("Tailnet")).
-		Where("tailnet_id = ? and user_id = ?", tailnetID, userID).
-		Find(&authKeys)
+	dir, cmp := "asc", ">"
+	if filter.Descending {
+		dir, cmp = "desc", "<"
+	}
+
+	if filter.AfterID != 0 {
+		tx = tx.Where(fmt.Sprintf("id %s ?", cmp), filter.AfterID)
+	}
+
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+
+	tx = tx.Order(fmt.Sprintf("id %s", dir)).Find(&authKeys)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type DNSChallengeRecordRepository interface {
+	SaveDNSChallengeRecord(ctx context.Context, record *DNSChallengeRecord) error
+	ListDNSChallengeRecordsCreatedBefore(ctx context.Context, t time.Time) ([]DNSChallengeRecord, error)
+	DeleteDNSChallengeRecord(ctx context.Context, id uint64) error
+}
+
+type DNSChallengeRecord struct {
+	ID        uint64 `gorm:"primary_key"`
+	Type      string
+	Name      string
+	Value     string
+	CreatedAt time.Time
+}
+
+func (r *repository) SaveDNSChallengeRecord(ctx context.Context, record *DNSChallengeRecord) error {
+	tx := r.withContext(ctx).Save(record)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (r *repository) ListDNSChallengeRecordsCreatedBefore(ctx context.Context, t time.Time) ([]DNSChallengeRecord, error) {
+	var records = []DNSChallengeRecord{}
+
+	tx := r.withContext(ctx).Where("created_at < ?", t.UTC()).Find(&records)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return records, nil
+}
+
+func (r *repository) DeleteDNSChallengeRecord(ctx context.Context, id uint64) error {
+	tx := r.withContext(ctx).Delete(&DNSChallengeRecord{ID: id})
+	return tx.Error
+}
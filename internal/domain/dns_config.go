@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -19,6 +20,17 @@ type DNSConfig struct {
 	Routes            map[string][]string `json:"routes"`
 	SearchDomains     []string            `json:"search_domains"`
 	ExtraRecords      []tailcfg.DNSRecord `json:"extra_records"`
+	PublishRecords    bool                `json:"publish_records"`
+	Overlays          []DNSOverlay        `json:"overlays"`
+}
+
+// DNSOverlay holds additional nameservers, split DNS routes and search domains
+// for the machines matching one of the targets, using the same selectors as the ACL policy.
+type DNSOverlay struct {
+	Targets       []string            `json:"targets"`
+	Nameservers   []string            `json:"nameservers"`
+	Routes        map[string][]string `json:"routes"`
+	SearchDomains []string            `json:"search_domains"`
 }
 
 func (i *DNSConfig) Equal(x *DNSConfig) bool {
@@ -32,10 +44,55 @@ func (i *DNSConfig) Equal(x *DNSConfig) bool {
 	return i.MagicDNS == x.MagicDNS &&
 		i.HttpsCertsEnabled == x.HttpsCertsEnabled &&
 		i.OverrideLocalDNS == x.OverrideLocalDNS &&
+		i.PublishRecords == x.PublishRecords &&
 		reflect.DeepEqual(i.Nameservers, x.Nameservers) &&
 		reflect.DeepEqual(i.Routes, x.Routes) &&
 		reflect.DeepEqual(i.ExtraRecords, x.ExtraRecords) &&
-		reflect.DeepEqual(i.SearchDomains, x.SearchDomains)
+		reflect.DeepEqual(i.SearchDomains, x.SearchDomains) &&
+		reflect.DeepEqual(i.Overlays, x.Overlays)
+}
+
+// ForMachine returns the DNS configuration for a specific machine,
+// with all overlays targeting the machine merged on top of the tailnet-wide settings.
+func (i *DNSConfig) ForMachine(m *Machine, policy *ACLPolicy) DNSConfig {
+	result := *i
+	result.Overlays = nil
+
+	if len(i.Overlays) == 0 || policy == nil {
+		return result
+	}
+
+	result.Nameservers = slices.Clone(i.Nameservers)
+	result.SearchDomains = slices.Clone(i.SearchDomains)
+	result.Routes = make(map[string][]string)
+
+	for k, v := range i.Routes {
+		result.Routes[k] = slices.Clone(v)
+	}
+
+	for _, o := range i.Overlays {
+		if !policy.matchesTarget(m, o.Targets) {
+			continue
+		}
+
+		result.Nameservers = appendMissing(result.Nameservers, o.Nameservers...)
+		result.SearchDomains = appendMissing(result.SearchDomains, o.SearchDomains...)
+
+		for k, v := range o.Routes {
+			result.Routes[k] = appendMissing(result.Routes[k], v...)
+		}
+	}
+
+	return result
+}
+
+func appendMissing(s []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
 }
 
 func (i *DNSConfig) Scan(destination interface{}) error {
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"github.com/jsiebens/ionscale/pkg/client/ionscale"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDNSConfig_ForMachine(t *testing.T) {
+	policy := &ACLPolicy{
+		ionscale.ACLPolicy{
+			Groups: map[string][]string{
+				"group:eng": {"jane@example.com"},
+			},
+		},
+	}
+
+	config := &DNSConfig{
+		MagicDNS:      true,
+		Nameservers:   []string{"1.1.1.1"},
+		Routes:        map[string][]string{"example.com": {"10.0.0.1"}},
+		SearchDomains: []string{"example.com"},
+		Overlays: []DNSOverlay{
+			{
+				Targets: []string{"tag:k8s"},
+				Routes:  map[string][]string{"cluster.local": {"10.96.0.10"}},
+			},
+			{
+				Targets:       []string{"group:eng"},
+				Nameservers:   []string{"8.8.8.8"},
+				Routes:        map[string][]string{"example.com": {"10.0.0.2"}},
+				SearchDomains: []string{"corp.internal"},
+			},
+		},
+	}
+
+	k8s := config.ForMachine(createMachine("john@example.com", "tag:k8s"), policy)
+	assert.Equal(t, []string{"1.1.1.1"}, k8s.Nameservers)
+	assert.Equal(t, []string{"example.com"}, k8s.SearchDomains)
+	assert.Equal(t, map[string][]string{"example.com": {"10.0.0.1"}, "cluster.local": {"10.96.0.10"}}, k8s.Routes)
+	assert.Nil(t, k8s.Overlays)
+
+	jane := config.ForMachine(createMachine("jane@example.com"), policy)
+	assert.Equal(t, []string{"1.1.1.1", "8.8.8.8"}, jane.Nameservers)
+	assert.Equal(t, []string{"example.com", "corp.internal"}, jane.SearchDomains)
+	assert.Equal(t, map[string][]string{"example.com": {"10.0.0.1", "10.0.0.2"}}, jane.Routes)
+
+	john := config.ForMachine(createMachine("john@example.com"), policy)
+	assert.Equal(t, []string{"1.1.1.1"}, john.Nameservers)
+	assert.Equal(t, []string{"example.com"}, john.SearchDomains)
+	assert.Equal(t, map[string][]string{"example.com": {"10.0.0.1"}}, john.Routes)
+
+	// the tailnet wide configuration is left untouched
+	assert.Equal(t, map[string][]string{"example.com": {"10.0.0.1"}}, config.Routes)
+}
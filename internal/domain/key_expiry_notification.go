@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type KeyExpiryNotificationRepository interface {
+	CreateKeyExpiryNotification(ctx context.Context, n *KeyExpiryNotification) (bool, error)
+	DeleteKeyExpiryNotification(ctx context.Context, id uint64) error
+	DeleteKeyExpiryNotificationsBefore(ctx context.Context, t time.Time) error
+}
+
+// KeyExpiryNotification records that a machine was notified through a channel about its key expiry for a given threshold,
+// so that the notification is sent only once per channel, also after a restart. A machine that is re-authenticated
+// gets a new expiry date and is notified again.
+type KeyExpiryNotification struct {
+	ID        uint64 `gorm:"primary_key"`
+	MachineID uint64
+	ExpiresAt time.Time
+	Threshold time.Duration
+	Channel   string
+	CreatedAt time.Time
+}
+
+// CreateKeyExpiryNotification stores the notification, and returns false when it was already stored before.
+func (r *repository) CreateKeyExpiryNotification(ctx context.Context, n *KeyExpiryNotification) (bool, error) {
+	tx := r.withContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(n)
+
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	return tx.RowsAffected == 1, nil
+}
+
+func (r *repository) DeleteKeyExpiryNotification(ctx context.Context, id uint64) error {
+	tx := r.withContext(ctx).Delete(&KeyExpiryNotification{ID: id})
+	return tx.Error
+}
+
+func (r *repository) DeleteKeyExpiryNotificationsBefore(ctx context.Context, t time.Time) error {
+	tx := r.withContext(ctx).Where("expires_at < ?", t.UTC()).Delete(&KeyExpiryNotification{})
+	return tx.Error
+}
@@ -7,12 +7,22 @@ import (
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 	"net/netip"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"tailscale.com/tailcfg"
+	"tailscale.com/util/cmpver"
+	"tailscale.com/util/dnsname"
 	"time"
 )
 
+// DefaultKeyExpiry is the lifetime of the node key of a machine, after it is registered or authenticated again.
+const DefaultKeyExpiry = 180 * 24 * time.Hour
+
 type MachineRepository interface {
 	SaveMachine(ctx context.Context, m *Machine) error
 	DeleteMachine(ctx context.Context, id uint64) (bool, error)
@@ -22,11 +32,14 @@ type MachineRepository interface {
 	CountMachinesWithIPv4(ctx context.Context, ip string) (int64, error)
 	GetNextMachineNameIndex(ctx context.Context, tailnetID uint64, name string) (uint64, error)
 	ListMachineByTailnet(ctx context.Context, tailnetID uint64) (Machines, error)
+	ListMachinesByFilter(ctx context.Context, filter MachineFilter) (Machines, error)
 	CountMachineByTailnet(ctx context.Context, tailnetID uint64) (int64, error)
+	GetLatestClientVersions(ctx context.Context, tailnetID uint64) (ClientVersions, error)
 	DeleteMachineByTailnet(ctx context.Context, tailnetID uint64) error
 	DeleteMachineByUser(ctx context.Context, userID uint64) error
 	ListMachinePeers(ctx context.Context, tailnetID uint64, machineID uint64) (Machines, error)
 	ListInactiveEphemeralMachines(ctx context.Context, checkpoint time.Time) (Machines, error)
+	ListMachinesExpiringBefore(ctx context.Context, t time.Time) (Machines, error)
 	SetMachineLastSeen(ctx context.Context, machineID uint64) error
 }
 
@@ -34,14 +47,17 @@ type Machine struct {
 	ID                uint64 `gorm:"primary_key"`
 	Name              string
 	NameIdx           uint64
+	CustomName        bool
 	MachineKey        string
 	NodeKey           string
+	KeySignature      []byte
 	DiscoKey          string
 	Ephemeral         bool
 	RegisteredTags    Tags
 	Tags              Tags
 	KeyExpiryDisabled bool
 	Authorized        bool
+	Aliases           Tags
 
 	HostInfo     HostInfo
 	Endpoints    Endpoints
@@ -64,6 +80,59 @@ type Machine struct {
 
 type Machines []Machine
 
+const (
+	MachineOrderByName      = "name"
+	MachineOrderByLastSeen  = "last_seen"
+	MachineOrderByCreatedAt = "created_at"
+)
+
+// MachineFilter selects machines of a tailnet, ordered by name unless specified otherwise, criteria left empty are ignored.
+type MachineFilter struct {
+	TailnetID      uint64
+	Tag            string
+	UserID         uint64
+	Connected      *bool
+	ConnectedIDs   []uint64
+	Expired        *bool
+	Authorized     *bool
+	OS             string
+	NamePrefix     string
+	LastSeenAfter  *time.Time
+	LastSeenBefore *time.Time
+
+	// OrderBy is one of the MachineOrderBy values, machines with the same value are ordered by id.
+	OrderBy    string
+	Descending bool
+
+	// After continues the listing after the machine at the cursor, in the order of the filter.
+	After *MachineCursor
+	Limit int
+}
+
+// MachineCursor is the position of a machine in a listing, as returned by MachineFilter.CursorOf.
+type MachineCursor struct {
+	ID      uint64
+	Name    string
+	NameIdx uint64
+	Time    time.Time
+}
+
+// CursorOf returns the position of the machine in the listing of the filter.
+func (f MachineFilter) CursorOf(m *Machine) MachineCursor {
+	cursor := MachineCursor{ID: m.ID, Name: m.Name, NameIdx: m.NameIdx}
+	switch f.OrderBy {
+	case MachineOrderByLastSeen:
+		if m.LastSeen != nil {
+			cursor.Time = *m.LastSeen
+		} else {
+			cursor.Time = time.Unix(0, 0).UTC()
+		}
+	case MachineOrderByCreatedAt:
+		cursor.Time = m.CreatedAt
+	}
+	return cursor
+}
+
 func (m *Machine) CompleteName() string {
 	if m.NameIdx != 0 {
 		return fmt.Sprintf("%s-%d", m.Name, m.NameIdx)
@@ -71,6 +140,19 @@ func (m *Machine) CompleteName() string {
 	return m.Name
 }
 
+// AliasRecords returns the extra DNS records resolving the aliases of the machine to its addresses.
+func (m *Machine) AliasRecords() []tailcfg.DNSRecord {
+	var records []tailcfg.DNSRecord
+	for _, alias := range m.Aliases {
+		for _, ip := range []IP{m.IPv4, m.IPv6} {
+			if ip.Addr != nil && ip.IsValid() {
+				records = append(records, tailcfg.DNSRecord{Name: alias, Value: ip.String()})
+			}
+		}
+	}
+	return records
+}
+
 func (m *Machine) IPs() []string {
 	return []string{m.IPv4.String(), m.IPv6.String()}
 }
@@ -79,6 +161,26 @@ func (m *Machine) IsExpired() bool {
 	return !m.KeyExpiryDisabled && !m.ExpiresAt.IsZero() && m.ExpiresAt.Before(time.Now())
 }
 
+// KeyExpiryThreshold returns the smallest of the thresholds the remaining key lifetime of the machine falls within.
+func (m *Machine) KeyExpiryThreshold(now time.Time, thresholds []time.Duration) (time.Duration, bool) {
+	if m.KeyExpiryDisabled || m.ExpiresAt.IsZero() || !m.ExpiresAt.After(now) {
+		return 0, false
+	}
+
+	remaining := m.ExpiresAt.Sub(now)
+
+	var result time.Duration
+	var found bool
+	for _, t := range thresholds {
+		if remaining <= t && (!found || t < result) {
+			result = t
+			found = true
+		}
+	}
+
+	return result, found
+}
+
 func (m *Machine) HasIP(v netip.Addr) bool {
 	return v.Compare(*m.IPv4.Addr) == 0 || v.Compare(*m.IPv6.Addr) == 0
 }
@@ -92,6 +194,47 @@ func (m *Machine) HasTag(tag string) bool {
 	return false
 }
 
+// RecorderApp is the app a session recorder advertises in its host info, followed by the port
+// it receives recordings on, e.g. "ionscale-recorder:8080".
+const RecorderApp = "ionscale-recorder"
+
+const defaultRecorderPort = 80
+
+// IsRecorder reports whether the machine registered as a session recorder.
+func (m *Machine) IsRecorder() bool {
+	app, _, _ := strings.Cut(m.HostInfo.App, ":")
+	return app == RecorderApp
+}
+
+// RecorderPort returns the port advertised by a session recorder,
+// falling back to port 80 for machines not advertising one.
+func (m *Machine) RecorderPort() uint16 {
+	if !m.IsRecorder() {
+		return defaultRecorderPort
+	}
+
+	_, port, _ := strings.Cut(m.HostInfo.App, ":")
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || p == 0 {
+		return defaultRecorderPort
+	}
+
+	return uint16(p)
+}
+
+// RecorderAddrs returns the IPv4 and IPv6 addresses on which the machine receives recordings.
+func (m *Machine) RecorderAddrs() []netip.AddrPort {
+	var result []netip.AddrPort
+	port := m.RecorderPort()
+	if m.IPv4.Addr != nil && m.IPv4.IsValid() {
+		result = append(result, netip.AddrPortFrom(*m.IPv4.Addr, port))
+	}
+	if m.IPv6.Addr != nil && m.IPv6.IsValid() {
+		result = append(result, netip.AddrPortFrom(*m.IPv6.Addr, port))
+	}
+	return result
+}
+
 func (m *Machine) HasUser(loginName string) bool {
 	return m.User.Name == loginName
 }
@@ -133,6 +276,35 @@ func (m *Machine) AdvertisedPrefixes() []string {
 	return result
 }
 
+// EnableAdvertisedRoutes enables the given routes when advertised by the machine, or all advertised
+// subnet routes when none are given. It returns whether any route was enabled.
+func (m *Machine) EnableAdvertisedRoutes(routes []netip.Prefix) bool {
+	allowIPs := NewAllowIPsSet(m.AllowIPs)
+
+	var changed bool
+	for _, r := range m.HostInfo.RoutableIPs {
+		if len(routes) == 0 && r.Bits() == 0 || len(routes) != 0 && !slices.Contains(routes, r) {
+			continue
+		}
+		if !slices.Contains(m.AllowIPs, r) && !slices.Contains(m.AutoAllowIPs, r) {
+			allowIPs.Add(r)
+			changed = true
+		}
+	}
+
+	if changed {
+		m.AllowIPs = allowIPs.Items()
+	}
+
+	return changed
+}
+
+// MatchesName returns whether the name of the machine matches the glob pattern.
+func (m *Machine) MatchesName(pattern string) bool {
+	ok, _ := path.Match(pattern, m.CompleteName())
+	return ok
+}
+
 func (m *Machine) AllowedPrefixes() []string {
 	result := StringSet{}
 	for _, r := range m.AllowIPs {
@@ -148,6 +320,102 @@ func (m *Machine) AllowedPrefixes() []string {
 	return result.Items()
 }
 
+// ClientVersions are the newest known client versions, per release track.
+type ClientVersions struct {
+	Stable   string
+	Unstable string
+}
+
+// Merge returns the newest of both client versions, per release track.
+func (c ClientVersions) Merge(o ClientVersions) ClientVersions {
+	return latestClientVersions([]string{c.Stable, c.Unstable, o.Stable, o.Unstable})
+}
+
+// LatestClientVersions returns the newest client versions of the machines.
+func (m Machines) LatestClientVersions() ClientVersions {
+	var versions []string
+	for _, x := range m {
+		versions = append(versions, x.ClientVersion())
+	}
+	return latestClientVersions(versions)
+}
+
+// latestClientVersions returns the newest of the given versions, per release track.
+func latestClientVersions(versions []string) ClientVersions {
+	var result ClientVersions
+	for _, v := range versions {
+		if v == "" {
+			continue
+		}
+		if isUnstableClientVersion(v) {
+			if cmpver.Compare(v, result.Unstable) > 0 {
+				result.Unstable = v
+			}
+		} else if cmpver.Compare(v, result.Stable) > 0 {
+			result.Stable = v
+		}
+	}
+	return result
+}
+
+// UpdateAvailable reports whether a newer version is available on the release track of the given version.
+func (c ClientVersions) UpdateAvailable(version string) bool {
+	if version == "" {
+		return false
+	}
+	if isUnstableClientVersion(version) {
+		return cmpver.Compare(c.Unstable, version) > 0
+	}
+	return cmpver.Compare(c.Stable, version) > 0
+}
+
+// unstable releases of the client have an odd minor version
+func isUnstableClientVersion(v string) bool {
+	parts := strings.Split(v, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	return err == nil && minor%2 == 1
+}
+
+// RouteCandidates returns the machines serving each of the enabled routes, with Tailnet Lock enabled
+// only the machines trusted by the key authority are candidates.
+func (m Machines) RouteCandidates(keyAuthority *TailnetKeyAuthority) map[netip.Prefix][]uint64 {
+	result := map[netip.Prefix][]uint64{}
+	for _, x := range m {
+		if !keyAuthority.IsTrusted(&x) {
+			continue
+		}
+		for _, r := range slices.Concat(x.AllowIPs, x.AutoAllowIPs) {
+			if r.Bits() != 0 && !slices.Contains(result[r], x.ID) {
+				result[r] = append(result[r], x.ID)
+			}
+		}
+	}
+	return result
+}
+
+// SubnetRoutes returns the enabled routes of the machines acting as subnet router, single addresses
+// and exit node routes excluded.
+func (m Machines) SubnetRoutes() []netip.Prefix {
+	var result []netip.Prefix
+	for _, x := range m {
+		for _, r := range slices.Concat(x.AllowIPs, x.AutoAllowIPs) {
+			if r.Bits() != 0 && !r.IsSingleIP() && !slices.Contains(result, r) {
+				result = append(result, r)
+			}
+		}
+	}
+	return result
+}
+
+// ClientVersion returns the version of the client without build information, e.g. 1.64.2
+func (m *Machine) ClientVersion() string {
+	v, _, _ := strings.Cut(m.HostInfo.IPNVersion, "-")
+	return v
+}
+
 func (m *Machine) IsAllowedIP(i netip.Addr) bool {
 	if m.HasIP(i) {
 		return true
@@ -193,6 +461,85 @@ func (m *Machine) IsExitNode() bool {
 	return false
 }
 
+// NameTaken reports whether the complete name of a machine in the tailnet domain is already in use by another
+// of the machines, as its name or as an alias.
+func (m Machines) NameTaken(machineID uint64, completeName string, tailnetDomain string) bool {
+	fqdn := fmt.Sprintf("%s.%s", completeName, tailnetDomain)
+	for _, o := range m {
+		if o.ID == machineID {
+			continue
+		}
+		if o.CompleteName() == completeName || slices.Contains(o.Aliases, fqdn) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextMachineNameIndex returns the next name index for the given name in the tailnet, skipping indices for which the
+// complete name is already taken by another machine, e.g. a machine named 'web-1', or by an alias.
+func NextMachineNameIndex(ctx context.Context, r Repository, tailnet *Tailnet, magicDNSSuffix string, machineID uint64, name string) (uint64, error) {
+	nameIdx, err := r.GetNextMachineNameIndex(ctx, tailnet.ID, name)
+	if err != nil {
+		return 0, err
+	}
+
+	machines, err := r.ListMachineByTailnet(ctx, tailnet.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		candidate := Machine{Name: name, NameIdx: nameIdx}
+		if !machines.NameTaken(machineID, candidate.CompleteName(), tailnet.MachineDomain(magicDNSSuffix)) {
+			return nameIdx, nil
+		}
+		nameIdx++
+	}
+}
+
+// CheckAliases returns an error when an alias of the machine is already in use by another of the machines,
+// as an alias or as its name in the tailnet domain, or by an extra DNS record of the tailnet.
+func (m Machines) CheckAliases(machine *Machine, tailnet *Tailnet, magicDNSSuffix string) error {
+	tailnetDomain := tailnet.MachineDomain(magicDNSSuffix)
+	for _, alias := range machine.Aliases {
+		for _, r := range tailnet.DNSConfig.ExtraRecords {
+			if strings.TrimSuffix(strings.ToLower(r.Name), ".") == alias {
+				return fmt.Errorf("alias [%s] conflicts with an extra dns record", alias)
+			}
+		}
+
+		for _, o := range m {
+			if o.ID == machine.ID {
+				continue
+			}
+			if slices.Contains(o.Aliases, alias) || alias == fmt.Sprintf("%s.%s", o.CompleteName(), tailnetDomain) {
+				return fmt.Errorf("alias [%s] is already in use by machine [%s]", alias, o.CompleteName())
+			}
+		}
+	}
+	return nil
+}
+
+// SanitizeAliases normalizes the given aliases to lower case domain names
+// without a trailing dot, removing any duplicates.
+func SanitizeAliases(aliases []string) (Tags, error) {
+	s := StringSet{}
+	for _, a := range aliases {
+		name := strings.TrimSuffix(strings.ToLower(a), ".")
+		if name == "" {
+			return nil, fmt.Errorf("invalid alias [%s]: empty name", a)
+		}
+
+		if err := dnsname.ValidHostname(name); err != nil {
+			return nil, fmt.Errorf("invalid alias [%s]: %w", a, err)
+		}
+
+		s.Add(name)
+	}
+	return s.Items(), nil
+}
+
 type IP struct {
 	*netip.Addr
 }
@@ -367,9 +714,18 @@ func (r *repository) SaveMachine(ctx context.Context, machine *Machine) error {
 	return nil
 }
 
+// DeleteMachine deletes the machine and the shares of the machine.
 func (r *repository) DeleteMachine(ctx context.Context, id uint64) (bool, error) {
-	tx := r.withContext(ctx).Delete(&Machine{}, id)
-	return tx.RowsAffected == 1, tx.Error
+	var deleted bool
+	err := r.withContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("machine_id = ?", id).Delete(&MachineShare{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&Machine{}, id)
+		deleted = result.RowsAffected == 1
+		return result.Error
+	})
+	return deleted, err
 }
 
 func (r *repository) GetMachine(ctx context.Context, machineID uint64) (*Machine, error) {
@@ -461,13 +817,22 @@ func (r *repository) CountMachineByTailnet(ctx context.Context, tailnetID uint64
 }
 
 func (r *repository) DeleteMachineByTailnet(ctx context.Context, tailnetID uint64) error {
-	tx := r.withContext(ctx).Model(&Machine{}).Where("tailnet_id = ?", tailnetID).Delete(&Machine{})
-	return tx.Error
+	return r.deleteMachinesWhere(ctx, "tailnet_id = ?", tailnetID)
 }
 
 func (r *repository) DeleteMachineByUser(ctx context.Context, userID uint64) error {
-	tx := r.withContext(ctx).Model(&Machine{}).Where("user_id = ?", userID).Delete(&Machine{})
-	return tx.Error
+	return r.deleteMachinesWhere(ctx, "user_id = ?", userID)
+}
+
+// deleteMachinesWhere deletes the matching machines and the shares of those machines.
+func (r *repository) deleteMachinesWhere(ctx context.Context, query string, args ...interface{}) error {
+	return r.withContext(ctx).Transaction(func(tx *gorm.DB) error {
+		machineIDs := tx.Model(&Machine{}).Select("id").Where(query, args...)
+		if err := tx.Where("machine_id IN (?)", machineIDs).Delete(&MachineShare{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Machine{}).Where(query, args...).Delete(&Machine{}).Error
+	})
 }
 
 func (r *repository) ListMachineByTailnet(ctx context.Context, tailnetID uint64) (Machines, error) {
@@ -488,6 +853,105 @@ func (r *repository) ListMachineByTailnet(ctx context.Context, tailnetID uint64)
 	return machines, nil
 }
 
+func (r *repository) ListMachinesByFilter(ctx context.Context, filter MachineFilter) (Machines, error) {
+	var machines = []Machine{}
+
+	tx := r.withContext(ctx).
+		Preload("Tailnet").
+		Joins("User").
+		Joins("User.Account").
+		Where("machines.tailnet_id = ?", filter.TailnetID)
+
+	if filter.Tag != "" {
+		tx = tx.Where(`machines.tags LIKE ? ESCAPE '\'`, "%|"+likeEscaper.Replace(filter.Tag)+"|%")
+	}
+
+	if filter.UserID != 0 {
+		tx = tx.Where("machines.user_id = ?", filter.UserID)
+	}
+
+	if filter.Connected != nil {
+		if *filter.Connected {
+			tx = tx.Where("machines.id IN ?", filter.ConnectedIDs)
+		} else if len(filter.ConnectedIDs) != 0 {
+			tx = tx.Where("machines.id NOT IN ?", filter.ConnectedIDs)
+		}
+	}
+
+	if filter.Expired != nil {
+		expired := "machines.key_expiry_disabled = ? AND machines.expires_at > ? AND machines.expires_at < ?"
+		if !*filter.Expired {
+			expired = "NOT (" + expired + ")"
+		}
+		tx = tx.Where(expired, false, time.Unix(0, 0).UTC(), time.Now().UTC())
+	}
+
+	if filter.Authorized != nil {
+		tx = tx.Where("machines.authorized = ?", *filter.Authorized)
+	}
+
+	if filter.OS != "" {
+		switch tx.Dialector.Name() {
+		case "postgres":
+			tx = tx.Where("lower(machines.host_info->>'OS') = ?", strings.ToLower(filter.OS))
+		default:
+			tx = tx.Where("lower(json_extract(machines.host_info, '$.OS')) = ?", strings.ToLower(filter.OS))
+		}
+	}
+
+	if filter.NamePrefix != "" {
+		tx = tx.Where(`machines.name LIKE ? ESCAPE '\'`, likeEscaper.Replace(strings.ToLower(filter.NamePrefix))+"%")
+	}
+
+	if filter.LastSeenAfter != nil {
+		tx = tx.Where("machines.last_seen >= ?", filter.LastSeenAfter.UTC())
+	}
+
+	if filter.LastSeenBefore != nil {
+		tx = tx.Where("machines.last_seen < ?", filter.LastSeenBefore.UTC())
+	}
+
+	dir, cmp := "asc", ">"
+	if filter.Descending {
+		dir, cmp = "desc", "<"
+	}
+
+	switch filter.OrderBy {
+	case MachineOrderByLastSeen, MachineOrderByCreatedAt:
+		column, vars := "machines.created_at", []interface{}{}
+		if filter.OrderBy == MachineOrderByLastSeen {
+			// machines that never connected are ordered as if they were last seen a long time ago
+			column, vars = "COALESCE(machines.last_seen, ?)", []interface{}{time.Unix(0, 0).UTC()}
+		}
+
+		if filter.After != nil {
+			after := fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND machines.id %[2]s ?))", column, cmp)
+			tx = tx.Where(after, slices.Concat(vars, []interface{}{filter.After.Time.UTC()}, vars, []interface{}{filter.After.Time.UTC(), filter.After.ID})...)
+		}
+
+		tx = tx.Clauses(clause.OrderBy{Expression: clause.Expr{SQL: fmt.Sprintf("%[1]s %[2]s, machines.id %[2]s", column, dir), Vars: vars}})
+	default:
+		if filter.After != nil {
+			after := fmt.Sprintf("(machines.name %[1]s ? OR (machines.name = ? AND machines.name_idx %[1]s ?))", cmp)
+			tx = tx.Where(after, filter.After.Name, filter.After.Name, filter.After.NameIdx)
+		}
+
+		tx = tx.Order(fmt.Sprintf("machines.name %[1]s, machines.name_idx %[1]s", dir))
+	}
+
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+
+	tx = tx.Find(&machines)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return machines, nil
+}
+
 func (r *repository) ListMachinePeers(ctx context.Context, tailnetID uint64, machineID uint64) (Machines, error) {
 	var machines []Machine
 
@@ -520,6 +984,23 @@ func (r *repository) ListInactiveEphemeralMachines(ctx context.Context, t time.T
 	return machines, nil
 }
 
+func (r *repository) ListMachinesExpiringBefore(ctx context.Context, t time.Time) (Machines, error) {
+	var machines = []Machine{}
+
+	tx := r.withContext(ctx).
+		Preload("Tailnet").
+		Joins("User").
+		Joins("User.Account").
+		Where("machines.key_expiry_disabled = ? AND machines.expires_at > ? AND machines.expires_at < ?", false, time.Now().UTC(), t.UTC()).
+		Find(&machines)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return machines, nil
+}
+
 func (r *repository) SetMachineLastSeen(ctx context.Context, machineID uint64) error {
 	now := time.Now().UTC()
 	tx := r.withContext(ctx).
@@ -533,3 +1014,33 @@ func (r *repository) SetMachineLastSeen(ctx context.Context, machineID uint64) e
 
 	return nil
 }
+
+// GetLatestClientVersions returns the newest client versions running in the tailnet. Only the distinct
+// versions are loaded, the number of machines in the tailnet doesn't matter.
+func (r *repository) GetLatestClientVersions(ctx context.Context, tailnetID uint64) (ClientVersions, error) {
+	tx := r.withContext(ctx).Model(&Machine{})
+
+	var column string
+	switch tx.Dialector.Name() {
+	case "postgres":
+		column = "machines.host_info->>'IPNVersion'"
+	default:
+		column = "json_extract(machines.host_info, '$.IPNVersion')"
+	}
+
+	var versions []string
+	tx = tx.
+		Distinct(column).
+		Where("machines.tailnet_id = ? AND "+column+" IS NOT NULL", tailnetID).
+		Pluck(column, &versions)
+
+	if tx.Error != nil {
+		return ClientVersions{}, tx.Error
+	}
+
+	for i, v := range versions {
+		versions[i], _, _ = strings.Cut(v, "-")
+	}
+
+	return latestClientVersions(versions), nil
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"strings"
+	"time"
+)
+
+const (
+	MachineRetentionDelete = "delete"
+	MachineRetentionExpire = "expire"
+
+	MachineRetentionSelectAll      = "*"
+	MachineRetentionSelectTagged   = "tagged"
+	MachineRetentionSelectUntagged = "untagged"
+)
+
+// MachineRetentionPolicy deletes or expires the machines of a tailnet that are not seen for a while.
+// Ephemeral machines are not subject to the policy, they are always deleted shortly after they disconnect.
+type MachineRetentionPolicy struct {
+	Rules []MachineRetentionRule `json:"rules,omitempty"`
+}
+
+type MachineRetentionRule struct {
+	Action string `json:"action"`
+	// Selector matches all machines (*), the tagged or untagged machines, or the machines with a given tag.
+	Selector    string        `json:"selector"`
+	InactiveFor time.Duration `json:"inactive_for"`
+}
+
+// MachineRetentionCandidate is a machine the policy applies to, and the action to take.
+type MachineRetentionCandidate struct {
+	Machine Machine
+	Action  string
+}
+
+func (p MachineRetentionPolicy) Validate() error {
+	for i, r := range p.Rules {
+		if r.Action != MachineRetentionDelete && r.Action != MachineRetentionExpire {
+			return fmt.Errorf("rule %d: invalid action [%s]", i, r.Action)
+		}
+
+		switch {
+		case r.Selector == MachineRetentionSelectAll, r.Selector == MachineRetentionSelectTagged, r.Selector == MachineRetentionSelectUntagged:
+		case strings.HasPrefix(r.Selector, "tag:"):
+			if err := CheckTag(r.Selector); err != nil {
+				return fmt.Errorf("rule %d: %w", i, err)
+			}
+		default:
+			return fmt.Errorf("rule %d: invalid selector [%s]", i, r.Selector)
+		}
+
+		if r.InactiveFor < time.Hour {
+			return fmt.Errorf("rule %d: inactivity period must be at least 1h", i)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the machines the policy applies to, deletion takes precedence over expiry
+// and machines already expired are not expired again.
+func (p MachineRetentionPolicy) Evaluate(machines Machines, now time.Time, isConnected func(m *Machine) bool) []MachineRetentionCandidate {
+	var result []MachineRetentionCandidate
+
+	for _, m := range machines {
+		if m.Ephemeral || isConnected(&m) {
+			continue
+		}
+
+		lastSeen := m.CreatedAt
+		if m.LastSeen != nil {
+			lastSeen = *m.LastSeen
+		}
+
+		var action string
+		for _, r := range p.Rules {
+			if !r.matches(&m) || now.Sub(lastSeen) < r.InactiveFor {
+				continue
+			}
+			if r.Action == MachineRetentionDelete {
+				action = MachineRetentionDelete
+				break
+			}
+			if !m.IsExpired() {
+				action = MachineRetentionExpire
+			}
+		}
+
+		if action != "" {
+			result = append(result, MachineRetentionCandidate{Machine: m, Action: action})
+		}
+	}
+
+	return result
+}
+
+func (r MachineRetentionRule) matches(m *Machine) bool {
+	switch r.Selector {
+	case MachineRetentionSelectAll:
+		return true
+	case MachineRetentionSelectTagged:
+		return m.HasTags()
+	case MachineRetentionSelectUntagged:
+		return !m.HasTags()
+	default:
+		return m.HasTag(r.Selector)
+	}
+}
+
+func (i *MachineRetentionPolicy) Scan(destination interface{}) error {
+	switch value := destination.(type) {
+	case []byte:
+		return json.Unmarshal(value, i)
+	case string:
+		return json.Unmarshal([]byte(value), i)
+	default:
+		return fmt.Errorf("unexpected data type %T", destination)
+	}
+}
+
+func (i MachineRetentionPolicy) Value() (driver.Value, error) {
+	bytes, err := json.Marshal(i)
+	return bytes, err
+}
+
+func (MachineRetentionPolicy) GormDataType() string {
+	return "json"
+}
+
+func (MachineRetentionPolicy) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return "JSON"
+	}
+	return ""
+}
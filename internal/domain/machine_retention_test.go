@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMachineRetentionPolicy_Validate(t *testing.T) {
+	valid := MachineRetentionPolicy{Rules: []MachineRetentionRule{
+		{Action: MachineRetentionDelete, Selector: MachineRetentionSelectUntagged, InactiveFor: 90 * 24 * time.Hour},
+		{Action: MachineRetentionExpire, Selector: "tag:server", InactiveFor: 14 * 24 * time.Hour},
+	}}
+	assert.NoError(t, valid.Validate())
+
+	invalid := []MachineRetentionRule{
+		{Action: "archive", Selector: MachineRetentionSelectAll, InactiveFor: time.Hour},
+		{Action: MachineRetentionDelete, Selector: "servers", InactiveFor: time.Hour},
+		{Action: MachineRetentionDelete, Selector: MachineRetentionSelectAll, InactiveFor: time.Minute},
+	}
+	for _, r := range invalid {
+		assert.Error(t, MachineRetentionPolicy{Rules: []MachineRetentionRule{r}}.Validate())
+	}
+}
+
+func TestMachineRetentionPolicy_Evaluate(t *testing.T) {
+	now := time.Now().UTC()
+	seen := func(m *Machine, ago time.Duration) Machine {
+		lastSeen := now.Add(-ago)
+		m.LastSeen = &lastSeen
+		return *m
+	}
+
+	laptop := seen(createMachine("john@example.com"), 100*24*time.Hour)
+	laptop.ID = 1
+	recent := seen(createMachine("john@example.com"), 24*time.Hour)
+	recent.ID = 2
+	server := seen(createMachine("john@example.com", "tag:server"), 20*24*time.Hour)
+	server.ID = 3
+	ephemeral := seen(createMachine("john@example.com"), 100*24*time.Hour)
+	ephemeral.ID = 4
+	ephemeral.Ephemeral = true
+	connected := seen(createMachine("john@example.com"), 100*24*time.Hour)
+	connected.ID = 5
+	expired := seen(createMachine("john@example.com", "tag:server"), 20*24*time.Hour)
+	expired.ID = 6
+	expired.ExpiresAt = now.Add(-time.Hour)
+	never := *createMachine("john@example.com", "tag:server")
+	never.ID = 7
+	never.CreatedAt = now.Add(-200 * 24 * time.Hour)
+
+	policy := MachineRetentionPolicy{Rules: []MachineRetentionRule{
+		{Action: MachineRetentionExpire, Selector: MachineRetentionSelectAll, InactiveFor: 14 * 24 * time.Hour},
+		{Action: MachineRetentionDelete, Selector: MachineRetentionSelectAll, InactiveFor: 90 * 24 * time.Hour},
+	}}
+
+	isConnected := func(m *Machine) bool { return m.ID == 5 }
+
+	actions := map[uint64]string{}
+	for _, c := range policy.Evaluate(Machines{laptop, recent, server, ephemeral, connected, expired, never}, now, isConnected) {
+		actions[c.Machine.ID] = c.Action
+	}
+
+	assert.Equal(t, map[uint64]string{
+		1: MachineRetentionDelete,
+		3: MachineRetentionExpire,
+		7: MachineRetentionDelete,
+	}, actions)
+}
@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"slices"
+	"tailscale.com/tailcfg"
+	"time"
+)
+
+type MachineShareRepository interface {
+	SaveMachineShare(ctx context.Context, share *MachineShare) error
+	GetMachineShare(ctx context.Context, id uint64) (*MachineShare, error)
+	GetMachineShareByCode(ctx context.Context, code string) (*MachineShare, error)
+	ListMachineSharesByMachine(ctx context.Context, machineID uint64) ([]MachineShare, error)
+	ListMachineSharesByRecipient(ctx context.Context, tailnetID uint64) ([]MachineShare, error)
+	ListSharingTailnets(ctx context.Context, tailnetID uint64) ([]uint64, error)
+	DeleteMachineShare(ctx context.Context, id uint64) error
+	DeleteMachineSharesByMachine(ctx context.Context, machineID uint64) error
+	DeleteMachineSharesByTailnet(ctx context.Context, tailnetID uint64) error
+}
+
+// MachineShare shares a single machine with a user or all machines of another tailnet.
+// A share starts as an invite, optionally restricted to a login name or a tailnet, and is
+// accepted by a user of the recipient tailnet. The recipients can connect to the shared machine,
+// the shared machine can't connect to the recipients.
+type MachineShare struct {
+	ID        uint64 `gorm:"primary_key"`
+	Code      string
+	MachineID uint64
+	Machine   Machine
+	TailnetID uint64
+
+	InviteLoginName string
+	InviteTailnetID uint64
+
+	// RecipientUserID is empty when the machine is shared with the whole recipient tailnet
+	RecipientTailnetID uint64
+	RecipientUserID    uint64
+	AcceptedAt         *time.Time
+
+	CreatedAt time.Time
+}
+
+var (
+	ErrShareAlreadyAccepted = errors.New("share is already accepted")
+	ErrShareNotForRecipient = errors.New("share is not for this recipient")
+)
+
+func (s *MachineShare) IsAccepted() bool {
+	return s.AcceptedAt != nil
+}
+
+// IsTailnetInvite returns whether the invite shares the machine with all machines of a tailnet.
+func (s *MachineShare) IsTailnetInvite() bool {
+	return s.InviteTailnetID != 0
+}
+
+// AcceptByTailnet accepts an invite for a tailnet, on behalf of all machines of that tailnet.
+func (s *MachineShare) AcceptByTailnet(tailnetID uint64, now time.Time) error {
+	if s.IsAccepted() {
+		return ErrShareAlreadyAccepted
+	}
+	if !s.IsTailnetInvite() || s.InviteTailnetID != tailnetID || s.TailnetID == tailnetID {
+		return ErrShareNotForRecipient
+	}
+	s.RecipientTailnetID = tailnetID
+	s.AcceptedAt = &now
+	return nil
+}
+
+// AcceptByUser accepts an invite for a user, only the machines of that user get access.
+func (s *MachineShare) AcceptByUser(u *User, now time.Time) error {
+	if s.IsAccepted() {
+		return ErrShareAlreadyAccepted
+	}
+	if s.IsTailnetInvite() || s.TailnetID == u.TailnetID || u.UserType != UserTypePerson {
+		return ErrShareNotForRecipient
+	}
+	if s.InviteLoginName != "" && s.InviteLoginName != u.Name {
+		return ErrShareNotForRecipient
+	}
+	s.RecipientTailnetID = u.TailnetID
+	s.RecipientUserID = u.ID
+	s.AcceptedAt = &now
+	return nil
+}
+
+// IsRecipient returns whether the machine has access to the shared machine.
+func (s *MachineShare) IsRecipient(m *Machine) bool {
+	if !s.IsAccepted() || m.TailnetID != s.RecipientTailnetID {
+		return false
+	}
+	return s.RecipientUserID == 0 || !m.HasTags() && m.UserID == s.RecipientUserID
+}
+
+// Recipients returns the machines with access to the shared machine.
+func (s *MachineShare) Recipients(machines Machines) Machines {
+	var result Machines
+	for _, m := range machines {
+		if s.IsRecipient(&m) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// ShareFilterRule allows all traffic from the recipients to the shared machine.
+func ShareFilterRule(recipients Machines) (tailcfg.FilterRule, bool) {
+	srcIPs := &StringSet{}
+	for _, m := range recipients {
+		srcIPs.Add(m.IPv4.String(), m.IPv6.String())
+	}
+
+	if srcIPs.Empty() {
+		return tailcfg.FilterRule{}, false
+	}
+
+	return tailcfg.FilterRule{
+		SrcIPs:   srcIPs.Items(),
+		DstPorts: []tailcfg.NetPortRange{{IP: "*", Ports: tailcfg.PortRangeAny}},
+	}, true
+}
+
+func (r *repository) SaveMachineShare(ctx context.Context, share *MachineShare) error {
+	tx := r.withContext(ctx).Omit("Machine").Save(share)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (r *repository) GetMachineShare(ctx context.Context, id uint64) (*MachineShare, error) {
+	var s MachineShare
+	tx := r.withContext(ctx).Take(&s, "id = ?", id)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &s, nil
+}
+
+func (r *repository) GetMachineShareByCode(ctx context.Context, code string) (*MachineShare, error) {
+	var s MachineShare
+	tx := r.withContext(ctx).Take(&s, "code = ?", code)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &s, nil
+}
+
+func (r *repository) ListMachineSharesByMachine(ctx context.Context, machineID uint64) ([]MachineShare, error) {
+	var shares = []MachineShare{}
+
+	tx := r.withContext(ctx).Where("machine_id = ?", machineID).Order("id asc").Find(&shares)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return shares, nil
+}
+
+// ListMachineSharesByRecipient returns the accepted shares of the tailnet, with the shared machines.
+// Shares of machines that no longer exist are skipped.
+func (r *repository) ListMachineSharesByRecipient(ctx context.Context, tailnetID uint64) ([]MachineShare, error) {
+	var shares = []MachineShare{}
+
+	tx := r.withContext(ctx).
+		InnerJoins("Machine").
+		Preload("Machine.Tailnet").
+		Preload("Machine.User").
+		Where("machine_shares.recipient_tailnet_id = ? AND machine_shares.accepted_at IS NOT NULL", tailnetID).
+		Order("machine_shares.id asc").
+		Find(&shares)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return shares, nil
+}
+
+// ListSharingTailnets returns the tailnets that share machines with the tailnet, or receive machines of the tailnet.
+func (r *repository) ListSharingTailnets(ctx context.Context, tailnetID uint64) ([]uint64, error) {
+	var shares = []MachineShare{}
+
+	tx := r.withContext(ctx).
+		Where("(tailnet_id = ? OR recipient_tailnet_id = ?) AND accepted_at IS NOT NULL", tailnetID, tailnetID).
+		Find(&shares)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	var ids []uint64
+	for _, s := range shares {
+		for _, id := range []uint64{s.TailnetID, s.RecipientTailnetID} {
+			if id != tailnetID && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+func (r *repository) DeleteMachineShare(ctx context.Context, id uint64) error {
+	tx := r.withContext(ctx).Delete(&MachineShare{ID: id})
+	return tx.Error
+}
+
+func (r *repository) DeleteMachineSharesByMachine(ctx context.Context, machineID uint64) error {
+	tx := r.withContext(ctx).Where("machine_id = ?", machineID).Delete(&MachineShare{})
+	return tx.Error
+}
+
+// DeleteMachineSharesByTailnet deletes the shares granted by the tailnet, and the shares received by the tailnet.
+func (r *repository) DeleteMachineSharesByTailnet(ctx context.Context, tailnetID uint64) error {
+	tx := r.withContext(ctx).Where("tailnet_id = ? OR recipient_tailnet_id = ? OR invite_tailnet_id = ?", tailnetID, tailnetID, tailnetID).Delete(&MachineShare{})
+	return tx.Error
+}
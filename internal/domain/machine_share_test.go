@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"tailscale.com/tailcfg"
+	"testing"
+	"time"
+)
+
+func TestMachineShare_AcceptByUser(t *testing.T) {
+	now := time.Now().UTC()
+	john := &User{ID: 10, Name: "john@example.com", TailnetID: 2, UserType: UserTypePerson}
+	jane := &User{ID: 11, Name: "jane@example.com", TailnetID: 2, UserType: UserTypePerson}
+	owner := &User{ID: 12, Name: "owner@example.com", TailnetID: 1, UserType: UserTypePerson}
+
+	share := &MachineShare{MachineID: 1, TailnetID: 1, InviteLoginName: "john@example.com"}
+	assert.ErrorIs(t, share.AcceptByUser(jane, now), ErrShareNotForRecipient)
+	assert.NoError(t, share.AcceptByUser(john, now))
+	assert.Equal(t, uint64(2), share.RecipientTailnetID)
+	assert.Equal(t, uint64(10), share.RecipientUserID)
+	assert.ErrorIs(t, share.AcceptByUser(john, now), ErrShareAlreadyAccepted)
+
+	share = &MachineShare{MachineID: 1, TailnetID: 1}
+	assert.ErrorIs(t, share.AcceptByUser(owner, now), ErrShareNotForRecipient)
+	assert.NoError(t, share.AcceptByUser(jane, now))
+
+	share = &MachineShare{MachineID: 1, TailnetID: 1, InviteTailnetID: 2}
+	assert.ErrorIs(t, share.AcceptByUser(john, now), ErrShareNotForRecipient)
+}
+
+func TestMachineShare_AcceptByTailnet(t *testing.T) {
+	now := time.Now().UTC()
+
+	share := &MachineShare{MachineID: 1, TailnetID: 1, InviteTailnetID: 2}
+	assert.ErrorIs(t, share.AcceptByTailnet(3, now), ErrShareNotForRecipient)
+	assert.NoError(t, share.AcceptByTailnet(2, now))
+	assert.Equal(t, uint64(2), share.RecipientTailnetID)
+	assert.Equal(t, uint64(0), share.RecipientUserID)
+	assert.ErrorIs(t, share.AcceptByTailnet(2, now), ErrShareAlreadyAccepted)
+
+	share = &MachineShare{MachineID: 1, TailnetID: 1, InviteLoginName: "john@example.com"}
+	assert.ErrorIs(t, share.AcceptByTailnet(2, now), ErrShareNotForRecipient)
+}
+
+func TestMachineShare_Recipients(t *testing.T) {
+	now := time.Now().UTC()
+
+	laptop := createMachine("john@example.com")
+	laptop.ID, laptop.TailnetID, laptop.UserID = 1, 2, 10
+	other := createMachine("jane@example.com")
+	other.ID, other.TailnetID, other.UserID = 2, 2, 11
+	server := createMachine("john@example.com", "tag:server")
+	server.ID, server.TailnetID, server.UserID = 3, 2, 10
+	foreign := createMachine("john@example.com")
+	foreign.ID, foreign.TailnetID, foreign.UserID = 4, 3, 10
+
+	machines := Machines{*laptop, *other, *server, *foreign}
+
+	pending := &MachineShare{TailnetID: 1, InviteTailnetID: 2}
+	assert.Empty(t, pending.Recipients(machines))
+
+	byUser := &MachineShare{TailnetID: 1, RecipientTailnetID: 2, RecipientUserID: 10, AcceptedAt: &now}
+	assert.Equal(t, Machines{*laptop}, byUser.Recipients(machines))
+
+	byTailnet := &MachineShare{TailnetID: 1, RecipientTailnetID: 2, AcceptedAt: &now}
+	assert.Equal(t, Machines{*laptop, *other, *server}, byTailnet.Recipients(machines))
+}
+
+func TestShareFilterRule(t *testing.T) {
+	_, ok := ShareFilterRule(nil)
+	assert.False(t, ok)
+
+	p1 := createMachine("john@example.com")
+	p2 := createMachine("jane@example.com")
+
+	rule, ok := ShareFilterRule(Machines{*p1, *p2})
+	assert.True(t, ok)
+	assert.Equal(t, expectedSourceIPs(p1, p2), rule.SrcIPs)
+	assert.Equal(t, []tailcfg.NetPortRange{{IP: "*", Ports: tailcfg.PortRangeAny}}, rule.DstPorts)
+}
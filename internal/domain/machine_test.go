@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/netip"
+	"tailscale.com/tailcfg"
+	"testing"
+	"time"
+)
+
+func TestSanitizeAliases(t *testing.T) {
+	aliases, err := SanitizeAliases([]string{"Grafana.Internal.", "grafana.internal", "db"})
+	require.NoError(t, err)
+	assert.Equal(t, Tags{"db", "grafana.internal"}, aliases)
+
+	_, err = SanitizeAliases([]string{"invalid_alias!.internal"})
+	assert.Error(t, err)
+
+	_, err = SanitizeAliases([]string{""})
+	assert.Error(t, err)
+}
+
+func TestMachines_NameTaken(t *testing.T) {
+	machines := Machines{
+		{ID: 1, Name: "web"},
+		{ID: 2, Name: "web", NameIdx: 1},
+		{ID: 3, Name: "db", Aliases: Tags{"api.example.ionscale.net", "grafana.internal"}},
+	}
+
+	assert.True(t, machines.NameTaken(4, "web", "example.ionscale.net"))
+	assert.True(t, machines.NameTaken(4, "web-1", "example.ionscale.net"))
+	assert.True(t, machines.NameTaken(4, "api", "example.ionscale.net"))
+	assert.False(t, machines.NameTaken(4, "web-2", "example.ionscale.net"))
+	assert.False(t, machines.NameTaken(4, "api", "other.ionscale.net"))
+	assert.False(t, machines.NameTaken(4, "grafana", "example.ionscale.net"))
+
+	// the machine itself doesn't take the name
+	assert.False(t, machines.NameTaken(1, "web", "example.ionscale.net"))
+}
+
+func TestMachines_CheckAliases(t *testing.T) {
+	tailnet := &Tailnet{Name: "example", DNSConfig: DNSConfig{ExtraRecords: []tailcfg.DNSRecord{{Name: "dns.internal.", Value: "10.0.0.1"}}}}
+	machines := Machines{
+		{ID: 1, Name: "web"},
+		{ID: 2, Name: "db", Aliases: Tags{"grafana.internal"}},
+	}
+
+	check := func(aliases ...string) error {
+		return machines.CheckAliases(&Machine{ID: 3, Name: "laptop", Aliases: aliases}, tailnet, "ionscale.net")
+	}
+
+	assert.NoError(t, check())
+	assert.NoError(t, check("laptop.internal", "web.internal"))
+	assert.Error(t, check("grafana.internal"))
+	assert.Error(t, check("web.example.ionscale.net"))
+	assert.Error(t, check("dns.internal"))
+
+	// the machine can keep its own aliases
+	assert.NoError(t, machines.CheckAliases(&Machine{ID: 2, Name: "db", Aliases: Tags{"grafana.internal"}}, tailnet, "ionscale.net"))
+}
+
+func TestMachine_AliasRecords(t *testing.T) {
+	m := createMachine("john@example.com")
+	m.Aliases = Tags{"grafana.internal"}
+
+	expected := []tailcfg.DNSRecord{
+		{Name: "grafana.internal", Value: m.IPv4.String()},
+		{Name: "grafana.internal", Value: m.IPv6.String()},
+	}
+
+	assert.Equal(t, expected, m.AliasRecords())
+}
+
+func TestMachine_RecorderPort(t *testing.T) {
+	m := createMachine("john@example.com", "tag:recorder")
+	assert.False(t, m.IsRecorder())
+	assert.Equal(t, uint16(80), m.RecorderPort())
+
+	m.HostInfo.App = RecorderApp + ":8080"
+	assert.True(t, m.IsRecorder())
+	assert.Equal(t, uint16(8080), m.RecorderPort())
+
+	m.HostInfo.App = RecorderApp
+	assert.True(t, m.IsRecorder())
+	assert.Equal(t, uint16(80), m.RecorderPort())
+
+	addrs := m.RecorderAddrs()
+	require.Len(t, addrs, 2)
+	assert.True(t, addrs[0].Addr().Is4())
+	assert.True(t, addrs[1].Addr().Is6())
+}
+
+func TestMachines_RouteCandidates(t *testing.T) {
+	route1 := netip.MustParsePrefix("10.160.0.0/20")
+	route2 := netip.MustParsePrefix("10.161.0.0/20")
+
+	machines := Machines{
+		{ID: 1, AllowIPs: []netip.Prefix{route1}, AutoAllowIPs: []netip.Prefix{route1}},
+		{ID: 2, AutoAllowIPs: []netip.Prefix{route1, route2}},
+		{ID: 3, AllowIPs: []netip.Prefix{netip.MustParsePrefix("0.0.0.0/0")}},
+	}
+
+	expected := map[netip.Prefix][]uint64{
+		route1: {1, 2},
+		route2: {2},
+	}
+
+	assert.Equal(t, expected, machines.RouteCandidates(nil))
+}
+
+func TestMachines_LatestClientVersions(t *testing.T) {
+	machine := func(version string) Machine {
+		return Machine{HostInfo: HostInfo{IPNVersion: version}}
+	}
+
+	machines := Machines{
+		machine("1.62.1-t1234567-gabcdef"),
+		machine("1.64.2-t7654321-gfedcba"),
+		machine("1.65.88-t1111111-g2222222"),
+		machine(""),
+	}
+
+	latest := machines.LatestClientVersions()
+
+	assert.Equal(t, ClientVersions{Stable: "1.64.2", Unstable: "1.65.88"}, latest)
+	assert.True(t, latest.UpdateAvailable("1.62.1"))
+	assert.False(t, latest.UpdateAvailable("1.64.2"))
+	assert.False(t, latest.UpdateAvailable("1.65.88"))
+	assert.True(t, latest.UpdateAvailable("1.63.4"))
+	assert.False(t, latest.UpdateAvailable(""))
+	assert.False(t, ClientVersions{Stable: "1.64.2"}.UpdateAvailable("1.65.1"))
+}
+
+func TestClientVersions_Merge(t *testing.T) {
+	running := ClientVersions{Stable: "1.64.2", Unstable: "1.65.88"}
+
+	// released versions newer than the ones running in the tailnet
+	merged := running.Merge(ClientVersions{Stable: "1.66.4"})
+	assert.Equal(t, ClientVersions{Stable: "1.66.4", Unstable: "1.65.88"}, merged)
+	assert.True(t, merged.UpdateAvailable("1.64.2"))
+
+	// machines running a newer version than configured
+	assert.Equal(t, running, running.Merge(ClientVersions{Stable: "1.62.0", Unstable: "1.63.10"}))
+	assert.Equal(t, running, running.Merge(ClientVersions{}))
+	assert.Equal(t, ClientVersions{Stable: "1.66.4"}, ClientVersions{}.Merge(ClientVersions{Stable: "1.66.4"}))
+}
+
+func TestMachine_KeyExpiryThreshold(t *testing.T) {
+	now := time.Now().UTC()
+	thresholds := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour}
+
+	m := createMachine("john@example.com")
+	m.ExpiresAt = now.Add(30 * 24 * time.Hour)
+	_, ok := m.KeyExpiryThreshold(now, thresholds)
+	assert.False(t, ok)
+
+	m.ExpiresAt = now.Add(5 * 24 * time.Hour)
+	threshold, ok := m.KeyExpiryThreshold(now, thresholds)
+	assert.True(t, ok)
+	assert.Equal(t, 7*24*time.Hour, threshold)
+
+	m.ExpiresAt = now.Add(12 * time.Hour)
+	threshold, ok = m.KeyExpiryThreshold(now, thresholds)
+	assert.True(t, ok)
+	assert.Equal(t, 24*time.Hour, threshold)
+
+	m.KeyExpiryDisabled = true
+	_, ok = m.KeyExpiryThreshold(now, thresholds)
+	assert.False(t, ok)
+
+	m.KeyExpiryDisabled = false
+	m.ExpiresAt = now.Add(-time.Hour)
+	_, ok = m.KeyExpiryThreshold(now, thresholds)
+	assert.False(t, ok)
+}
+
+func TestMachine_EnableAdvertisedRoutes(t *testing.T) {
+	m := createMachine("john@example.com")
+	m.HostInfo.RoutableIPs = []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("10.1.0.0/24"),
+		netip.MustParsePrefix("0.0.0.0/0"),
+	}
+
+	assert.True(t, m.EnableAdvertisedRoutes([]netip.Prefix{netip.MustParsePrefix("10.1.0.0/24"), netip.MustParsePrefix("10.2.0.0/24")}))
+	assert.Equal(t, []string{"10.1.0.0/24"}, m.AllowedPrefixes())
+
+	assert.True(t, m.EnableAdvertisedRoutes(nil))
+	assert.Equal(t, []string{"10.0.0.0/24", "10.1.0.0/24"}, m.AllowedPrefixes())
+	assert.False(t, m.IsAllowedExitNode())
+
+	assert.False(t, m.EnableAdvertisedRoutes(nil))
+}
+
+func TestMachine_MatchesName(t *testing.T) {
+	m := createMachine("john@example.com")
+	m.Name = "ci-runner"
+	m.NameIdx = 2
+
+	assert.True(t, m.MatchesName("ci-*"))
+	assert.True(t, m.MatchesName("ci-runner-2"))
+	assert.False(t, m.MatchesName("ci-runner"))
+	assert.False(t, m.MatchesName("web-*"))
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+)
+
+type PublishedDNSRecordRepository interface {
+	SavePublishedDNSRecord(ctx context.Context, record *PublishedDNSRecord) error
+	ListPublishedDNSRecordsByTailnet(ctx context.Context, tailnetID uint64) ([]PublishedDNSRecord, error)
+	ListPublishedDNSRecordTailnets(ctx context.Context) ([]uint64, error)
+	DeletePublishedDNSRecord(ctx context.Context, id uint64) error
+}
+
+type PublishedDNSRecord struct {
+	ID        uint64 `gorm:"primary_key"`
+	TailnetID uint64
+	MachineID uint64
+	Type      string
+	Name      string
+	Value     string
+}
+
+func (r *repository) SavePublishedDNSRecord(ctx context.Context, record *PublishedDNSRecord) error {
+	tx := r.withContext(ctx).Save(record)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (r *repository) ListPublishedDNSRecordsByTailnet(ctx context.Context, tailnetID uint64) ([]PublishedDNSRecord, error) {
+	var records = []PublishedDNSRecord{}
+
+	tx := r.withContext(ctx).Where("tailnet_id = ?", tailnetID).Find(&records)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return records, nil
+}
+
+func (r *repository) ListPublishedDNSRecordTailnets(ctx context.Context) ([]uint64, error) {
+	var ids = []uint64{}
+
+	tx := r.withContext(ctx).Model(&PublishedDNSRecord{}).Distinct().Pluck("tailnet_id", &ids)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return ids, nil
+}
+
+func (r *repository) DeletePublishedDNSRecord(ctx context.Context, id uint64) error {
+	tx := r.withContext(ctx).Delete(&PublishedDNSRecord{ID: id})
+	return tx.Error
+}
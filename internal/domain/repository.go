@@ -4,6 +4,7 @@ import (
 	"context"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"strings"
 )
 
 type Repository interface {
@@ -17,6 +18,13 @@ type Repository interface {
 	AuthenticationRequestRepository
 	RegistrationRequestRepository
 	SSHActionRequestRepository
+	SSHApprovalRepository
+	SSHSessionEventRepository
+	TailnetKeyAuthorityRepository
+	DNSChallengeRecordRepository
+	PublishedDNSRecordRepository
+	KeyExpiryNotificationRepository
+	MachineShareRepository
 
 	GetControlKeys(ctx context.Context) (*ControlKeys, error)
 	SetControlKeys(ctx context.Context, keys *ControlKeys) error
@@ -41,6 +49,9 @@ func (r *repository) withContext(ctx context.Context) *gorm.DB {
 	return r.db.WithContext(ctx).Omit(clause.Associations)
 }
 
+// likeEscaper escapes the wildcards of a LIKE pattern, the queries using it declare the backslash as escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func (r *repository) Transaction(action func(Repository) error) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		return action(NewRepository(tx))
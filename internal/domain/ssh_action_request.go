@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"errors"
+	"github.com/jsiebens/ionscale/internal/util"
 	"gorm.io/gorm"
 	"time"
 )
@@ -19,6 +20,78 @@ type SSHActionRequest struct {
 	SrcMachineID uint64
 	DstMachineID uint64
 	CreatedAt    time.Time
+
+	TailnetID     uint64
+	SSHUser       string
+	LocalUser     string
+	Approvers     Tags
+	Authenticated bool
+	ApprovedBy    string
+	ExpiresAt     *time.Time
+}
+
+// RequiresApproval reports whether a second person, one of the approvers, has to approve the request.
+func (r *SSHActionRequest) RequiresApproval() bool {
+	return len(r.Approvers) != 0
+}
+
+// IsExpired reports whether the request was not approved in time.
+func (r *SSHActionRequest) IsExpired(now time.Time) bool {
+	return r.ExpiresAt != nil && now.After(*r.ExpiresAt)
+}
+
+// IsPending reports whether no decision was taken yet on the request.
+func (r *SSHActionRequest) IsPending() bool {
+	return r.Action == ""
+}
+
+// CanBeApprovedBy reports whether the user with the given login name is one of the approvers,
+// a requester is never allowed to approve its own request.
+func (r *SSHActionRequest) CanBeApprovedBy(policy ACLPolicy, requester *User, name string) bool {
+	if requester != nil && requester.Name == name {
+		return false
+	}
+	return policy.IsSSHApprover(r.Approvers, name)
+}
+
+// MarkAuthenticated records the connecting user re-authenticated successfully,
+// the request is accepted once the approval, when required, is given as well.
+func (r *SSHActionRequest) MarkAuthenticated() {
+	r.Authenticated = true
+	if !r.RequiresApproval() || r.ApprovedBy != "" {
+		r.Action = "accept"
+	}
+}
+
+// Approve records the approval of the given approver,
+// the request is accepted once the connecting user re-authenticated as well.
+func (r *SSHActionRequest) Approve(approver string) {
+	r.ApprovedBy = approver
+	if r.Authenticated {
+		r.Action = "accept"
+	}
+}
+
+func (r *SSHActionRequest) Reject() {
+	r.Action = "reject"
+}
+
+// Audit creates the audit record of the decision taken on a request requiring approval.
+func (r *SSHActionRequest) Audit(decision, decidedBy string, decidedAt time.Time) *SSHApproval {
+	return &SSHApproval{
+		ID:           util.NextID(),
+		TailnetID:    r.TailnetID,
+		RequestKey:   r.Key,
+		SrcMachineID: r.SrcMachineID,
+		DstMachineID: r.DstMachineID,
+		SSHUser:      r.SSHUser,
+		LocalUser:    r.LocalUser,
+		Approvers:    r.Approvers,
+		Decision:     decision,
+		DecidedBy:    decidedBy,
+		RequestedAt:  r.CreatedAt,
+		DecidedAt:    decidedAt,
+	}
 }
 
 func (r *repository) SaveSSHActionRequest(ctx context.Context, session *SSHActionRequest) error {
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"github.com/jsiebens/ionscale/pkg/client/ionscale"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSSHActionRequest_CanBeApprovedBy(t *testing.T) {
+	policy := ACLPolicy{
+		ionscale.ACLPolicy{
+			Groups: map[string][]string{
+				"group:sre": {"jane@example.com", "john@example.com"},
+			},
+		},
+	}
+
+	req := &SSHActionRequest{Approvers: []string{"group:sre", "bob@example.com"}}
+	requester := &User{Name: "john@example.com"}
+
+	assert.True(t, req.CanBeApprovedBy(policy, requester, "jane@example.com"))
+	assert.True(t, req.CanBeApprovedBy(policy, requester, "bob@example.com"))
+	assert.False(t, req.CanBeApprovedBy(policy, requester, "john@example.com"))
+	assert.False(t, req.CanBeApprovedBy(policy, requester, "alice@example.com"))
+}
+
+func TestSSHActionRequest_AcceptedWhenAuthenticatedAndApproved(t *testing.T) {
+	req := &SSHActionRequest{Approvers: []string{"group:sre"}}
+
+	req.MarkAuthenticated()
+	assert.True(t, req.IsPending())
+
+	req.Approve("jane@example.com")
+	assert.Equal(t, "accept", req.Action)
+
+	req = &SSHActionRequest{Approvers: []string{"group:sre"}}
+
+	req.Approve("jane@example.com")
+	assert.True(t, req.IsPending())
+
+	req.MarkAuthenticated()
+	assert.Equal(t, "accept", req.Action)
+}
+
+func TestSSHActionRequest_WithoutApprovers(t *testing.T) {
+	req := &SSHActionRequest{}
+	req.MarkAuthenticated()
+	assert.Equal(t, "accept", req.Action)
+}
+
+func TestSSHActionRequest_IsExpired(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Minute)
+
+	req := &SSHActionRequest{ExpiresAt: &expiresAt}
+
+	assert.False(t, req.IsExpired(now))
+	assert.True(t, req.IsExpired(now.Add(2*time.Minute)))
+	assert.False(t, (&SSHActionRequest{}).IsExpired(now))
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	SSHApprovalApproved = "approved"
+	SSHApprovalRejected = "rejected"
+	SSHApprovalExpired  = "expired"
+)
+
+type SSHApprovalRepository interface {
+	SaveSSHApproval(ctx context.Context, approval *SSHApproval) error
+}
+
+// SSHApproval is the audit record of an SSH check request that required the approval of a second person.
+type SSHApproval struct {
+	ID           uint64 `gorm:"primary_key"`
+	TailnetID    uint64
+	RequestKey   string
+	SrcMachineID uint64
+	DstMachineID uint64
+	SSHUser      string
+	LocalUser    string
+	Approvers    Tags
+	Decision     string
+	DecidedBy    string
+	RequestedAt  time.Time
+	DecidedAt    time.Time
+}
+
+func (r *repository) SaveSSHApproval(ctx context.Context, approval *SSHApproval) error {
+	tx := r.withContext(ctx).Save(approval)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
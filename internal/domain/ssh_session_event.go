@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	SSHSessionEventStart = "start"
+	SSHSessionEventEnd   = "end"
+	SSHSessionEventCheck = "check"
+
+	SSHSessionDecisionAccept = "accept"
+	SSHSessionDecisionReject = "reject"
+	SSHSessionDecisionExpire = "expire"
+)
+
+type SSHSessionEventRepository interface {
+	SaveSSHSessionEvent(ctx context.Context, event *SSHSessionEvent) error
+	ListSSHSessionEvents(ctx context.Context, tailnetID uint64, filter SSHSessionEventFilter) ([]SSHSessionEvent, error)
+}
+
+// SSHSessionEvent is the audit record of an SSH session, reported by the recorders when a session starts or ends,
+// or by the control server when a check decided whether the session is allowed.
+// The machine names are kept, so the events still make sense after the machines are removed.
+type SSHSessionEvent struct {
+	ID             uint64 `gorm:"primary_key"`
+	TailnetID      uint64 `gorm:"index"`
+	Type           string
+	ConnectionID   string
+	SrcMachineID   uint64
+	SrcMachineName string
+	DstMachineID   uint64
+	DstMachineName string
+	SSHUser        string
+	LocalUser      string
+	Decision       string
+	Recording      string
+	CreatedAt      time.Time
+}
+
+type SSHSessionEventFilter struct {
+	// MachineID matches events where the machine is either the source or the destination
+	MachineID uint64
+	Since     *time.Time
+	Limit     int
+}
+
+func (r *repository) SaveSSHSessionEvent(ctx context.Context, event *SSHSessionEvent) error {
+	tx := r.withContext(ctx).Save(event)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (r *repository) ListSSHSessionEvents(ctx context.Context, tailnetID uint64, filter SSHSessionEventFilter) ([]SSHSessionEvent, error) {
+	var events = []SSHSessionEvent{}
+
+	tx := r.withContext(ctx).Where("tailnet_id = ?", tailnetID)
+
+	if filter.MachineID != 0 {
+		tx = tx.Where("(src_machine_id = ? OR dst_machine_id = ?)", filter.MachineID, filter.MachineID)
+	}
+
+	if filter.Since != nil {
+		tx = tx.Where("created_at >= ?", filter.Since.UTC())
+	}
+
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+
+	tx = tx.Order("created_at desc, id desc").Find(&events)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return events, nil
+}
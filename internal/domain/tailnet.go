@@ -3,7 +3,9 @@ package domain
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"net/mail"
 	"strings"
 	"tailscale.com/util/dnsname"
@@ -20,11 +22,13 @@ type Tailnet struct {
 	FileSharingEnabled          bool
 	SSHEnabled                  bool
 	MachineAuthorizationEnabled bool
+	MachineRetentionPolicy      MachineRetentionPolicy
 }
 
 type TailnetRepository interface {
 	SaveTailnet(ctx context.Context, tailnet *Tailnet) error
 	GetTailnet(ctx context.Context, id uint64) (*Tailnet, error)
+	GetTailnetForUpdate(ctx context.Context, id uint64) (*Tailnet, error)
 	GetTailnetByName(ctx context.Context, name string) (*Tailnet, error)
 	ListTailnets(ctx context.Context) ([]Tailnet, error)
 	DeleteTailnet(ctx context.Context, id uint64) error
@@ -38,6 +42,11 @@ func (t Tailnet) GetDERPMap(ctx context.Context, fallack DefaultDERPMap) (*DERPM
 	}
 }
 
+// MachineDomain returns the domain of the machines in the tailnet.
+func (t Tailnet) MachineDomain(magicDNSSuffix string) string {
+	return fmt.Sprintf("%s.%s", SanitizeTailnetName(t.Name), magicDNSSuffix)
+}
+
 func SanitizeTailnetName(name string) string {
 	name = strings.ToLower(name)
 
@@ -66,8 +75,18 @@ func (r *repository) SaveTailnet(ctx context.Context, tailnet *Tailnet) error {
 }
 
 func (r *repository) GetTailnet(ctx context.Context, id uint64) (*Tailnet, error) {
+	return getTailnet(r.withContext(ctx), id)
+}
+
+// GetTailnetForUpdate locks the tailnet until the end of the transaction, so that changes to the names
+// of its machines are not made concurrently.
+func (r *repository) GetTailnetForUpdate(ctx context.Context, id uint64) (*Tailnet, error) {
+	return getTailnet(r.withContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func getTailnet(db *gorm.DB, id uint64) (*Tailnet, error) {
 	var t Tailnet
-	tx := r.withContext(ctx).Take(&t, "id = ?", id)
+	tx := db.Take(&t, "id = ?", id)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -0,0 +1,389 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jsiebens/ionscale/internal/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"tailscale.com/tailcfg"
+	"tailscale.com/tka"
+	"tailscale.com/types/key"
+	"tailscale.com/types/tkatype"
+	"time"
+)
+
+type TailnetKeyAuthorityRepository interface {
+	GetTailnetKeyAuthority(ctx context.Context, tailnetID uint64) (*TailnetKeyAuthority, error)
+	GetTailnetKeyAuthorityForUpdate(ctx context.Context, tailnetID uint64) (*TailnetKeyAuthority, error)
+	SaveTailnetKeyAuthority(ctx context.Context, authority *TailnetKeyAuthority) error
+	DeleteTailnetKeyAuthority(ctx context.Context, tailnetID uint64) error
+}
+
+const (
+	TailnetKeyAuthorityPending  = "pending"
+	TailnetKeyAuthorityEnabled  = "enabled"
+	TailnetKeyAuthorityDisabled = "disabled"
+)
+
+// TailnetKeyAuthority holds the chain of authority updates (AUMs) of a tailnet with Tailnet Lock.
+// The control server only stores and relays the updates, every update is signed by a trusted key
+// of the tailnet and verified before it is accepted, so the chain can't be altered by the server.
+type TailnetKeyAuthority struct {
+	TailnetID         uint64 `gorm:"primary_key;autoIncrement:false"`
+	State             string
+	Head              string
+	DisablementSecret []byte
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	// InitiatedBy is the machine that started the initialization, only that machine can finish it
+	InitiatedBy uint64
+
+	AUMs []TailnetKeyAuthorityAUM `gorm:"foreignKey:TailnetID;references:TailnetID"`
+
+	authority *tka.Authority `gorm:"-"`
+	storage   *tkaStorage    `gorm:"-"`
+}
+
+type TailnetKeyAuthorityAUM struct {
+	TailnetID uint64 `gorm:"primary_key;autoIncrement:false"`
+	Hash      string `gorm:"primary_key"`
+	Data      []byte
+	Idx       int
+}
+
+// tkaStorage keeps the chain in memory while it is verified and updated,
+// and tracks which updates still have to be persisted.
+type tkaStorage struct {
+	*tka.Mem
+	committed []tka.AUM
+}
+
+func (s *tkaStorage) CommitVerifiedAUMs(updates []tka.AUM) error {
+	s.committed = append(s.committed, updates...)
+	return s.Mem.CommitVerifiedAUMs(updates)
+}
+
+// NewTailnetKeyAuthority verifies the genesis update proposed by a node, the authority stays pending
+// until the node has signed the existing nodes of the tailnet.
+func NewTailnetKeyAuthority(tailnetID uint64, initiatedBy uint64, genesis tkatype.MarshaledAUM) (*TailnetKeyAuthority, error) {
+	var aum tka.AUM
+	if err := aum.Unserialize(genesis); err != nil {
+		return nil, fmt.Errorf("invalid genesis: %w", err)
+	}
+
+	t := &TailnetKeyAuthority{
+		TailnetID:   tailnetID,
+		State:       TailnetKeyAuthorityPending,
+		InitiatedBy: initiatedBy,
+		storage:     &tkaStorage{Mem: &tka.Mem{}},
+	}
+
+	authority, err := tka.Bootstrap(t.storage, aum)
+	if err != nil {
+		return nil, err
+	}
+
+	t.authority = authority
+	if err := t.commit(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *TailnetKeyAuthority) IsEnabled() bool {
+	return t.State == TailnetKeyAuthorityEnabled
+}
+
+// tailnetKeyAuthorityInitTimeout is the time after which a pending initialization is abandoned,
+// and another machine can start a new one.
+const tailnetKeyAuthorityInitTimeout = time.Hour
+
+// CanBeInitializedBy reports whether the machine can start the initialization, a pending initialization
+// is only replaced by the machine that started it, or when it is abandoned.
+func (t *TailnetKeyAuthority) CanBeInitializedBy(machineID uint64, now time.Time) bool {
+	if t.IsEnabled() {
+		return false
+	}
+	if t.State != TailnetKeyAuthorityPending {
+		return true
+	}
+	return t.InitiatedBy == machineID || now.Sub(t.CreatedAt) > tailnetKeyAuthorityInitTimeout
+}
+
+// IsInitializedBy reports whether the initialization is pending and started by the machine.
+func (t *TailnetKeyAuthority) IsInitializedBy(machineID uint64) bool {
+	return t.State == TailnetKeyAuthorityPending && t.InitiatedBy == machineID
+}
+
+// Authority opens the authority from the stored chain.
+func (t *TailnetKeyAuthority) Authority() (*tka.Authority, error) {
+	if t.authority != nil {
+		return t.authority, nil
+	}
+
+	if len(t.AUMs) == 0 {
+		return nil, errors.New("tailnet key authority has no genesis")
+	}
+
+	storage := &tkaStorage{Mem: &tka.Mem{}}
+	aums := make([]tka.AUM, len(t.AUMs))
+	for i, a := range t.AUMs {
+		if err := aums[i].Unserialize(a.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := storage.Mem.CommitVerifiedAUMs(aums); err != nil {
+		return nil, err
+	}
+
+	if err := storage.SetLastActiveAncestor(aums[0].Hash()); err != nil {
+		return nil, err
+	}
+
+	authority, err := tka.Open(storage)
+	if err != nil {
+		return nil, err
+	}
+
+	t.authority = authority
+	t.storage = storage
+
+	return authority, nil
+}
+
+// Genesis returns the first update of the chain, which new nodes use to bootstrap their authority.
+func (t *TailnetKeyAuthority) Genesis() tkatype.MarshaledAUM {
+	if len(t.AUMs) == 0 {
+		return nil
+	}
+	return t.AUMs[0].Data
+}
+
+// SyncOffer returns the head and ancestors of the chain, and the updates the remote offer is missing.
+func (t *TailnetKeyAuthority) SyncOffer(head string, ancestors []string) (*tailcfg.TKASyncOfferResponse, error) {
+	authority, err := t.Authority()
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := toSyncOffer(head, ancestors)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := authority.SyncOffer(t.storage)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &tailcfg.TKASyncOfferResponse{}
+	if resp.Head, resp.Ancestors, err = fromSyncOffer(local); err != nil {
+		return nil, err
+	}
+
+	if remote.Head == local.Head {
+		return resp, nil
+	}
+
+	missing, err := authority.MissingAUMs(t.storage, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range missing {
+		resp.MissingAUMs = append(resp.MissingAUMs, m.Serialize())
+	}
+
+	return resp, nil
+}
+
+// Inform verifies and applies updates sent by a node.
+func (t *TailnetKeyAuthority) Inform(updates []tkatype.MarshaledAUM) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	authority, err := t.Authority()
+	if err != nil {
+		return err
+	}
+
+	aums := make([]tka.AUM, len(updates))
+	for i, u := range updates {
+		if err := aums[i].Unserialize(u); err != nil {
+			return fmt.Errorf("invalid update %d: %w", i, err)
+		}
+	}
+
+	if err := authority.Inform(t.storage, aums); err != nil {
+		return err
+	}
+
+	return t.commit()
+}
+
+// IsTrusted reports whether the machine can be distributed to its peers, with Tailnet Lock enabled
+// only machines with a node key signed by a trusted key are.
+func (t *TailnetKeyAuthority) IsTrusted(m *Machine) bool {
+	if t == nil || !t.IsEnabled() {
+		return true
+	}
+
+	nodeKey, err := util.ParseNodePublicKey(m.NodeKey)
+	if err != nil {
+		return false
+	}
+
+	return t.NodeKeyAuthorized(*nodeKey, m.KeySignature)
+}
+
+// NodeKeyAuthorized returns whether the signature authorizes the node key by a trusted key of the authority.
+func (t *TailnetKeyAuthority) NodeKeyAuthorized(nodeKey key.NodePublic, signature []byte) bool {
+	if len(signature) == 0 {
+		return false
+	}
+
+	authority, err := t.Authority()
+	if err != nil {
+		return false
+	}
+
+	return authority.NodeKeyAuthorized(nodeKey, signature) == nil
+}
+
+func (t *TailnetKeyAuthority) ValidDisablement(secret []byte) bool {
+	authority, err := t.Authority()
+	if err != nil {
+		return false
+	}
+	return authority.ValidDisablement(secret)
+}
+
+// Disable keeps the disablement secret, so that the nodes can verify it and clear their own authority.
+func (t *TailnetKeyAuthority) Disable(secret []byte) {
+	t.State = TailnetKeyAuthorityDisabled
+	t.DisablementSecret = secret
+}
+
+// Info is the state of the authority as delivered in the map responses.
+func (t *TailnetKeyAuthority) Info() *tailcfg.TKAInfo {
+	switch t.State {
+	case TailnetKeyAuthorityEnabled:
+		return &tailcfg.TKAInfo{Head: t.Head}
+	case TailnetKeyAuthorityDisabled:
+		return &tailcfg.TKAInfo{Disabled: true}
+	default:
+		return nil
+	}
+}
+
+func (t *TailnetKeyAuthority) commit() error {
+	head, err := t.authority.Head().MarshalText()
+	if err != nil {
+		return err
+	}
+	t.Head = string(head)
+
+	for _, a := range t.storage.committed {
+		t.AUMs = append(t.AUMs, TailnetKeyAuthorityAUM{
+			TailnetID: t.TailnetID,
+			Hash:      a.Hash().String(),
+			Data:      a.Serialize(),
+			Idx:       len(t.AUMs),
+		})
+	}
+	t.storage.committed = nil
+
+	return nil
+}
+
+func toSyncOffer(head string, ancestors []string) (tka.SyncOffer, error) {
+	var out tka.SyncOffer
+	if err := out.Head.UnmarshalText([]byte(head)); err != nil {
+		return tka.SyncOffer{}, fmt.Errorf("invalid head: %w", err)
+	}
+	out.Ancestors = make([]tka.AUMHash, len(ancestors))
+	for i, a := range ancestors {
+		if err := out.Ancestors[i].UnmarshalText([]byte(a)); err != nil {
+			return tka.SyncOffer{}, fmt.Errorf("invalid ancestor %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
+
+func fromSyncOffer(offer tka.SyncOffer) (string, []string, error) {
+	head, err := offer.Head.MarshalText()
+	if err != nil {
+		return "", nil, err
+	}
+	ancestors := make([]string, len(offer.Ancestors))
+	for i, a := range offer.Ancestors {
+		h, err := a.MarshalText()
+		if err != nil {
+			return "", nil, err
+		}
+		ancestors[i] = string(h)
+	}
+	return string(head), ancestors, nil
+}
+
+func (r *repository) GetTailnetKeyAuthority(ctx context.Context, tailnetID uint64) (*TailnetKeyAuthority, error) {
+	return getTailnetKeyAuthority(r.withContext(ctx), tailnetID)
+}
+
+// GetTailnetKeyAuthorityForUpdate locks the authority until the end of the transaction,
+// so that the chain isn't updated concurrently.
+func (r *repository) GetTailnetKeyAuthorityForUpdate(ctx context.Context, tailnetID uint64) (*TailnetKeyAuthority, error) {
+	return getTailnetKeyAuthority(r.withContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), tailnetID)
+}
+
+func getTailnetKeyAuthority(db *gorm.DB, tailnetID uint64) (*TailnetKeyAuthority, error) {
+	var m TailnetKeyAuthority
+	tx := db.
+		Preload("AUMs", func(db *gorm.DB) *gorm.DB { return db.Order("idx asc") }).
+		Take(&m, "tailnet_id = ?", tailnetID)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &m, nil
+}
+
+func (r *repository) SaveTailnetKeyAuthority(ctx context.Context, authority *TailnetKeyAuthority) error {
+	tx := r.withContext(ctx).Save(authority)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if len(authority.AUMs) == 0 {
+		return nil
+	}
+
+	// updates are never changed once stored
+	tx = r.withContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&authority.AUMs)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteTailnetKeyAuthority(ctx context.Context, tailnetID uint64) error {
+	tx := r.withContext(ctx).Where("tailnet_id = ?", tailnetID).Delete(&TailnetKeyAuthorityAUM{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	tx = r.withContext(ctx).Where("tailnet_id = ?", tailnetID).Delete(&TailnetKeyAuthority{})
+	return tx.Error
+}
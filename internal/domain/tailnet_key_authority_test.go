@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/netip"
+	"tailscale.com/tka"
+	"tailscale.com/types/key"
+	"tailscale.com/types/tkatype"
+	"testing"
+	"time"
+)
+
+func TestTailnetKeyAuthorityInitiator(t *testing.T) {
+	now := time.Now().UTC()
+	pending := &TailnetKeyAuthority{TailnetID: 1, State: TailnetKeyAuthorityPending, InitiatedBy: 1, CreatedAt: now}
+
+	// only the initiating node can restart or finish a pending initialization
+	assert.True(t, pending.CanBeInitializedBy(1, now))
+	assert.False(t, pending.CanBeInitializedBy(2, now))
+	assert.True(t, pending.IsInitializedBy(1))
+	assert.False(t, pending.IsInitializedBy(2))
+
+	// an abandoned initialization can be replaced by another node
+	assert.True(t, pending.CanBeInitializedBy(2, now.Add(2*time.Hour)))
+
+	enabled := &TailnetKeyAuthority{TailnetID: 1, State: TailnetKeyAuthorityEnabled, InitiatedBy: 1, CreatedAt: now}
+	assert.False(t, enabled.CanBeInitializedBy(1, now))
+	assert.False(t, enabled.IsInitializedBy(1))
+}
+
+func TestTailnetKeyAuthority_IsTrusted(t *testing.T) {
+	signer := key.NewNLPrivate()
+	_, genesis, err := tka.Create(&tka.Mem{}, tka.State{
+		Keys:               []tka.Key{{Kind: tka.Key25519, Public: signer.Public().Verifier(), Votes: 1}},
+		DisablementSecrets: [][]byte{tka.DisablementKDF([]byte("secret"))},
+	}, signer)
+	require.NoError(t, err)
+
+	authority, err := NewTailnetKeyAuthority(1, 1, genesis.Serialize())
+	require.NoError(t, err)
+
+	route := netip.MustParsePrefix("10.0.0.0/24")
+	signedKey := key.NewNode().Public()
+	signed := Machine{ID: 1, NodeKey: signedKey.String(), KeySignature: signNodeKey(t, signer, signedKey), AllowIPs: []netip.Prefix{route}}
+	unsigned := Machine{ID: 2, NodeKey: key.NewNode().Public().String(), AllowIPs: []netip.Prefix{route}}
+
+	// without an enabled authority all machines are trusted
+	var none *TailnetKeyAuthority
+	assert.True(t, none.IsTrusted(&unsigned))
+	assert.True(t, authority.IsTrusted(&unsigned))
+	assert.Equal(t, map[netip.Prefix][]uint64{route: {1, 2}}, Machines{signed, unsigned}.RouteCandidates(authority))
+
+	authority.State = TailnetKeyAuthorityEnabled
+	assert.True(t, authority.IsTrusted(&signed))
+	assert.False(t, authority.IsTrusted(&unsigned))
+	assert.Equal(t, map[netip.Prefix][]uint64{route: {1}}, Machines{signed, unsigned}.RouteCandidates(authority))
+}
+
+func TestTailnetKeyAuthority(t *testing.T) {
+	signer := key.NewNLPrivate()
+	disablementSecret := []byte("disablement secret")
+
+	// the genesis is created by the node initializing Tailnet Lock
+	nodeStorage := &tka.Mem{}
+	nodeAuthority, genesis, err := tka.Create(nodeStorage, tka.State{
+		Keys:               []tka.Key{{Kind: tka.Key25519, Public: signer.Public().Verifier(), Votes: 1}},
+		DisablementSecrets: [][]byte{tka.DisablementKDF(disablementSecret)},
+	}, signer)
+	require.NoError(t, err)
+
+	authority, err := NewTailnetKeyAuthority(1, 1, genesis.Serialize())
+	require.NoError(t, err)
+	assert.Len(t, authority.AUMs, 1)
+	assert.Nil(t, authority.Info())
+
+	authority.State = TailnetKeyAuthorityEnabled
+	assert.Equal(t, nodeHead(t, nodeAuthority), authority.Info().Head)
+
+	// signed node keys are authorized, others are not
+	nodeKey := key.NewNode().Public()
+	assert.True(t, authority.NodeKeyAuthorized(nodeKey, signNodeKey(t, signer, nodeKey)))
+	assert.False(t, authority.NodeKeyAuthorized(key.NewNode().Public(), signNodeKey(t, signer, nodeKey)))
+	assert.False(t, authority.NodeKeyAuthorized(nodeKey, signNodeKey(t, key.NewNLPrivate(), nodeKey)))
+	assert.False(t, authority.NodeKeyAuthorized(nodeKey, nil))
+
+	// a node adds a trusted key and sends the update
+	other := key.NewNLPrivate()
+	updater := nodeAuthority.NewUpdater(signer)
+	require.NoError(t, updater.AddKey(tka.Key{Kind: tka.Key25519, Public: other.Public().Verifier(), Votes: 1}))
+	updates, err := updater.Finalize(nodeStorage)
+	require.NoError(t, err)
+	require.NoError(t, nodeAuthority.Inform(nodeStorage, updates))
+
+	require.NoError(t, authority.Inform(toMarshaledAUMs(updates)))
+	assert.Len(t, authority.AUMs, 2)
+	assert.Equal(t, nodeHead(t, nodeAuthority), authority.Head)
+	assert.True(t, authority.NodeKeyAuthorized(nodeKey, signNodeKey(t, other, nodeKey)))
+
+	// the chain is restored from the stored updates
+	restored := &TailnetKeyAuthority{TailnetID: 1, State: TailnetKeyAuthorityEnabled, Head: authority.Head, AUMs: authority.AUMs}
+	assert.True(t, restored.NodeKeyAuthorized(nodeKey, signNodeKey(t, other, nodeKey)))
+	assert.True(t, restored.ValidDisablement(disablementSecret))
+	assert.False(t, restored.ValidDisablement([]byte("wrong")))
+
+	// a node only knowing the genesis receives the missing updates
+	newNodeStorage := &tka.Mem{}
+	newNodeAuthority, err := tka.Bootstrap(newNodeStorage, genesis)
+	require.NoError(t, err)
+	offer, err := newNodeAuthority.SyncOffer(newNodeStorage)
+	require.NoError(t, err)
+	head, ancestors, err := fromSyncOffer(offer)
+	require.NoError(t, err)
+
+	resp, err := restored.SyncOffer(head, ancestors)
+	require.NoError(t, err)
+	assert.Equal(t, restored.Head, resp.Head)
+	assert.Len(t, resp.MissingAUMs, 1)
+
+	// updates not signed by a trusted key are rejected
+	rogueStorage := &tka.Mem{}
+	rogueAuthority, err := tka.Bootstrap(rogueStorage, genesis)
+	require.NoError(t, err)
+	rogueUpdater := rogueAuthority.NewUpdater(key.NewNLPrivate())
+	require.NoError(t, rogueUpdater.AddKey(tka.Key{Kind: tka.Key25519, Public: key.NewNLPrivate().Public().Verifier(), Votes: 1}))
+	rogueUpdates, err := rogueUpdater.Finalize(rogueStorage)
+	require.NoError(t, err)
+
+	assert.Error(t, restored.Inform(toMarshaledAUMs(rogueUpdates)))
+	assert.Len(t, restored.AUMs, 2)
+}
+
+func nodeHead(t *testing.T, a *tka.Authority) string {
+	head, err := a.Head().MarshalText()
+	require.NoError(t, err)
+	return string(head)
+}
+
+func signNodeKey(t *testing.T, signer key.NLPrivate, nodeKey key.NodePublic) []byte {
+	pub, err := nodeKey.MarshalBinary()
+	require.NoError(t, err)
+
+	sig := tka.NodeKeySignature{SigKind: tka.SigDirect, KeyID: signer.KeyID(), Pubkey: pub}
+	sig.Signature, err = signer.SignNKS(sig.SigHash())
+	require.NoError(t, err)
+
+	return sig.Serialize()
+}
+
+func toMarshaledAUMs(aums []tka.AUM) []tkatype.MarshaledAUM {
+	var result []tkatype.MarshaledAUM
+	for _, a := range aums {
+		result = append(result, a.Serialize())
+	}
+	return result
+}
@@ -3,8 +3,10 @@ package domain
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/jsiebens/ionscale/internal/util"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
@@ -43,7 +45,7 @@ type UserRepository interface {
 	GetOrCreateUserWithAccount(ctx context.Context, tailnet *Tailnet, account *Account) (*User, bool, error)
 	GetUser(ctx context.Context, userID uint64) (*User, error)
 	DeleteUser(ctx context.Context, userID uint64) error
-	ListUsers(ctx context.Context, tailnetID uint64) (Users, error)
+	ListUsers(ctx context.Context, filter UserFilter) (Users, error)
 	DeleteUsersByTailnet(ctx context.Context, tailnetID uint64) error
 	SetUserLastAuthenticated(ctx context.Context, userID uint64, timestamp time.Time) error
 }
@@ -61,6 +63,30 @@ type User struct {
 
 type Users []User
 
+const (
+	UserOrderByID   = "id"
+	UserOrderByName = "name"
+)
+
+// UserFilter selects the users of a tailnet, ordered by id unless specified otherwise, criteria left empty are ignored.
+type UserFilter struct {
+	TailnetID  uint64
+	NamePrefix string
+
+	// OrderBy is one of the UserOrderBy values, users with the same name are ordered by id.
+	OrderBy    string
+	Descending bool
+
+	// After continues the listing after the user at the cursor, in the order of the filter.
+	After *UserCursor
+	Limit int
+}
+
+type UserCursor struct {
+	ID   uint64
+	Name string
+}
+
 func (r *repository) GetOrCreateServiceUser(ctx context.Context, tailnet *Tailnet) (*User, bool, error) {
 	user := &User{}
 	id := util.NextID()
@@ -77,10 +103,38 @@ func (r *repository) GetOrCreateServiceUser(ctx context.Context, tailnet *Tailne
 	return user, user.ID == id, nil
 }
 
-func (r *repository) ListUsers(ctx context.Context, tailnetID uint64) (Users, error) {
+func (r *repository) ListUsers(ctx context.Context, filter UserFilter) (Users, error) {
 	var users = []User{}
 
-	tx := r.withContext(ctx).Where("tailnet_id = ? AND user_type = ?", tailnetID, UserTypePerson).Find(&users)
+	tx := r.withContext(ctx).Where("tailnet_id = ? AND user_type = ?", filter.TailnetID, UserTypePerson)
+
+	if filter.NamePrefix != "" {
+		tx = tx.Where(`lower(name) LIKE ? ESCAPE '\'`, likeEscaper.Replace(strings.ToLower(filter.NamePrefix))+"%")
+	}
+
+	dir, cmp := "asc", ">"
+	if filter.Descending {
+		dir, cmp = "desc", "<"
+	}
+
+	switch filter.OrderBy {
+	case UserOrderByName:
+		if filter.After != nil {
+			tx = tx.Where(fmt.Sprintf("(name %[1]s ? OR (name = ? AND id %[1]s ?))", cmp), filter.After.Name, filter.After.Name, filter.After.ID)
+		}
+		tx = tx.Order(fmt.Sprintf("name %[1]s, id %[1]s", dir))
+	default:
+		if filter.After != nil {
+			tx = tx.Where(fmt.Sprintf("id %s ?", cmp), filter.After.ID)
+		}
+		tx = tx.Order(fmt.Sprintf("id %s", dir))
+	}
+
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+
+	tx = tx.Find(&users)
 
 	if tx.Error != nil {
 		return nil, tx.Error
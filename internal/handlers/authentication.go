@@ -9,11 +9,15 @@ import (
 	tpl "github.com/jsiebens/ionscale/internal/templates"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/mr-tron/base58"
+	"go.uber.org/zap"
 	"net/http"
+	"strings"
 	"tailscale.com/tailcfg"
 	"time"
 
 	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/dns"
 	"github.com/jsiebens/ionscale/internal/domain"
 	"github.com/jsiebens/ionscale/internal/util"
 	"github.com/labstack/echo/v4"
@@ -24,6 +28,9 @@ func NewAuthenticationHandlers(
 	config *config.Config,
 	authProvider auth.Provider,
 	systemIAMPolicy *domain.IAMPolicy,
+	notifier core.RequestNotifier,
+	sessionManager core.PollMapSessionManager,
+	dnsPublisher dns.Publisher,
 	repository domain.Repository) *AuthenticationHandlers {
 
 	return &AuthenticationHandlers{
@@ -31,6 +38,9 @@ func NewAuthenticationHandlers(
 		authProvider:    authProvider,
 		repository:      repository,
 		systemIAMPolicy: systemIAMPolicy,
+		notifier:        notifier,
+		sessionManager:  sessionManager,
+		dnsPublisher:    dnsPublisher,
 	}
 }
 
@@ -39,13 +49,17 @@ type AuthenticationHandlers struct {
 	authProvider    auth.Provider
 	config          *config.Config
 	systemIAMPolicy *domain.IAMPolicy
+	notifier        core.RequestNotifier
+	sessionManager  core.PollMapSessionManager
+	dnsPublisher    dns.Publisher
 }
 
 type AuthInput struct {
-	Key     string   `param:"key"`
-	Flow    AuthFlow `param:"flow"`
-	AuthKey string   `query:"ak" form:"ak"`
-	Oidc    bool     `query:"oidc" form:"oidc"`
+	Key      string   `param:"key"`
+	Flow     AuthFlow `param:"flow"`
+	AuthKey  string   `query:"ak" form:"ak"`
+	Oidc     bool     `query:"oidc" form:"oidc"`
+	Decision string   `form:"decision"`
 }
 
 type EndAuthForm struct {
@@ -57,8 +71,9 @@ type EndAuthForm struct {
 }
 
 type oauthState struct {
-	Key  string
-	Flow AuthFlow
+	Key      string
+	Flow     AuthFlow
+	Decision string `json:",omitempty"`
 }
 
 type AuthFlow string
@@ -67,6 +82,13 @@ const (
 	AuthFlowMachineRegistration = "r"
 	AuthFlowClient              = "c"
 	AuthFlowSSHCheckFlow        = "s"
+	AuthFlowSSHApprovalFlow     = "a"
+	AuthFlowMachineShare        = "m"
+)
+
+const (
+	sshApprovalDecisionApprove = "approve"
+	sshApprovalDecisionReject  = "reject"
 )
 
 func (h *AuthenticationHandlers) StartAuth(c echo.Context) error {
@@ -110,6 +132,43 @@ func (h *AuthenticationHandlers) StartAuth(c echo.Context) error {
 		}
 	}
 
+	// ssh approval flow, the approver takes a decision before authenticating
+	if input.Flow == AuthFlowSSHApprovalFlow {
+		req, err := h.repository.GetSSHActionRequest(ctx, input.Key)
+		if err != nil || req == nil || !req.RequiresApproval() {
+			return logError(err)
+		}
+
+		if !req.IsPending() || req.IsExpired(time.Now().UTC()) {
+			return c.Redirect(http.StatusFound, "/a/error")
+		}
+
+		src, err := h.repository.GetMachine(ctx, req.SrcMachineID)
+		if err != nil || src == nil {
+			return logError(err)
+		}
+
+		dst, err := h.repository.GetMachine(ctx, req.DstMachineID)
+		if err != nil || dst == nil {
+			return logError(err)
+		}
+
+		csrf := c.Get(middleware.DefaultCSRFConfig.ContextKey).(string)
+		return c.Render(http.StatusOK, "", tpl.SSHApproval(src.User.Name, src.CompleteName(), dst.CompleteName(), req.SSHUser, csrf))
+	}
+
+	// machine share flow, a user of another tailnet accepts the invite
+	if input.Flow == AuthFlowMachineShare {
+		share, err := h.repository.GetMachineShareByCode(ctx, input.Key)
+		if err != nil || share == nil {
+			return logError(err)
+		}
+
+		if share.IsAccepted() {
+			return c.Redirect(http.StatusFound, "/a/error")
+		}
+	}
+
 	if h.authProvider == nil {
 		return logError(fmt.Errorf("unable to start auth flow as no auth provider is configured"))
 	}
@@ -134,6 +193,10 @@ func (h *AuthenticationHandlers) ProcessAuth(c echo.Context) error {
 		return logError(err)
 	}
 
+	if input.Flow == AuthFlowSSHApprovalFlow {
+		return h.processSSHApproval(c, input)
+	}
+
 	req, err := h.repository.GetRegistrationRequestByKey(ctx, input.Key)
 	if err != nil || req == nil {
 		return logError(err)
@@ -180,6 +243,11 @@ func (h *AuthenticationHandlers) Callback(c echo.Context) error {
 		return logError(err)
 	}
 
+	account.Attributes = accountAttributes(user)
+	if err := h.repository.SetAccountAttributes(ctx, account.ID, account.Attributes); err != nil {
+		return logError(err)
+	}
+
 	if state.Flow == AuthFlowSSHCheckFlow {
 		sshActionReq, err := h.repository.GetSSHActionRequest(ctx, state.Key)
 		if err != nil || sshActionReq == nil {
@@ -192,13 +260,19 @@ func (h *AuthenticationHandlers) Callback(c echo.Context) error {
 		}
 
 		if !machine.HasTags() && machine.User.AccountID != nil && *machine.User.AccountID == account.ID {
-			sshActionReq.Action = "accept"
-
 			err := h.repository.Transaction(func(rp domain.Repository) error {
+				// reload the request, an approver may have decided in the meantime
+				req, err := rp.GetSSHActionRequest(ctx, state.Key)
+				if err != nil || req == nil {
+					return err
+				}
+				if req.IsPending() {
+					req.MarkAuthenticated()
+				}
 				if err := rp.SetUserLastAuthenticated(ctx, machine.UserID, time.Now().UTC()); err != nil {
 					return err
 				}
-				if err := rp.SaveSSHActionRequest(ctx, sshActionReq); err != nil {
+				if err := rp.SaveSSHActionRequest(ctx, req); err != nil {
 					return err
 				}
 				return nil
@@ -207,6 +281,8 @@ func (h *AuthenticationHandlers) Callback(c echo.Context) error {
 				return logError(err)
 			}
 
+			h.notifier.Notify(core.SSHActionRequestTopic(state.Key))
+
 			return c.Redirect(http.StatusFound, "/a/success")
 		}
 
@@ -214,9 +290,14 @@ func (h *AuthenticationHandlers) Callback(c echo.Context) error {
 		if err := h.repository.SaveSSHActionRequest(ctx, sshActionReq); err != nil {
 			return logError(err)
 		}
+		h.notifier.Notify(core.SSHActionRequestTopic(state.Key))
 		return c.Redirect(http.StatusFound, "/a/error?e=nmo")
 	}
 
+	if state.Flow == AuthFlowSSHApprovalFlow {
+		return h.endSSHApprovalFlow(c, state, account)
+	}
+
 	tailnets, err := h.listAvailableTailnets(ctx, user)
 	if err != nil {
 		return logError(err)
@@ -230,6 +311,7 @@ func (h *AuthenticationHandlers) Callback(c echo.Context) error {
 			if err == nil && registrationRequest != nil {
 				registrationRequest.Error = "unauthorized"
 				_ = h.repository.SaveRegistrationRequest(ctx, registrationRequest)
+				h.notifier.Notify(core.RegistrationRequestTopic(registrationRequest.MachineKey))
 			}
 			return c.Redirect(http.StatusFound, "/a/error?e=ua")
 		}
@@ -259,6 +341,7 @@ func (h *AuthenticationHandlers) Callback(c echo.Context) error {
 			if err == nil && req != nil {
 				req.Error = "unauthorized"
 				_ = h.repository.SaveAuthenticationRequest(ctx, req)
+				h.notifier.Notify(core.AuthenticationRequestTopic(req.Key))
 			}
 			return c.Redirect(http.StatusFound, "/a/error?e=ua")
 		}
@@ -266,6 +349,32 @@ func (h *AuthenticationHandlers) Callback(c echo.Context) error {
 		return c.Render(http.StatusOK, "", tpl.Tailnets(account.ID, isSystemAdmin, tailnets, csrf))
 	}
 
+	if state.Flow == AuthFlowMachineShare {
+		share, err := h.repository.GetMachineShareByCode(ctx, state.Key)
+		if err != nil || share == nil {
+			return logError(err)
+		}
+
+		// the machine is shared with another tailnet, never with the tailnet of the machine itself
+		var candidates = []domain.Tailnet{}
+		for _, t := range tailnets {
+			if t.ID == share.TailnetID || share.IsTailnetInvite() && t.ID != share.InviteTailnetID {
+				continue
+			}
+			candidates = append(candidates, t)
+		}
+
+		if len(candidates) == 0 {
+			return c.Redirect(http.StatusFound, "/a/error?e=nsr")
+		}
+
+		if len(candidates) == 1 {
+			return h.endMachineShareFlow(c, EndAuthForm{AccountID: account.ID, TailnetID: candidates[0].ID}, share)
+		}
+
+		return c.Render(http.StatusOK, "", tpl.Tailnets(account.ID, false, candidates, csrf))
+	}
+
 	return echo.NewHTTPError(http.StatusNotFound)
 }
 
@@ -300,6 +409,39 @@ func (h *AuthenticationHandlers) EndAuth(c echo.Context) error {
 		return h.endCliAuthenticationFlow(c, form, req)
 	}
 
+	if state.Flow == AuthFlowMachineShare {
+		share, err := h.repository.GetMachineShareByCode(ctx, state.Key)
+		if err != nil || share == nil {
+			return logError(err)
+		}
+
+		// the selected tailnet is posted by the browser, so check it again like the callback did
+		tailnet, err := h.repository.GetTailnet(ctx, form.TailnetID)
+		if err != nil || tailnet == nil {
+			return logError(err)
+		}
+
+		account, err := h.repository.GetAccount(ctx, form.AccountID)
+		if err != nil || account == nil {
+			return logError(err)
+		}
+
+		if tailnet.ID == share.TailnetID || share.IsTailnetInvite() && tailnet.ID != share.InviteTailnetID {
+			return c.Redirect(http.StatusFound, "/a/error?e=nsr")
+		}
+
+		approved, err := tailnet.IAMPolicy.Get().EvaluatePolicy(accountIdentity(account))
+		if err != nil {
+			return logError(err)
+		}
+
+		if !approved {
+			return c.Redirect(http.StatusFound, "/a/error?e=nsr")
+		}
+
+		return h.endMachineShareFlow(c, form, share)
+	}
+
 	return echo.NewHTTPError(http.StatusBadRequest, "Invalid state parameter")
 }
 
@@ -323,6 +465,10 @@ func (h *AuthenticationHandlers) Error(c echo.Context) error {
 		return c.Render(http.StatusForbidden, "", tpl.NotTagOwner())
 	case "nmo":
 		return c.Render(http.StatusForbidden, "", tpl.NotMachineOwner())
+	case "nap":
+		return c.Render(http.StatusForbidden, "", tpl.NotSSHApprover())
+	case "nsr":
+		return c.Render(http.StatusForbidden, "", tpl.NotShareRecipient())
 	}
 	return c.Render(http.StatusOK, "", tpl.Error())
 }
@@ -353,6 +499,7 @@ func (h *AuthenticationHandlers) endCliAuthenticationFlow(c echo.Context, form E
 		if err != nil {
 			return logError(err)
 		}
+		h.notifier.Notify(core.AuthenticationRequestTopic(req.Key))
 		return c.Redirect(http.StatusFound, "/a/success")
 	}
 
@@ -387,6 +534,8 @@ func (h *AuthenticationHandlers) endCliAuthenticationFlow(c echo.Context, form E
 		return logError(err)
 	}
 
+	h.notifier.Notify(core.AuthenticationRequestTopic(req.Key))
+
 	return c.Redirect(http.StatusFound, "/a/success")
 }
 
@@ -418,6 +567,8 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 				return logError(err)
 			}
 
+			h.notifier.Notify(core.RegistrationRequestTopic(machineKey))
+
 			return c.Redirect(http.StatusFound, "/a/error?e=iak")
 		}
 
@@ -453,14 +604,20 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 		if err := h.repository.SaveRegistrationRequest(ctx, registrationRequest); err != nil {
 			return logError(err)
 		}
+		h.notifier.Notify(core.RegistrationRequestTopic(machineKey))
 		return c.Redirect(http.StatusFound, "/a/error?e=nto")
 	}
 
-	autoAllowIPs := tailnet.ACLPolicy.Get().FindAutoApprovedIPs(req.Hostinfo.RoutableIPs, tags, user)
+	tailnetMachines, err := h.repository.ListMachineByTailnet(ctx, tailnet.ID)
+	if err != nil {
+		return logError(err)
+	}
+
+	autoAllowIPs := tailnet.ACLPolicy.Get().FindAutoApprovedIPs(req.Hostinfo.RoutableIPs, tags, user, tailnetMachines.SubnetRoutes())
 
 	var m *domain.Machine
 
-	m, err := h.repository.GetMachineByKeyAndUser(ctx, machineKey, user.ID)
+	m, err = h.repository.GetMachineByKeyAndUser(ctx, machineKey, user.ID)
 	if err != nil {
 		return logError(err)
 	}
@@ -473,7 +630,7 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 		tags := append(registeredTags, advertisedTags...)
 
 		sanitizeHostname := dnsname.SanitizeHostname(req.Hostinfo.Hostname)
-		nameIdx, err := h.repository.GetNextMachineNameIndex(ctx, tailnet.ID, sanitizeHostname)
+		nameIdx, err := domain.NextMachineNameIndex(ctx, h.repository, tailnet, config.MagicDNSSuffix(), 0, sanitizeHostname)
 		if err != nil {
 			return logError(err)
 		}
@@ -484,12 +641,13 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 			NameIdx:           nameIdx,
 			MachineKey:        machineKey,
 			NodeKey:           nodeKey,
+			KeySignature:      req.NodeKeySignature,
 			Ephemeral:         ephemeral || req.Ephemeral,
 			RegisteredTags:    registeredTags,
 			Tags:              domain.SanitizeTags(tags),
 			AutoAllowIPs:      autoAllowIPs,
 			CreatedAt:         now,
-			ExpiresAt:         now.Add(180 * 24 * time.Hour).UTC(),
+			ExpiresAt:         now.Add(domain.DefaultKeyExpiry).UTC(),
 			KeyExpiryDisabled: len(tags) != 0,
 			Authorized:        !tailnet.MachineAuthorizationEnabled || authorized,
 
@@ -511,8 +669,8 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 		tags := append(registeredTags, advertisedTags...)
 
 		sanitizeHostname := dnsname.SanitizeHostname(req.Hostinfo.Hostname)
-		if m.Name != sanitizeHostname {
-			nameIdx, err := h.repository.GetNextMachineNameIndex(ctx, tailnet.ID, sanitizeHostname)
+		if !m.CustomName && m.Name != sanitizeHostname {
+			nameIdx, err := domain.NextMachineNameIndex(ctx, h.repository, tailnet, config.MagicDNSSuffix(), m.ID, sanitizeHostname)
 			if err != nil {
 				return logError(err)
 			}
@@ -520,6 +678,7 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 			m.NameIdx = nameIdx
 		}
 		m.NodeKey = nodeKey
+		m.KeySignature = req.NodeKeySignature
 		m.Ephemeral = ephemeral || req.Ephemeral
 		m.RegisteredTags = registeredTags
 		m.Tags = domain.SanitizeTags(tags)
@@ -528,7 +687,7 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 		m.User = *user
 		m.TailnetID = tailnet.ID
 		m.Tailnet = *tailnet
-		m.ExpiresAt = now.Add(180 * 24 * time.Hour).UTC()
+		m.ExpiresAt = now.Add(domain.DefaultKeyExpiry).UTC()
 	}
 
 	err = h.repository.Transaction(func(rp domain.Repository) error {
@@ -555,6 +714,9 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 		return logError(err)
 	}
 
+	h.notifier.Notify(core.RegistrationRequestTopic(machineKey))
+	h.dnsPublisher.SyncTailnet(m.TailnetID)
+
 	if m.Authorized {
 		return c.Redirect(http.StatusFound, "/a/success")
 	} else {
@@ -562,6 +724,50 @@ func (h *AuthenticationHandlers) endMachineRegistrationFlow(c echo.Context, form
 	}
 }
 
+func (h *AuthenticationHandlers) endMachineShareFlow(c echo.Context, form EndAuthForm, share *domain.MachineShare) error {
+	ctx := c.Request().Context()
+
+	tailnet, err := h.repository.GetTailnet(ctx, form.TailnetID)
+	if err != nil || tailnet == nil {
+		return logError(err)
+	}
+
+	account, err := h.repository.GetAccount(ctx, form.AccountID)
+	if err != nil || account == nil {
+		return logError(err)
+	}
+
+	user, _, err := h.repository.GetOrCreateUserWithAccount(ctx, tailnet, account)
+	if err != nil {
+		return logError(err)
+	}
+
+	now := time.Now().UTC()
+
+	// a machine shared with a tailnet is accepted by an admin, on behalf of the whole tailnet
+	if share.IsTailnetInvite() {
+		if !tailnet.IAMPolicy.Get().GetRole(*user).IsAdmin() {
+			return c.Redirect(http.StatusFound, "/a/error?e=nsr")
+		}
+		err = share.AcceptByTailnet(tailnet.ID, now)
+	} else {
+		err = share.AcceptByUser(user, now)
+	}
+
+	if err != nil {
+		return c.Redirect(http.StatusFound, "/a/error?e=nsr")
+	}
+
+	if err := h.repository.SaveMachineShare(ctx, share); err != nil {
+		return logError(err)
+	}
+
+	h.sessionManager.NotifyAll(share.TailnetID)
+	h.sessionManager.NotifyAll(share.RecipientTailnetID)
+
+	return c.Redirect(http.StatusFound, "/a/success")
+}
+
 func (h *AuthenticationHandlers) isSystemAdmin(u *auth.User) (bool, error) {
 	return h.systemIAMPolicy.EvaluatePolicy(&domain.Identity{UserID: u.ID, Email: u.Name, Attr: u.Attr})
 }
@@ -595,8 +801,135 @@ func (h *AuthenticationHandlers) exchangeUser(code string) (*auth.User, error) {
 	return user, nil
 }
 
+func (h *AuthenticationHandlers) processSSHApproval(c echo.Context, input AuthInput) error {
+	if input.Decision != sshApprovalDecisionApprove && input.Decision != sshApprovalDecisionReject {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid decision")
+	}
+
+	if h.authProvider == nil {
+		return logError(fmt.Errorf("unable to start auth flow as no auth provider is configured"))
+	}
+
+	state, err := h.encodeState(oauthState{Key: input.Key, Flow: input.Flow, Decision: input.Decision})
+	if err != nil {
+		return logError(err)
+	}
+
+	redirectUrl := h.authProvider.GetLoginURL(h.config.CreateUrl("/a/callback"), state)
+
+	return c.Redirect(http.StatusFound, redirectUrl)
+}
+
+func (h *AuthenticationHandlers) endSSHApprovalFlow(c echo.Context, state *oauthState, account *domain.Account) error {
+	ctx := c.Request().Context()
+
+	var authorized = true
+
+	err := h.repository.Transaction(func(rp domain.Repository) error {
+		req, err := rp.GetSSHActionRequest(ctx, state.Key)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if req == nil || !req.RequiresApproval() || !req.IsPending() || req.IsExpired(now) {
+			authorized = false
+			return nil
+		}
+
+		tailnet, err := rp.GetTailnet(ctx, req.TailnetID)
+		if err != nil || tailnet == nil {
+			return err
+		}
+
+		src, err := rp.GetMachine(ctx, req.SrcMachineID)
+		if err != nil || src == nil {
+			return err
+		}
+
+		if !req.CanBeApprovedBy(*tailnet.ACLPolicy.Get(), &src.User, account.LoginName) {
+			authorized = false
+			return nil
+		}
+
+		decision := domain.SSHApprovalApproved
+		if state.Decision == sshApprovalDecisionApprove {
+			req.Approve(account.LoginName)
+		} else {
+			req.Reject()
+			decision = domain.SSHApprovalRejected
+		}
+
+		if err := rp.SaveSSHActionRequest(ctx, req); err != nil {
+			return err
+		}
+
+		return rp.SaveSSHApproval(ctx, req.Audit(decision, account.LoginName, now))
+	})
+	if err != nil {
+		return logError(err)
+	}
+
+	if !authorized {
+		return c.Redirect(http.StatusFound, "/a/error?e=nap")
+	}
+
+	h.notifier.Notify(core.SSHActionRequestTopic(state.Key))
+
+	zap.L().Info("ssh request decided by approver",
+		zap.String("key", state.Key),
+		zap.String("decision", state.Decision),
+		zap.String("approver", account.LoginName))
+
+	return c.Redirect(http.StatusFound, "/a/success")
+}
+
+// accountAttributes collects the string claims of the identity provider,
+// claims of the id token take precedence over the ones of the user info endpoint.
+func accountAttributes(u *auth.User) domain.AccountAttributes {
+	result := domain.AccountAttributes{}
+	for _, key := range []string{"userinfo", "token"} {
+		if claims, ok := u.Attr[key].(map[string]interface{}); ok {
+			for k, v := range claims {
+				if s, ok := v.(string); ok {
+					result[k] = s
+				}
+			}
+		}
+	}
+	return result
+}
+
+// accountIdentity rebuilds the identity of an account from what is stored at login. Only the string claims are
+// stored, so a policy filter on another claim doesn't match and access is denied.
+func accountIdentity(a *domain.Account) *domain.Identity {
+	claims := map[string]interface{}{}
+	for k, v := range a.Attributes {
+		claims[k] = v
+	}
+
+	var emailDomain string
+	if _, d, ok := strings.Cut(a.LoginName, "@"); ok {
+		emailDomain = d
+	}
+
+	return &domain.Identity{
+		UserID: a.ExternalID,
+		Email:  a.LoginName,
+		Attr: map[string]interface{}{
+			"email":    a.LoginName,
+			"domain":   emailDomain,
+			"token":    claims,
+			"userinfo": claims,
+		},
+	}
+}
+
 func (h *AuthenticationHandlers) createState(flow AuthFlow, key string) (string, error) {
-	stateMap := oauthState{Key: key, Flow: flow}
+	return h.encodeState(oauthState{Key: key, Flow: flow})
+}
+
+func (h *AuthenticationHandlers) encodeState(stateMap oauthState) (string, error) {
 	marshal, err := json.Marshal(&stateMap)
 	if err != nil {
 		return "", err
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"github.com/jsiebens/ionscale/internal/config"
 	"github.com/jsiebens/ionscale/internal/dns"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/util"
 	"github.com/labstack/echo/v4"
-	"net"
 	"net/http"
 	"strings"
 	"tailscale.com/tailcfg"
@@ -11,14 +13,18 @@ import (
 	"time"
 )
 
-func NewDNSHandlers(_ key.MachinePublic, provider dns.Provider) *DNSHandlers {
+func NewDNSHandlers(_ key.MachinePublic, config *config.Config, provider dns.Provider, repository domain.Repository) *DNSHandlers {
 	return &DNSHandlers{
-		provider: provider,
+		config:     config,
+		provider:   provider,
+		repository: repository,
 	}
 }
 
 type DNSHandlers struct {
-	provider dns.Provider
+	config     *config.Config
+	provider   dns.Provider
+	repository domain.Repository
 }
 
 func (h *DNSHandlers) SetDNS(c echo.Context) error {
@@ -42,9 +48,21 @@ func (h *DNSHandlers) SetDNS(c echo.Context) error {
 	}
 
 	if strings.HasPrefix(req.Name, "_acme-challenge") && req.Type == "TXT" {
+		record := &domain.DNSChallengeRecord{
+			ID:        util.NextID(),
+			Type:      req.Type,
+			Name:      req.Name,
+			Value:     req.Value,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		if err := h.repository.SaveDNSChallengeRecord(ctx, record); err != nil {
+			return logError(err)
+		}
+
 		// Listen to connection close
 		notify := ctx.Done()
-		timeout := time.After(5 * time.Minute)
+		timeout := time.After(h.config.DNS.Provider.PropagationTimeout)
 		tick := time.NewTicker(5 * time.Second)
 
 		defer func() { tick.Stop() }()
@@ -52,11 +70,9 @@ func (h *DNSHandlers) SetDNS(c echo.Context) error {
 		for {
 			select {
 			case <-tick.C:
-				txtrecords, _ := net.LookupTXT(req.Name)
-				for _, txt := range txtrecords {
-					if txt == req.Value {
-						return c.JSON(http.StatusOK, tailcfg.SetDNSResponse{})
-					}
+				propagated, _ := dns.CheckTXTPropagation(ctx, h.config.DNS.Provider.Zone, req.Name, req.Value)
+				if propagated {
+					return c.JSON(http.StatusOK, tailcfg.SetDNSResponse{})
 				}
 			case <-timeout:
 				return c.JSON(http.StatusOK, tailcfg.SetDNSResponse{})
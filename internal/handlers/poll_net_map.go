@@ -11,6 +11,7 @@ import (
 	"github.com/klauspost/compress/zstd"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"slices"
 	"sync"
 	"tailscale.com/smallzstd"
 	"tailscale.com/tailcfg"
@@ -81,6 +82,16 @@ func (h *PollNetMapHandler) handlePollNetMap(c echo.Context, m *domain.Machine,
 	}
 
 	if !mapRequest.Stream {
+		// app connectors advertise the routes they learn while resolving their domains,
+		// so the approved routes follow the advertised ones
+		if !slices.Equal(m.HostInfo.RoutableIPs, mapRequest.Hostinfo.RoutableIPs) {
+			tailnetMachines, err := h.repository.ListMachineByTailnet(ctx, tailnetID)
+			if err != nil {
+				return logError(err)
+			}
+			m.AutoAllowIPs = m.Tailnet.ACLPolicy.Get().FindAutoApprovedIPs(mapRequest.Hostinfo.RoutableIPs, m.Tags, &m.User, tailnetMachines.SubnetRoutes())
+		}
+
 		m.HostInfo = domain.HostInfo(*mapRequest.Hostinfo)
 		m.DiscoKey = mapRequest.DiscoKey.String()
 		m.Endpoints = mapRequest.Endpoints
@@ -92,11 +103,31 @@ func (h *PollNetMapHandler) handlePollNetMap(c echo.Context, m *domain.Machine,
 
 		h.sessionManager.NotifyAll(tailnetID)
 
+		// machines of other tailnets may have this machine as a shared peer, or share a machine with it
+		sharingTailnets, err := h.repository.ListSharingTailnets(ctx, tailnetID)
+		if err != nil {
+			return logError(err)
+		}
+		for _, id := range sharingTailnets {
+			h.sessionManager.NotifyAll(id)
+		}
+
 		return c.JSONBlob(http.StatusOK, response)
 	}
 
 	updateChan := make(chan *core.Ping, 20)
-	h.sessionManager.Register(m.TailnetID, m.ID, updateChan)
+	session := &pollSession{sessionManager: h.sessionManager, machineID: machineID, tailnetID: tailnetID, tailnetName: m.Tailnet.Name, updates: updateChan}
+	session.register()
+
+	keepAliveTicker := time.NewTicker(config.KeepAliveInterval())
+	syncTicker := time.NewTicker(5 * time.Second)
+
+	defer func() {
+		session.deregister()
+		keepAliveTicker.Stop()
+		syncTicker.Stop()
+		_ = h.repository.SetMachineLastSeen(ctx, machineID)
+	}()
 
 	// Listen to connection close
 	notify := ctx.Done()
@@ -113,19 +144,6 @@ func (h *PollNetMapHandler) handlePollNetMap(c echo.Context, m *domain.Machine,
 	}
 	c.Response().Flush()
 
-	connectedDevices.WithLabelValues(m.Tailnet.Name).Inc()
-
-	keepAliveTicker := time.NewTicker(config.KeepAliveInterval())
-	syncTicker := time.NewTicker(5 * time.Second)
-
-	defer func() {
-		connectedDevices.WithLabelValues(m.Tailnet.Name).Dec()
-		h.sessionManager.Deregister(m.TailnetID, m.ID)
-		keepAliveTicker.Stop()
-		syncTicker.Stop()
-		_ = h.repository.SetMachineLastSeen(ctx, machineID)
-	}()
-
 	var latestSync = time.Now()
 	var latestUpdate = latestSync
 
@@ -151,6 +169,8 @@ func (h *PollNetMapHandler) handlePollNetMap(c echo.Context, m *domain.Machine,
 					return nil
 				}
 
+				session.follow(machine)
+
 				var payload []byte
 				var payloadErr error
 
@@ -173,6 +193,37 @@ func (h *PollNetMapHandler) handlePollNetMap(c echo.Context, m *domain.Machine,
 	}
 }
 
+// pollSession is the registration of a streaming map session with the session manager.
+type pollSession struct {
+	sessionManager core.PollMapSessionManager
+	machineID      uint64
+	tailnetID      uint64
+	tailnetName    string
+	updates        chan *core.Ping
+}
+
+func (s *pollSession) register() {
+	s.sessionManager.Register(s.tailnetID, s.machineID, s.updates)
+	connectedDevices.WithLabelValues(s.tailnetName).Inc()
+}
+
+func (s *pollSession) deregister() {
+	connectedDevices.WithLabelValues(s.tailnetName).Dec()
+	s.sessionManager.Deregister(s.tailnetID, s.machineID)
+}
+
+// follow registers the session with the tailnet of the machine, when the machine was moved to another tailnet.
+func (s *pollSession) follow(m *domain.Machine) {
+	if m.TailnetID == s.tailnetID {
+		return
+	}
+
+	s.deregister()
+	s.tailnetID = m.TailnetID
+	s.tailnetName = m.Tailnet.Name
+	s.register()
+}
+
 func (h *PollNetMapHandler) createKeepAliveResponse(request *tailcfg.MapRequest) ([]byte, error) {
 	mapResponse := &tailcfg.MapResponse{
 		KeepAlive: true,
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPollSession_FollowsMovedMachine(t *testing.T) {
+	sessionManager := core.NewPollMapSessionManager()
+	updates := make(chan *core.Ping, 1)
+
+	session := &pollSession{sessionManager: sessionManager, machineID: 10, tailnetID: 1, tailnetName: "a", updates: updates}
+	session.register()
+	defer session.deregister()
+
+	// the machine did not move, the session stays registered with its tailnet
+	session.follow(&domain.Machine{ID: 10, TailnetID: 1, Tailnet: domain.Tailnet{ID: 1, Name: "a"}})
+	assert.True(t, sessionManager.HasSession(1, 10))
+
+	session.follow(&domain.Machine{ID: 10, TailnetID: 2, Tailnet: domain.Tailnet{ID: 2, Name: "b"}})
+	assert.False(t, sessionManager.HasSession(1, 10))
+	assert.True(t, sessionManager.HasSession(2, 10))
+	assert.Equal(t, uint64(2), session.tailnetID)
+	assert.Equal(t, "b", session.tailnetName)
+
+	// updates of the new tailnet reach the session
+	sessionManager.NotifyAll(2)
+	select {
+	case <-updates:
+	default:
+		assert.Fail(t, "expected an update for the new tailnet")
+	}
+
+	sessionManager.NotifyAll(1)
+	select {
+	case <-updates:
+		assert.Fail(t, "unexpected update for the previous tailnet")
+	default:
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/jsiebens/ionscale/internal/addr"
 	"github.com/jsiebens/ionscale/internal/config"
 	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/dns"
 	"github.com/jsiebens/ionscale/internal/domain"
 	"github.com/jsiebens/ionscale/internal/mapping"
 	"github.com/jsiebens/ionscale/internal/util"
@@ -21,10 +22,14 @@ func NewRegistrationHandlers(
 	machineKey key.MachinePublic,
 	config *config.Config,
 	sessionManager core.PollMapSessionManager,
+	notifier core.RequestNotifier,
+	dnsPublisher dns.Publisher,
 	repository domain.Repository) *RegistrationHandlers {
 	return &RegistrationHandlers{
 		machineKey:     machineKey,
 		sessionManager: sessionManager,
+		notifier:       notifier,
+		dnsPublisher:   dnsPublisher,
 		repository:     repository,
 		config:         config,
 	}
@@ -34,6 +39,8 @@ type RegistrationHandlers struct {
 	machineKey     key.MachinePublic
 	repository     domain.Repository
 	sessionManager core.PollMapSessionManager
+	notifier       core.RequestNotifier
+	dnsPublisher   dns.Publisher
 	config         *config.Config
 }
 
@@ -70,10 +77,18 @@ func (h *RegistrationHandlers) Register(c echo.Context) error {
 			m.ExpiresAt = req.Expiry
 
 			if m.Ephemeral {
+				sharingTailnets, err := h.repository.ListSharingTailnets(ctx, m.TailnetID)
+				if err != nil {
+					return logError(err)
+				}
 				if _, err := h.repository.DeleteMachine(ctx, m.ID); err != nil {
 					return logError(err)
 				}
 				h.sessionManager.NotifyAll(m.TailnetID)
+				for _, id := range sharingTailnets {
+					h.sessionManager.NotifyAll(id)
+				}
+				h.dnsPublisher.SyncTailnet(m.TailnetID)
 			} else {
 				if err := h.repository.SaveMachine(ctx, m); err != nil {
 					return logError(err)
@@ -86,8 +101,8 @@ func (h *RegistrationHandlers) Register(c echo.Context) error {
 		}
 
 		sanitizeHostname := dnsname.SanitizeHostname(req.Hostinfo.Hostname)
-		if m.Name != sanitizeHostname {
-			nameIdx, err := h.repository.GetNextMachineNameIndex(ctx, m.TailnetID, sanitizeHostname)
+		if !m.CustomName && m.Name != sanitizeHostname {
+			nameIdx, err := domain.NextMachineNameIndex(ctx, h.repository, &m.Tailnet, config.MagicDNSSuffix(), m.ID, sanitizeHostname)
 			if err != nil {
 				return logError(err)
 			}
@@ -103,6 +118,8 @@ func (h *RegistrationHandlers) Register(c echo.Context) error {
 			return logError(err)
 		}
 
+		h.dnsPublisher.SyncTailnet(m.TailnetID)
+
 		tUser, tLogin := mapping.ToUser(m.User)
 
 		response := tailcfg.RegisterResponse{
@@ -174,7 +191,12 @@ func (h *RegistrationHandlers) authenticateMachineWithAuthKey(c echo.Context, ma
 	advertisedTags := domain.SanitizeTags(req.Hostinfo.RequestTags)
 	tags := append(registeredTags, advertisedTags...)
 
-	autoAllowIPs := tailnet.ACLPolicy.Get().FindAutoApprovedIPs(req.Hostinfo.RoutableIPs, tags, &user)
+	tailnetMachines, err := h.repository.ListMachineByTailnet(ctx, tailnet.ID)
+	if err != nil {
+		return logError(err)
+	}
+
+	autoAllowIPs := tailnet.ACLPolicy.Get().FindAutoApprovedIPs(req.Hostinfo.RoutableIPs, tags, &user, tailnetMachines.SubnetRoutes())
 
 	var m *domain.Machine
 
@@ -187,7 +209,7 @@ func (h *RegistrationHandlers) authenticateMachineWithAuthKey(c echo.Context, ma
 
 	if m == nil {
 		sanitizeHostname := dnsname.SanitizeHostname(req.Hostinfo.Hostname)
-		nameIdx, err := h.repository.GetNextMachineNameIndex(ctx, tailnet.ID, sanitizeHostname)
+		nameIdx, err := domain.NextMachineNameIndex(ctx, h.repository, &tailnet, config.MagicDNSSuffix(), 0, sanitizeHostname)
 		if err != nil {
 			return logError(err)
 		}
@@ -198,12 +220,13 @@ func (h *RegistrationHandlers) authenticateMachineWithAuthKey(c echo.Context, ma
 			NameIdx:           nameIdx,
 			MachineKey:        machineKey,
 			NodeKey:           nodeKey,
+			KeySignature:      req.NodeKeySignature,
 			Ephemeral:         authKey.Ephemeral || req.Ephemeral,
 			RegisteredTags:    registeredTags,
 			Tags:              domain.SanitizeTags(tags),
 			AutoAllowIPs:      autoAllowIPs,
 			CreatedAt:         now,
-			ExpiresAt:         now.Add(180 * 24 * time.Hour).UTC(),
+			ExpiresAt:         now.Add(domain.DefaultKeyExpiry).UTC(),
 			KeyExpiryDisabled: len(tags) != 0,
 			Authorized:        !tailnet.MachineAuthorizationEnabled || authKey.PreAuthorized,
 
@@ -225,8 +248,8 @@ func (h *RegistrationHandlers) authenticateMachineWithAuthKey(c echo.Context, ma
 		m.IPv6 = domain.IP{Addr: ipv6}
 	} else {
 		sanitizeHostname := dnsname.SanitizeHostname(req.Hostinfo.Hostname)
-		if m.Name != sanitizeHostname {
-			nameIdx, err := h.repository.GetNextMachineNameIndex(ctx, tailnet.ID, sanitizeHostname)
+		if !m.CustomName && m.Name != sanitizeHostname {
+			nameIdx, err := domain.NextMachineNameIndex(ctx, h.repository, &tailnet, config.MagicDNSSuffix(), m.ID, sanitizeHostname)
 			if err != nil {
 				return logError(err)
 			}
@@ -234,6 +257,7 @@ func (h *RegistrationHandlers) authenticateMachineWithAuthKey(c echo.Context, ma
 			m.NameIdx = nameIdx
 		}
 		m.NodeKey = nodeKey
+		m.KeySignature = req.NodeKeySignature
 		m.Ephemeral = authKey.Ephemeral || req.Ephemeral
 		m.RegisteredTags = registeredTags
 		m.Tags = domain.SanitizeTags(tags)
@@ -242,13 +266,15 @@ func (h *RegistrationHandlers) authenticateMachineWithAuthKey(c echo.Context, ma
 		m.User = user
 		m.TailnetID = tailnet.ID
 		m.Tailnet = tailnet
-		m.ExpiresAt = now.Add(180 * 24 * time.Hour).UTC()
+		m.ExpiresAt = now.Add(domain.DefaultKeyExpiry).UTC()
 	}
 
 	if err := h.repository.SaveMachine(ctx, m); err != nil {
 		return logError(err)
 	}
 
+	h.dnsPublisher.SyncTailnet(m.TailnetID)
+
 	tUser, tLogin := mapping.ToUser(m.User)
 	response := tailcfg.RegisterResponse{
 		MachineAuthorized: true,
@@ -263,46 +289,52 @@ func (h *RegistrationHandlers) followup(c echo.Context, req *tailcfg.RegisterReq
 	// Listen to connection close
 	ctx := c.Request().Context()
 	notify := ctx.Done()
-	tick := time.NewTicker(2 * time.Second)
-
-	defer func() { tick.Stop() }()
 
 	machineKey := h.machineKey.String()
 
+	events, unsubscribe := h.notifier.Subscribe(core.RegistrationRequestTopic(machineKey))
+	defer unsubscribe()
+
+	tick := time.NewTicker(core.RequestRecheckInterval)
+
+	defer func() { tick.Stop() }()
+
 	for {
-		select {
-		case <-tick.C:
-			m, err := h.repository.GetRegistrationRequestByMachineKey(ctx, machineKey)
+		m, err := h.repository.GetRegistrationRequestByMachineKey(ctx, machineKey)
 
-			if err != nil || m == nil {
-				response := tailcfg.RegisterResponse{MachineAuthorized: false, Error: "something went wrong"}
-				return c.JSON(http.StatusOK, response)
-			}
+		if err != nil || m == nil {
+			response := tailcfg.RegisterResponse{MachineAuthorized: false, Error: "something went wrong"}
+			return c.JSON(http.StatusOK, response)
+		}
 
-			if m != nil && m.Authenticated {
-				user, err := h.repository.GetUser(ctx, m.UserID)
-				if err != nil {
-					return err
-				}
+		if m.Authenticated {
+			user, err := h.repository.GetUser(ctx, m.UserID)
+			if err != nil {
+				return err
+			}
 
-				u, l := mapping.ToUser(*user)
+			u, l := mapping.ToUser(*user)
 
-				response := tailcfg.RegisterResponse{
-					MachineAuthorized: len(m.Error) != 0,
-					Error:             m.Error,
-					User:              u,
-					Login:             l,
-				}
-				return c.JSON(http.StatusOK, response)
+			response := tailcfg.RegisterResponse{
+				MachineAuthorized: len(m.Error) != 0,
+				Error:             m.Error,
+				User:              u,
+				Login:             l,
 			}
+			return c.JSON(http.StatusOK, response)
+		}
 
-			if m != nil && len(m.Error) != 0 {
-				response := tailcfg.RegisterResponse{
-					MachineAuthorized: len(m.Error) != 0,
-					Error:             m.Error,
-				}
-				return c.JSON(http.StatusOK, response)
+		if len(m.Error) != 0 {
+			response := tailcfg.RegisterResponse{
+				MachineAuthorized: len(m.Error) != 0,
+				Error:             m.Error,
 			}
+			return c.JSON(http.StatusOK, response)
+		}
+
+		select {
+		case <-events:
+		case <-tick.C:
 		case <-notify:
 			return nil
 		}
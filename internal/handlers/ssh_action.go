@@ -1,22 +1,28 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/core"
 	"github.com/jsiebens/ionscale/internal/domain"
 	"github.com/jsiebens/ionscale/internal/util"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 	"net/http"
+	"net/url"
+	"strings"
 	"tailscale.com/tailcfg"
 	"tailscale.com/types/key"
 	"time"
 )
 
-func NewSSHActionHandlers(machineKey key.MachinePublic, config *config.Config, repository domain.Repository) *SSHActionHandlers {
+func NewSSHActionHandlers(machineKey key.MachinePublic, config *config.Config, notifier core.RequestNotifier, repository domain.Repository) *SSHActionHandlers {
 	return &SSHActionHandlers{
 		machineKey: machineKey,
 		repository: repository,
 		config:     config,
+		notifier:   notifier,
 	}
 }
 
@@ -24,12 +30,16 @@ type SSHActionHandlers struct {
 	machineKey key.MachinePublic
 	repository domain.Repository
 	config     *config.Config
+	notifier   core.RequestNotifier
 }
 
 type sshActionRequestData struct {
-	SrcMachineID uint64 `param:"src_machine_id"`
-	DstMachineID uint64 `param:"dst_machine_id"`
-	CheckPeriod  string `param:"check_period"`
+	SrcMachineID uint64   `param:"src_machine_id"`
+	DstMachineID uint64   `param:"dst_machine_id"`
+	CheckPeriod  string   `param:"check_period"`
+	Approvers    []string `query:"approvers"`
+	SSHUser      string   `query:"ssh_user"`
+	LocalUser    string   `query:"local_user"`
 }
 
 func (h *SSHActionHandlers) StartAuth(c echo.Context) error {
@@ -40,7 +50,8 @@ func (h *SSHActionHandlers) StartAuth(c echo.Context) error {
 		return logError(err)
 	}
 
-	if data.CheckPeriod != "" && data.CheckPeriod != "always" {
+	// a check period never skips the approval of a second person
+	if len(data.Approvers) == 0 && data.CheckPeriod != "" && data.CheckPeriod != "always" {
 		checkPeriod, err := time.ParseDuration(data.CheckPeriod)
 		if err != nil {
 			_ = logError(err)
@@ -56,24 +67,49 @@ func (h *SSHActionHandlers) StartAuth(c echo.Context) error {
 			sinceLastAuthentication := time.Since(*machine.User.LastAuthenticated)
 
 			if sinceLastAuthentication < checkPeriod {
-				resp := &tailcfg.SSHAction{
-					Accept:                   true,
-					AllowAgentForwarding:     true,
-					AllowLocalPortForwarding: true,
+				options, err := domain.ParseSSHSessionOptions(c.QueryParams())
+				if err != nil {
+					_ = logError(err)
+					return c.JSON(http.StatusOK, &tailcfg.SSHAction{Reject: true})
 				}
-
-				return c.JSON(http.StatusOK, resp)
+				h.audit(ctx, data.SrcMachineID, data.DstMachineID, data.SSHUser, data.LocalUser, domain.SSHSessionDecisionAccept)
+				return c.JSON(http.StatusOK, options.Accept())
 			}
 		}
 	}
 
 check:
+	options, err := domain.ParseSSHSessionOptions(c.QueryParams())
+	if err != nil {
+		_ = logError(err)
+		return c.JSON(http.StatusOK, &tailcfg.SSHAction{Reject: true})
+	}
+
+	dst, err := h.repository.GetMachine(ctx, data.DstMachineID)
+	if err != nil {
+		return logError(err)
+	}
+
+	if dst == nil {
+		return c.JSON(http.StatusOK, &tailcfg.SSHAction{Reject: true})
+	}
+
+	now := time.Now().UTC()
 	key := util.RandStringBytes(8)
 	request := &domain.SSHActionRequest{
 		Key:          key,
 		SrcMachineID: data.SrcMachineID,
 		DstMachineID: data.DstMachineID,
-		CreatedAt:    time.Now().UTC(),
+		CreatedAt:    now,
+		TailnetID:    dst.TailnetID,
+		SSHUser:      data.SSHUser,
+		LocalUser:    data.LocalUser,
+		Approvers:    data.Approvers,
+	}
+
+	if request.RequiresApproval() {
+		expiresAt := now.Add(h.config.Auth.SSHApprovalTimeout)
+		request.ExpiresAt = &expiresAt
 	}
 
 	authUrl := h.config.CreateUrl("/a/s/%s", key)
@@ -82,11 +118,24 @@ check:
 		return logError(err)
 	}
 
+	message := fmt.Sprintf("# Tailscale SSH requires an additional check.\n# To authenticate, visit: %s\n", authUrl)
+	if request.RequiresApproval() {
+		approveUrl := h.config.CreateUrl("/a/a/%s", key)
+		message = message + fmt.Sprintf("# This session also requires the approval of one of: %s\n# Ask an approver to visit: %s\n", strings.Join(request.Approvers, ", "), approveUrl)
+	}
+
 	resp := &tailcfg.SSHAction{
-		Message:         fmt.Sprintf("# Tailscale SSH requires an additional check.\n# To authenticate, visit: %s\n", authUrl),
+		Message:         message,
 		HoldAndDelegate: fmt.Sprintf("https://unused/machine/ssh/action/check/%s", key),
 	}
 
+	// pass the session options along to the final action
+	query := url.Values{}
+	options.Encode(query)
+	if len(query) != 0 {
+		resp.HoldAndDelegate = resp.HoldAndDelegate + "?" + query.Encode()
+	}
+
 	return c.JSON(http.StatusOK, resp)
 }
 
@@ -95,38 +144,110 @@ func (h *SSHActionHandlers) CheckAuth(c echo.Context) error {
 	ctx := c.Request().Context()
 	notify := ctx.Done()
 
-	tick := time.NewTicker(2 * time.Second)
+	key := c.Param("key")
 
-	defer func() { tick.Stop() }()
+	events, unsubscribe := h.notifier.Subscribe(core.SSHActionRequestTopic(key))
+	defer unsubscribe()
 
-	key := c.Param("key")
+	tick := time.NewTicker(core.RequestRecheckInterval)
+
+	defer func() { tick.Stop() }()
 
 	for {
-		select {
-		case <-tick.C:
-			m, err := h.repository.GetSSHActionRequest(ctx, key)
+		m, err := h.repository.GetSSHActionRequest(ctx, key)
 
-			if err != nil || m == nil {
-				return c.JSON(http.StatusOK, &tailcfg.SSHAction{Reject: true})
-			}
+		if err != nil || m == nil {
+			return c.JSON(http.StatusOK, &tailcfg.SSHAction{Reject: true})
+		}
 
-			if m.Action == "accept" {
-				action := &tailcfg.SSHAction{
-					Accept:                   true,
-					AllowAgentForwarding:     true,
-					AllowLocalPortForwarding: true,
-				}
-				_ = h.repository.DeleteSSHActionRequest(ctx, key)
-				return c.JSON(http.StatusOK, action)
+		if m.Action == "" && m.IsExpired(time.Now().UTC()) {
+			if err := h.expire(ctx, m); err != nil {
+				return logError(err)
 			}
+			return c.JSON(http.StatusOK, &tailcfg.SSHAction{Reject: true})
+		}
 
-			if m.Action == "reject" {
-				action := &tailcfg.SSHAction{Reject: true}
+		if m.Action == "accept" {
+			options, err := domain.ParseSSHSessionOptions(c.QueryParams())
+			if err != nil {
+				_ = logError(err)
 				_ = h.repository.DeleteSSHActionRequest(ctx, key)
-				return c.JSON(http.StatusOK, action)
+				return c.JSON(http.StatusOK, &tailcfg.SSHAction{Reject: true})
 			}
+			action := options.Accept()
+			_ = h.repository.DeleteSSHActionRequest(ctx, key)
+			h.audit(ctx, m.SrcMachineID, m.DstMachineID, m.SSHUser, m.LocalUser, domain.SSHSessionDecisionAccept)
+			return c.JSON(http.StatusOK, action)
+		}
+
+		if m.Action == "reject" {
+			action := &tailcfg.SSHAction{Reject: true}
+			_ = h.repository.DeleteSSHActionRequest(ctx, key)
+			h.audit(ctx, m.SrcMachineID, m.DstMachineID, m.SSHUser, m.LocalUser, domain.SSHSessionDecisionReject)
+			return c.JSON(http.StatusOK, action)
+		}
+
+		// wake up when the approval timed out
+		var expired <-chan time.Time
+		if m.ExpiresAt != nil {
+			expired = time.After(time.Until(*m.ExpiresAt))
+		}
+
+		select {
+		case <-events:
+		case <-tick.C:
+		case <-expired:
 		case <-notify:
 			return nil
 		}
 	}
 }
+
+func (h *SSHActionHandlers) expire(ctx context.Context, req *domain.SSHActionRequest) error {
+	zap.L().Info("ssh request expired without approval",
+		zap.String("key", req.Key),
+		zap.Uint64("src_machine_id", req.SrcMachineID),
+		zap.Uint64("dst_machine_id", req.DstMachineID),
+		zap.String("ssh_user", req.SSHUser))
+
+	err := h.repository.Transaction(func(rp domain.Repository) error {
+		if err := rp.SaveSSHApproval(ctx, req.Audit(domain.SSHApprovalExpired, "", time.Now().UTC())); err != nil {
+			return err
+		}
+		return rp.DeleteSSHActionRequest(ctx, req.Key)
+	})
+	if err != nil {
+		return err
+	}
+
+	h.audit(ctx, req.SrcMachineID, req.DstMachineID, req.SSHUser, req.LocalUser, domain.SSHSessionDecisionExpire)
+	return nil
+}
+
+// audit records the outcome of a check as a session event, a failure is logged but never blocks the session.
+func (h *SSHActionHandlers) audit(ctx context.Context, srcMachineID, dstMachineID uint64, sshUser, localUser, decision string) {
+	event := &domain.SSHSessionEvent{
+		Type:         domain.SSHSessionEventCheck,
+		SrcMachineID: srcMachineID,
+		DstMachineID: dstMachineID,
+		SSHUser:      sshUser,
+		LocalUser:    localUser,
+		Decision:     decision,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	dst, err := h.repository.GetMachine(ctx, dstMachineID)
+	if err != nil || dst == nil {
+		return
+	}
+	event.TailnetID = dst.TailnetID
+	event.DstMachineName = dst.CompleteName()
+
+	if src, err := h.repository.GetMachine(ctx, srcMachineID); err == nil && src != nil {
+		event.SrcMachineName = src.CompleteName()
+	}
+
+	if err := h.repository.SaveSSHSessionEvent(ctx, event); err != nil {
+		_ = logError(err)
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/pkg/ssh"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+	"tailscale.com/tailcfg"
+	"time"
+)
+
+func NewSSHEventHandlers(config *config.Config, repository domain.Repository) *SSHEventHandlers {
+	return &SSHEventHandlers{
+		issuer:     config.PublicUrl.String(),
+		repository: repository,
+	}
+}
+
+type SSHEventHandlers struct {
+	issuer     string
+	repository domain.Repository
+}
+
+// maxEventClockSkew bounds the timestamp reported by a recorder, events outside of it are stored at the time received.
+const maxEventClockSkew = 5 * time.Minute
+
+// Report stores the session events of the recorders, which authenticate with an ID token issued by this server.
+func (h *SSHEventHandlers) Report(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	recorder, err := h.authenticateRecorder(ctx, c.Request().Header.Get(echo.HeaderAuthorization))
+	if err != nil {
+		return logError(err)
+	}
+
+	if recorder == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized)
+	}
+
+	req := &ssh.SessionEvent{}
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	if req.Type != ssh.SessionStarted && req.Type != ssh.SessionEnded {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	event := &domain.SSHSessionEvent{
+		TailnetID:      recorder.TailnetID,
+		Type:           req.Type,
+		ConnectionID:   req.ConnectionID,
+		SrcMachineName: req.SrcNode,
+		DstMachineName: req.DstNode,
+		SSHUser:        req.SSHUser,
+		LocalUser:      req.LocalUser,
+		Decision:       domain.SSHSessionDecisionAccept,
+		Recording:      req.Recording,
+		CreatedAt:      eventTime(req.Timestamp, time.Now().UTC()),
+	}
+
+	// only link machines of the tailnet of the recorder
+	if m := h.findMachine(ctx, recorder.TailnetID, req.SrcNodeID); m != nil {
+		event.SrcMachineID = m.ID
+		event.SrcMachineName = m.CompleteName()
+	}
+
+	if m := h.findMachine(ctx, recorder.TailnetID, req.DstNodeID); m != nil {
+		event.DstMachineID = m.ID
+		event.DstMachineName = m.CompleteName()
+	}
+
+	if err := h.repository.SaveSSHSessionEvent(ctx, event); err != nil {
+		return logError(err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *SSHEventHandlers) authenticateRecorder(ctx context.Context, authorization string) (*domain.Machine, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return nil, nil
+	}
+
+	keySet, err := h.repository.GetJSONWebKeySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return keySet.Key.Public(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	if err != nil || !parsed.Valid {
+		return nil, nil
+	}
+
+	if !claims.VerifyIssuer(h.issuer, true) || !claims.VerifyAudience(ssh.EventsAudience, true) {
+		return nil, nil
+	}
+
+	nid, ok := claims["nid"].(float64)
+	if !ok {
+		return nil, nil
+	}
+
+	m, err := h.repository.GetMachine(ctx, uint64(nid))
+	if err != nil {
+		return nil, err
+	}
+
+	// the recorder app is declared by the machine itself, the policy decides which machines are recorders
+	if m == nil || !m.Tailnet.ACLPolicy.Get().IsSessionRecorder(m) {
+		return nil, nil
+	}
+
+	return m, nil
+}
+
+func eventTime(reported time.Time, received time.Time) time.Time {
+	if reported.IsZero() || reported.Before(received.Add(-maxEventClockSkew)) || reported.After(received.Add(maxEventClockSkew)) {
+		return received
+	}
+	return reported.UTC()
+}
+
+func (h *SSHEventHandlers) findMachine(ctx context.Context, tailnetID uint64, id tailcfg.StableNodeID) *domain.Machine {
+	machineID, err := strconv.ParseUint(string(id), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	m, err := h.repository.GetMachine(ctx, machineID)
+	if err != nil || m == nil || m.TailnetID != tailnetID {
+		return nil
+	}
+
+	return m
+}
@@ -0,0 +1,472 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/util"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"tailscale.com/tailcfg"
+	"tailscale.com/tka"
+	"tailscale.com/types/key"
+	"tailscale.com/types/tkatype"
+	"time"
+)
+
+func NewTKAHandlers(machineKey key.MachinePublic, sessionManager core.PollMapSessionManager, repository domain.Repository) *TKAHandlers {
+	return &TKAHandlers{
+		machineKey:     machineKey,
+		sessionManager: sessionManager,
+		repository:     repository,
+	}
+}
+
+// TKAHandlers implements the Tailnet Lock endpoints. The authority is managed by the nodes holding
+// a trusted key, the control server verifies and relays the updates and node key signatures.
+type TKAHandlers struct {
+	machineKey     key.MachinePublic
+	sessionManager core.PollMapSessionManager
+	repository     domain.Repository
+}
+
+func (h *TKAHandlers) InitBegin(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(tailcfg.TKAInitBeginRequest)
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	m, err := h.adminMachine(ctx, req.NodeKey)
+	if err != nil {
+		return err
+	}
+
+	err = h.updateAuthority(ctx, m.TailnetID, func(rp domain.Repository, current *domain.TailnetKeyAuthority) error {
+		if current != nil && current.IsEnabled() {
+			return echo.NewHTTPError(http.StatusBadRequest, "tailnet lock is already enabled")
+		}
+
+		if current != nil && !current.CanBeInitializedBy(m.ID, time.Now().UTC()) {
+			return echo.NewHTTPError(http.StatusConflict, "tailnet lock initialization was started by another node")
+		}
+
+		authority, err := domain.NewTailnetKeyAuthority(m.TailnetID, m.ID, req.GenesisAUM)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		if err := rp.DeleteTailnetKeyAuthority(ctx, m.TailnetID); err != nil {
+			return err
+		}
+		return rp.SaveTailnetKeyAuthority(ctx, authority)
+	})
+	if err != nil {
+		return err
+	}
+
+	machines, err := h.repository.ListMachineByTailnet(ctx, m.TailnetID)
+	if err != nil {
+		return logError(err)
+	}
+
+	// the node initializing the lock signs all existing nodes
+	resp := &tailcfg.TKAInitBeginResponse{}
+	for _, n := range machines {
+		nodeKey, err := util.ParseNodePublicKey(n.NodeKey)
+		if err != nil {
+			return logError(err)
+		}
+		resp.NeedSignatures = append(resp.NeedSignatures, tailcfg.TKASignInfo{
+			NodeID:     tailcfg.NodeID(n.ID),
+			NodePublic: *nodeKey,
+		})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *TKAHandlers) InitFinish(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(tailcfg.TKAInitFinishRequest)
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	m, err := h.adminMachine(ctx, req.NodeKey)
+	if err != nil {
+		return err
+	}
+
+	err = h.updateAuthority(ctx, m.TailnetID, func(rp domain.Repository, authority *domain.TailnetKeyAuthority) error {
+		if authority == nil || authority.State != domain.TailnetKeyAuthorityPending {
+			return echo.NewHTTPError(http.StatusBadRequest, "tailnet lock initialization was not started")
+		}
+
+		if !authority.IsInitializedBy(m.ID) {
+			return echo.NewHTTPError(http.StatusConflict, "tailnet lock initialization was started by another node")
+		}
+
+		machines, err := rp.ListMachineByTailnet(ctx, m.TailnetID)
+		if err != nil {
+			return err
+		}
+
+		var signed []domain.Machine
+		for _, n := range machines {
+			sig, ok := req.Signatures[tailcfg.NodeID(n.ID)]
+			if !ok {
+				continue
+			}
+
+			nodeKey, err := util.ParseNodePublicKey(n.NodeKey)
+			if err != nil {
+				return err
+			}
+
+			if !authority.NodeKeyAuthorized(*nodeKey, sig) {
+				return echo.NewHTTPError(http.StatusBadRequest, "invalid node key signature")
+			}
+
+			n.KeySignature = sig
+			signed = append(signed, n)
+		}
+
+		authority.State = domain.TailnetKeyAuthorityEnabled
+
+		for _, n := range signed {
+			if err := rp.SaveMachine(ctx, &n); err != nil {
+				return err
+			}
+		}
+		return rp.SaveTailnetKeyAuthority(ctx, authority)
+	})
+	if err != nil {
+		return err
+	}
+
+	h.sessionManager.NotifyAll(m.TailnetID)
+
+	return c.JSON(http.StatusOK, &tailcfg.TKAInitFinishResponse{})
+}
+
+func (h *TKAHandlers) Bootstrap(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(tailcfg.TKABootstrapRequest)
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	m, err := h.machine(ctx, req.NodeKey)
+	if err != nil {
+		return err
+	}
+
+	authority, err := h.repository.GetTailnetKeyAuthority(ctx, m.TailnetID)
+	if err != nil {
+		return logError(err)
+	}
+
+	resp := &tailcfg.TKABootstrapResponse{}
+	if authority != nil {
+		switch authority.State {
+		case domain.TailnetKeyAuthorityEnabled:
+			resp.GenesisAUM = authority.Genesis()
+		case domain.TailnetKeyAuthorityDisabled:
+			resp.DisablementSecret = authority.DisablementSecret
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *TKAHandlers) SyncOffer(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(tailcfg.TKASyncOfferRequest)
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	m, err := h.machine(ctx, req.NodeKey)
+	if err != nil {
+		return err
+	}
+
+	authority, err := h.enabledAuthority(ctx, m.TailnetID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := authority.SyncOffer(req.Head, req.Ancestors)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *TKAHandlers) SyncSend(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(tailcfg.TKASyncSendRequest)
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	m, err := h.machine(ctx, req.NodeKey)
+	if err != nil {
+		return err
+	}
+
+	var head string
+	var changed bool
+
+	err = h.updateAuthority(ctx, m.TailnetID, func(rp domain.Repository, authority *domain.TailnetKeyAuthority) error {
+		if err := requireEnabled(authority); err != nil {
+			return err
+		}
+
+		previous := authority.Head
+		if err := authority.Inform(req.MissingAUMs); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		head = authority.Head
+		changed = head != previous
+
+		if !changed {
+			return nil
+		}
+
+		return rp.SaveTailnetKeyAuthority(ctx, authority)
+	})
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		h.sessionManager.NotifyAll(m.TailnetID)
+	}
+
+	return c.JSON(http.StatusOK, &tailcfg.TKASyncSendResponse{Head: head})
+}
+
+func (h *TKAHandlers) Disable(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(tailcfg.TKADisableRequest)
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	m, err := h.machine(ctx, req.NodeKey)
+	if err != nil {
+		return err
+	}
+
+	err = h.updateAuthority(ctx, m.TailnetID, func(rp domain.Repository, authority *domain.TailnetKeyAuthority) error {
+		if err := requireEnabled(authority); err != nil {
+			return err
+		}
+
+		if !authority.ValidDisablement(req.DisablementSecret) {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid disablement secret")
+		}
+
+		authority.Disable(req.DisablementSecret)
+
+		return rp.SaveTailnetKeyAuthority(ctx, authority)
+	})
+	if err != nil {
+		return err
+	}
+
+	h.sessionManager.NotifyAll(m.TailnetID)
+
+	return c.JSON(http.StatusOK, &tailcfg.TKADisableResponse{})
+}
+
+func (h *TKAHandlers) Sign(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(tailcfg.TKASubmitSignatureRequest)
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	m, err := h.machine(ctx, req.NodeKey)
+	if err != nil {
+		return err
+	}
+
+	var sig tka.NodeKeySignature
+	if err := sig.Unserialize(req.Signature); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid node key signature")
+	}
+
+	var nodeKey key.NodePublic
+	if err := nodeKey.UnmarshalBinary(sig.Pubkey); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid node key signature")
+	}
+
+	// the signature is verified and stored with the authority locked, so that it can't be stored
+	// after a concurrent update removed the key signing it
+	err = h.updateAuthority(ctx, m.TailnetID, func(rp domain.Repository, authority *domain.TailnetKeyAuthority) error {
+		if err := requireEnabled(authority); err != nil {
+			return err
+		}
+
+		if !authority.NodeKeyAuthorized(nodeKey, req.Signature) {
+			return echo.NewHTTPError(http.StatusBadRequest, "node key signature is not authorized by a trusted key")
+		}
+
+		machines, err := rp.ListMachineByTailnet(ctx, m.TailnetID)
+		if err != nil {
+			return err
+		}
+
+		target := findMachineByNodeKey(machines, nodeKey)
+		if target == nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "node does not exist")
+		}
+
+		target.KeySignature = req.Signature
+		return rp.SaveMachine(ctx, target)
+	})
+	if err != nil {
+		return err
+	}
+
+	h.sessionManager.NotifyAll(m.TailnetID)
+
+	return c.JSON(http.StatusOK, &tailcfg.TKASubmitSignatureResponse{})
+}
+
+func (h *TKAHandlers) AffectedSigs(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := new(tailcfg.TKASignaturesUsingKeyRequest)
+	if err := c.Bind(req); err != nil {
+		return logError(err)
+	}
+
+	m, err := h.machine(ctx, req.NodeKey)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.enabledAuthority(ctx, m.TailnetID); err != nil {
+		return err
+	}
+
+	machines, err := h.repository.ListMachineByTailnet(ctx, m.TailnetID)
+	if err != nil {
+		return logError(err)
+	}
+
+	resp := &tailcfg.TKASignaturesUsingKeyResponse{Signatures: []tkatype.MarshaledSignature{}}
+	for _, n := range machines {
+		if len(n.KeySignature) == 0 {
+			continue
+		}
+
+		var sig tka.NodeKeySignature
+		if err := sig.Unserialize(n.KeySignature); err != nil {
+			continue
+		}
+
+		keyID, err := sig.UnverifiedAuthorizingKeyID()
+		if err == nil && bytes.Equal(keyID, req.KeyID) {
+			resp.Signatures = append(resp.Signatures, n.KeySignature)
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *TKAHandlers) machine(ctx context.Context, nodeKey key.NodePublic) (*domain.Machine, error) {
+	m, err := h.repository.GetMachineByKeys(ctx, h.machineKey.String(), nodeKey.String())
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if m == nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	return m, nil
+}
+
+// adminMachine returns the machine only when it is owned by a tailnet admin, tagged machines
+// cannot enable the tailnet lock.
+func (h *TKAHandlers) adminMachine(ctx context.Context, nodeKey key.NodePublic) (*domain.Machine, error) {
+	m, err := h.machine(ctx, nodeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.HasTags() || m.User.UserType != domain.UserTypePerson || !m.Tailnet.IAMPolicy.Get().GetRole(m.User).IsAdmin() {
+		return nil, echo.NewHTTPError(http.StatusForbidden, "only tailnet admins can initialize tailnet lock")
+	}
+
+	return m, nil
+}
+
+func (h *TKAHandlers) enabledAuthority(ctx context.Context, tailnetID uint64) (*domain.TailnetKeyAuthority, error) {
+	authority, err := h.repository.GetTailnetKeyAuthority(ctx, tailnetID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if err := requireEnabled(authority); err != nil {
+		return nil, err
+	}
+
+	return authority, nil
+}
+
+// updateAuthority runs the update in a transaction with the authority of the tailnet locked, so that concurrent
+// updates are applied one after the other on the latest chain. The authority is nil when the tailnet has none.
+// An HTTP error returned by the update rolls back the transaction and is returned as is.
+func (h *TKAHandlers) updateAuthority(ctx context.Context, tailnetID uint64, update func(rp domain.Repository, authority *domain.TailnetKeyAuthority) error) error {
+	err := h.repository.Transaction(func(rp domain.Repository) error {
+		authority, err := rp.GetTailnetKeyAuthorityForUpdate(ctx, tailnetID)
+		if err != nil {
+			return err
+		}
+		return update(rp, authority)
+	})
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return err
+	}
+
+	if err != nil {
+		return logError(err)
+	}
+
+	return nil
+}
+
+func requireEnabled(authority *domain.TailnetKeyAuthority) error {
+	if authority == nil || !authority.IsEnabled() {
+		return echo.NewHTTPError(http.StatusBadRequest, "tailnet lock is not enabled")
+	}
+	return nil
+}
+
+func findMachineByNodeKey(machines []domain.Machine, nodeKey key.NodePublic) *domain.Machine {
+	for _, m := range machines {
+		if m.NodeKey == nodeKey.String() {
+			return &m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/database"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/util"
+	"github.com/jsiebens/ionscale/pkg/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"tailscale.com/tailcfg"
+	"tailscale.com/tka"
+	"tailscale.com/types/key"
+	"tailscale.com/types/tkatype"
+	"testing"
+	"time"
+)
+
+func TestTKAHandlers(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+	sessionManager := core.NewPollMapSessionManager()
+
+	tailnet := &domain.Tailnet{
+		ID:        util.NextID(),
+		Name:      "example",
+		IAMPolicy: domain.NewHuJSON(&domain.IAMPolicy{Roles: map[string]domain.UserRole{"john@example.com": domain.UserRoleAdmin}}),
+		ACLPolicy: domain.NewHuJSON(&domain.ACLPolicy{ACLPolicy: *defaults.DefaultACLPolicy()}),
+	}
+	require.NoError(t, repository.SaveTailnet(ctx, tailnet))
+
+	account, _, err := repository.GetOrCreateAccount(ctx, "john", "john@example.com")
+	require.NoError(t, err)
+	admin, _, err := repository.GetOrCreateUserWithAccount(ctx, tailnet, account)
+	require.NoError(t, err)
+	serviceUser, _, err := repository.GetOrCreateServiceUser(ctx, tailnet)
+	require.NoError(t, err)
+
+	laptop := createTKATestMachine(t, repository, tailnet, admin, "laptop")
+	server := createTKATestMachine(t, repository, tailnet, serviceUser, "server", "tag:server")
+
+	signer := key.NewNLPrivate()
+	disablementSecret := []byte("disablement secret")
+
+	nodeStorage := &tka.Mem{}
+	nodeAuthority, genesis, err := tka.Create(nodeStorage, tka.State{
+		Keys:               []tka.Key{{Kind: tka.Key25519, Public: signer.Public().Verifier(), Votes: 1}},
+		DisablementSecrets: [][]byte{tka.DisablementKDF(disablementSecret)},
+	}, signer)
+	require.NoError(t, err)
+
+	storedAuthority := func() *domain.TailnetKeyAuthority {
+		authority, err := repository.GetTailnetKeyAuthority(ctx, tailnet.ID)
+		require.NoError(t, err)
+		return authority
+	}
+
+	t.Run("init begin", func(t *testing.T) {
+		// tagged machines can't initialize the lock
+		err := tkaRequest(server.handlers(sessionManager, repository).InitBegin, &tailcfg.TKAInitBeginRequest{NodeKey: server.nodeKey, GenesisAUM: genesis.Serialize()}, nil)
+		assert.Equal(t, http.StatusForbidden, httpStatus(err))
+		assert.Nil(t, storedAuthority())
+
+		resp := &tailcfg.TKAInitBeginResponse{}
+		require.NoError(t, tkaRequest(laptop.handlers(sessionManager, repository).InitBegin, &tailcfg.TKAInitBeginRequest{NodeKey: laptop.nodeKey, GenesisAUM: genesis.Serialize()}, resp))
+
+		assert.ElementsMatch(t, []tailcfg.TKASignInfo{
+			{NodeID: tailcfg.NodeID(laptop.ID), NodePublic: laptop.nodeKey},
+			{NodeID: tailcfg.NodeID(server.ID), NodePublic: server.nodeKey},
+		}, resp.NeedSignatures)
+		assert.Equal(t, domain.TailnetKeyAuthorityPending, storedAuthority().State)
+	})
+
+	t.Run("init finish", func(t *testing.T) {
+		// signatures by an untrusted key are rejected, the initialization stays pending
+		err := tkaRequest(laptop.handlers(sessionManager, repository).InitFinish, &tailcfg.TKAInitFinishRequest{
+			NodeKey: laptop.nodeKey,
+			Signatures: map[tailcfg.NodeID]tkatype.MarshaledSignature{
+				tailcfg.NodeID(laptop.ID): signNodeKey(t, signer, laptop.nodeKey),
+				tailcfg.NodeID(server.ID): signNodeKey(t, key.NewNLPrivate(), server.nodeKey),
+			},
+		}, nil)
+		assert.Equal(t, http.StatusBadRequest, httpStatus(err))
+		assert.Equal(t, domain.TailnetKeyAuthorityPending, storedAuthority().State)
+
+		require.NoError(t, tkaRequest(laptop.handlers(sessionManager, repository).InitFinish, &tailcfg.TKAInitFinishRequest{
+			NodeKey: laptop.nodeKey,
+			Signatures: map[tailcfg.NodeID]tkatype.MarshaledSignature{
+				tailcfg.NodeID(laptop.ID): signNodeKey(t, signer, laptop.nodeKey),
+				tailcfg.NodeID(server.ID): signNodeKey(t, signer, server.nodeKey),
+			},
+		}, &tailcfg.TKAInitFinishResponse{}))
+
+		authority := storedAuthority()
+		assert.True(t, authority.IsEnabled())
+		assert.Equal(t, tkaHead(t, nodeAuthority), authority.Head)
+
+		for _, m := range []*tkaTestMachine{laptop, server} {
+			stored, err := repository.GetMachine(ctx, m.ID)
+			require.NoError(t, err)
+			assert.True(t, authority.IsTrusted(stored))
+		}
+	})
+
+	t.Run("sync send", func(t *testing.T) {
+		updater := nodeAuthority.NewUpdater(signer)
+		require.NoError(t, updater.AddKey(tka.Key{Kind: tka.Key25519, Public: key.NewNLPrivate().Public().Verifier(), Votes: 1}))
+		updates, err := updater.Finalize(nodeStorage)
+		require.NoError(t, err)
+		require.NoError(t, nodeAuthority.Inform(nodeStorage, updates))
+
+		resp := &tailcfg.TKASyncSendResponse{}
+		require.NoError(t, tkaRequest(laptop.handlers(sessionManager, repository).SyncSend, &tailcfg.TKASyncSendRequest{NodeKey: laptop.nodeKey, MissingAUMs: toMarshaledAUMs(updates)}, resp))
+
+		assert.Equal(t, tkaHead(t, nodeAuthority), resp.Head)
+		assert.Equal(t, tkaHead(t, nodeAuthority), storedAuthority().Head)
+		assert.Equal(t, []int{0, 1}, aumIndexes(storedAuthority()))
+
+		// updates not signed by a trusted key are rejected
+		rogueStorage := &tka.Mem{}
+		rogueAuthority, err := tka.Bootstrap(rogueStorage, genesis)
+		require.NoError(t, err)
+		rogueUpdater := rogueAuthority.NewUpdater(key.NewNLPrivate())
+		require.NoError(t, rogueUpdater.AddKey(tka.Key{Kind: tka.Key25519, Public: key.NewNLPrivate().Public().Verifier(), Votes: 1}))
+		rogueUpdates, err := rogueUpdater.Finalize(rogueStorage)
+		require.NoError(t, err)
+
+		err = tkaRequest(server.handlers(sessionManager, repository).SyncSend, &tailcfg.TKASyncSendRequest{NodeKey: server.nodeKey, MissingAUMs: toMarshaledAUMs(rogueUpdates)}, nil)
+		assert.Equal(t, http.StatusBadRequest, httpStatus(err))
+		assert.Equal(t, tkaHead(t, nodeAuthority), storedAuthority().Head)
+	})
+
+	t.Run("concurrent sync send", func(t *testing.T) {
+		updater := nodeAuthority.NewUpdater(signer)
+		require.NoError(t, updater.AddKey(tka.Key{Kind: tka.Key25519, Public: key.NewNLPrivate().Public().Verifier(), Votes: 1}))
+		updates, err := updater.Finalize(nodeStorage)
+		require.NoError(t, err)
+		require.NoError(t, nodeAuthority.Inform(nodeStorage, updates))
+
+		// every node relays the same update, it is applied once on the latest chain
+		var wg sync.WaitGroup
+		errs := make(chan error, 2*5)
+		for i := 0; i < 5; i++ {
+			for _, m := range []*tkaTestMachine{laptop, server} {
+				wg.Add(1)
+				go func(m *tkaTestMachine) {
+					defer wg.Done()
+					errs <- tkaRequest(m.handlers(sessionManager, repository).SyncSend, &tailcfg.TKASyncSendRequest{NodeKey: m.nodeKey, MissingAUMs: toMarshaledAUMs(updates)}, &tailcfg.TKASyncSendResponse{})
+				}(m)
+			}
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, tkaHead(t, nodeAuthority), storedAuthority().Head)
+		assert.Equal(t, []int{0, 1, 2}, aumIndexes(storedAuthority()))
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		err := tkaRequest(server.handlers(sessionManager, repository).Disable, &tailcfg.TKADisableRequest{NodeKey: server.nodeKey, DisablementSecret: []byte("wrong")}, nil)
+		assert.Equal(t, http.StatusBadRequest, httpStatus(err))
+		assert.True(t, storedAuthority().IsEnabled())
+
+		require.NoError(t, tkaRequest(server.handlers(sessionManager, repository).Disable, &tailcfg.TKADisableRequest{NodeKey: server.nodeKey, DisablementSecret: disablementSecret}, &tailcfg.TKADisableResponse{}))
+		assert.Equal(t, domain.TailnetKeyAuthorityDisabled, storedAuthority().State)
+
+		// updates are rejected once the lock is disabled
+		err = tkaRequest(laptop.handlers(sessionManager, repository).SyncSend, &tailcfg.TKASyncSendRequest{NodeKey: laptop.nodeKey}, nil)
+		assert.Equal(t, http.StatusBadRequest, httpStatus(err))
+	})
+}
+
+type tkaTestMachine struct {
+	*domain.Machine
+	machineKey key.MachinePublic
+	nodeKey    key.NodePublic
+}
+
+func (m *tkaTestMachine) handlers(sessionManager core.PollMapSessionManager, repository domain.Repository) *TKAHandlers {
+	return NewTKAHandlers(m.machineKey, sessionManager, repository)
+}
+
+func createTKATestMachine(t *testing.T, repository domain.Repository, tailnet *domain.Tailnet, user *domain.User, name string, tags ...string) *tkaTestMachine {
+	machineKey := key.NewMachine().Public()
+	nodeKey := key.NewNode().Public()
+
+	m := &domain.Machine{
+		ID:         util.NextID(),
+		Name:       name,
+		MachineKey: machineKey.String(),
+		NodeKey:    nodeKey.String(),
+		Authorized: true,
+		Tags:       tags,
+		CreatedAt:  time.Now().UTC(),
+		ExpiresAt:  time.Now().UTC().Add(24 * time.Hour),
+		TailnetID:  tailnet.ID,
+		UserID:     user.ID,
+	}
+	require.NoError(t, repository.SaveMachine(context.Background(), m))
+
+	return &tkaTestMachine{Machine: m, machineKey: machineKey, nodeKey: nodeKey}
+}
+
+// tkaRequest calls the handler with the request as JSON body, and decodes the response when the request succeeds.
+func tkaRequest(handler echo.HandlerFunc, req any, resp any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", bytes.NewReader(body))
+	r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	if err := handler(echo.New().NewContext(r, rec)); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rec.Body.Bytes(), resp)
+}
+
+func httpStatus(err error) int {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return 0
+}
+
+func aumIndexes(authority *domain.TailnetKeyAuthority) []int {
+	var result []int
+	for _, a := range authority.AUMs {
+		result = append(result, a.Idx)
+	}
+	return result
+}
+
+func tkaHead(t *testing.T, a *tka.Authority) string {
+	head, err := a.Head().MarshalText()
+	require.NoError(t, err)
+	return string(head)
+}
+
+func signNodeKey(t *testing.T, signer key.NLPrivate, nodeKey key.NodePublic) []byte {
+	pub, err := nodeKey.MarshalBinary()
+	require.NoError(t, err)
+
+	sig := tka.NodeKeySignature{SigKind: tka.SigDirect, KeyID: signer.KeyID(), Pubkey: pub}
+	sig.Signature, err = signer.SignNKS(sig.SigHash())
+	require.NoError(t, err)
+
+	return sig.Serialize()
+}
+
+func toMarshaledAUMs(aums []tka.AUM) []tkatype.MarshaledAUM {
+	var result []tkatype.MarshaledAUM
+	for _, a := range aums {
+		result = append(result, a.Serialize())
+	}
+	return result
+}
+
+func openTestRepository(t *testing.T) domain.Repository {
+	t.Setenv("IONSCALE_MACHINE_ID", "1")
+
+	_, repository, err := database.OpenDB(&config.Database{
+		Type:         "sqlite",
+		Url:          t.TempDir() + "/ionscale.db?_pragma=busy_timeout(5000)",
+		MaxOpenConns: 1,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	return repository
+}
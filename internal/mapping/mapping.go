@@ -1,7 +1,6 @@
 package mapping
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/netip"
 	"slices"
@@ -16,20 +15,8 @@ import (
 	"tailscale.com/types/key"
 )
 
-func CopyViaJson[F any, T any](f F, t T) error {
-	raw, err := json.Marshal(f)
-	if err != nil {
-		return err
-	}
-
-	if err := json.Unmarshal(raw, t); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func ToDNSConfig(m *domain.Machine, tailnet *domain.Tailnet, c *domain.DNSConfig) *tailcfg.DNSConfig {
+func ToDNSConfig(m *domain.Machine, peers []domain.Machine, tailnet *domain.Tailnet, tailnetDNSConfig *domain.DNSConfig) *tailcfg.DNSConfig {
+	c := tailnetDNSConfig.ForMachine(m, tailnet.ACLPolicy.Get())
 	certsEnabled := c.HttpsCertsEnabled && config.DNSProviderConfigured()
 
 	sanitizeTailnetName := domain.SanitizeTailnetName(tailnet.Name)
@@ -63,8 +50,14 @@ func ToDNSConfig(m *domain.Machine, tailnet *domain.Tailnet, c *domain.DNSConfig
 		dnsConfig.FallbackResolvers = resolvers
 	}
 
-	if len(c.ExtraRecords) != 0 {
-		dnsConfig.ExtraRecords = c.ExtraRecords
+	extraRecords := append([]tailcfg.DNSRecord{}, c.ExtraRecords...)
+	extraRecords = append(extraRecords, m.AliasRecords()...)
+	for _, p := range peers {
+		extraRecords = append(extraRecords, p.AliasRecords()...)
+	}
+
+	if len(extraRecords) != 0 {
+		dnsConfig.ExtraRecords = extraRecords
 	}
 
 	if len(c.Routes) != 0 || certsEnabled {
@@ -89,6 +82,25 @@ func ToDNSConfig(m *domain.Machine, tailnet *domain.Tailnet, c *domain.DNSConfig
 	return dnsConfig
 }
 
+// withSharedDomains lets MagicDNS resolve the names of the shared machines, which are part of the domain of their own tailnet.
+func withSharedDomains(c *tailcfg.DNSConfig, sharedPeers []domain.Machine) *tailcfg.DNSConfig {
+	if !c.Proxied {
+		return c
+	}
+
+	for _, p := range sharedPeers {
+		d := fmt.Sprintf("%s.%s", domain.SanitizeTailnetName(p.Tailnet.Name), config.MagicDNSSuffix())
+		if c.Routes == nil {
+			c.Routes = map[string][]*dnstype.Resolver{}
+		}
+		if _, ok := c.Routes[d]; !ok {
+			c.Routes[d] = nil
+		}
+	}
+
+	return c
+}
+
 func ToNode(capVer tailcfg.CapabilityVersion, m *domain.Machine, tailnet *domain.Tailnet, taggedDevicesUser *domain.User, peer bool, connected bool, routeFilter func(m *domain.Machine) []netip.Prefix) (*tailcfg.Node, *tailcfg.UserProfile, error) {
 	role := tailnet.IAMPolicy.Get().GetRole(m.User)
 
@@ -135,8 +147,19 @@ func ToNode(capVer tailcfg.CapabilityVersion, m *domain.Machine, tailnet *domain
 		allowedIPs = append(allowedIPs, ipv6)
 	}
 
+	var primaryRoutes []netip.Prefix
 	if connected {
-		allowedIPs = append(allowedIPs, routeFilter(m)...)
+		primaryRoutes = routeFilter(m)
+		allowedIPs = append(allowedIPs, primaryRoutes...)
+	}
+
+	// the machine itself knows all of its enabled routes, also when another router is the primary
+	if !peer {
+		for _, r := range slices.Concat(m.AllowIPs, m.AutoAllowIPs) {
+			if r.Bits() != 0 && !slices.Contains(allowedIPs, r) {
+				allowedIPs = append(allowedIPs, r)
+			}
+		}
 	}
 
 	if m.IsAllowedExitNode() {
@@ -162,32 +185,29 @@ func ToNode(capVer tailcfg.CapabilityVersion, m *domain.Machine, tailnet *domain
 	}
 
 	n := tailcfg.Node{
-		ID:         tailcfg.NodeID(m.ID),
-		StableID:   tailcfg.StableNodeID(strconv.FormatUint(m.ID, 10)),
-		Name:       fmt.Sprintf("%s.%s.%s.", name, sanitizedTailnetName, config.MagicDNSSuffix()),
-		Key:        *nKey,
-		Machine:    *mKey,
-		DiscoKey:   discoKey,
-		Addresses:  addrs,
-		AllowedIPs: allowedIPs,
-		Endpoints:  endpoints,
-		DERP:       derp,
+		ID:            tailcfg.NodeID(m.ID),
+		StableID:      tailcfg.StableNodeID(strconv.FormatUint(m.ID, 10)),
+		Name:          fmt.Sprintf("%s.%s.%s.", name, sanitizedTailnetName, config.MagicDNSSuffix()),
+		Key:           *nKey,
+		Machine:       *mKey,
+		DiscoKey:      discoKey,
+		Addresses:     addrs,
+		AllowedIPs:    allowedIPs,
+		PrimaryRoutes: primaryRoutes,
+		Endpoints:     endpoints,
+		DERP:          derp,
 
 		Hostinfo: hostInfo.View(),
 		Created:  m.CreatedAt.UTC(),
 
 		MachineAuthorized: m.Authorized,
 		User:              tailcfg.UserID(m.UserID),
+		KeySignature:      m.KeySignature,
 	}
 
 	if !peer {
-		var capabilities []tailcfg.NodeCapability
-		capMap := make(tailcfg.NodeCapMap)
-
-		for _, c := range tailnet.ACLPolicy.Get().NodeCapabilities(m) {
-			capabilities = append(capabilities, c)
-			capMap[c] = []tailcfg.RawMessage{}
-		}
+		capabilities := tailnet.ACLPolicy.Get().NodeCapabilities(m)
+		capMap := tailnet.ACLPolicy.Get().NodeCapMap(m)
 
 		if !m.HasTags() && role == domain.UserRoleAdmin {
 			capabilities = append(capabilities, tailcfg.CapabilityAdmin)
@@ -209,9 +229,16 @@ func ToNode(capVer tailcfg.CapabilityVersion, m *domain.Machine, tailnet *domain
 			capMap[tailcfg.CapabilityHTTPS] = []tailcfg.RawMessage{}
 		}
 
+		capabilities = append(capabilities, tailcfg.CapabilityTailnetLock)
+		capMap[tailcfg.CapabilityTailnetLock] = []tailcfg.RawMessage{}
+
+		if connectors := tailnet.ACLPolicy.Get().AppConnectorsFor(m); len(connectors) != 0 {
+			capMap[domain.AppConnectorCapability] = append(capMap[domain.AppConnectorCapability], connectors...)
+		}
+
 		// ionscale has no support for Funnel yet, so remove Funnel attribute if set via ACL policy
 		{
-			slices.DeleteFunc(capabilities, func(c tailcfg.NodeCapability) bool { return c == tailcfg.NodeAttrFunnel })
+			capabilities = slices.DeleteFunc(capabilities, func(c tailcfg.NodeCapability) bool { return c == tailcfg.NodeAttrFunnel })
 			delete(capMap, tailcfg.NodeAttrFunnel)
 		}
 
@@ -5,6 +5,7 @@ import (
 	"github.com/jsiebens/ionscale/internal/core"
 	"github.com/jsiebens/ionscale/internal/domain"
 	"net/netip"
+	"slices"
 	"sync"
 	"tailscale.com/tailcfg"
 	"tailscale.com/types/opt"
@@ -66,7 +67,31 @@ func (h *PollNetMapper) CreateMapResponse(ctx context.Context, delta bool) (*Map
 		return nil, err
 	}
 
-	prc := &primaryRoutesCollector{flagged: map[netip.Prefix]bool{}}
+	keyAuthority, err := h.repository.GetTailnetKeyAuthority(ctx, m.TailnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// all machines of the tailnet take part in the election of the primary routers, even when the peers are omitted
+	tailnetPeers, err := h.repository.ListMachinePeers(ctx, m.TailnetID, m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidatePeers domain.Machines
+	if !h.req.OmitPeers {
+		candidatePeers = tailnetPeers
+	}
+
+	var sharedPeers, shareRecipients domain.Machines
+	if !h.req.OmitPeers {
+		sharedPeers, shareRecipients, err = h.loadSharedPeers(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prc := h.newPrimaryRoutesCollector(m, tailnetPeers, keyAuthority)
 
 	node, user, err := ToNode(h.req.Version, m, &tailnet, serviceUser, false, true, prc.filter)
 	if err != nil {
@@ -78,17 +103,18 @@ func (h *PollNetMapper) CreateMapResponse(ctx context.Context, delta bool) (*Map
 	var removedPeers []tailcfg.NodeID
 	var filterRules = make([]tailcfg.FilterRule, 0)
 	var sshPolicy *tailcfg.SSHPolicy
+	var validPeers []domain.Machine
 	syncedPeerIDs := map[uint64]bool{}
 
 	if !h.req.OmitPeers {
-		candidatePeers, err := h.repository.ListMachinePeers(ctx, m.TailnetID, m.ID)
-		if err != nil {
-			return nil, err
-		}
-
 		syncedUserIDs := map[tailcfg.UserID]bool{user.ID: true}
 
 		for _, peer := range candidatePeers {
+			// with Tailnet Lock, only peers with a node key signed by a trusted key are distributed
+			if !keyAuthority.IsTrusted(&peer) {
+				continue
+			}
+
 			if policies.IsValidPeer(m, &peer) || policies.IsValidPeer(&peer, m) {
 				isConnected := h.sessionManager.HasSession(peer.TailnetID, peer.ID)
 
@@ -97,6 +123,7 @@ func (h *PollNetMapper) CreateMapResponse(ctx context.Context, delta bool) (*Map
 					return nil, err
 				}
 				changedPeers = append(changedPeers, n)
+				validPeers = append(validPeers, peer)
 				syncedPeerIDs[peer.ID] = true
 				delete(h.prevSyncedPeerIDs, peer.ID)
 
@@ -107,14 +134,50 @@ func (h *PollNetMapper) CreateMapResponse(ctx context.Context, delta bool) (*Map
 			}
 		}
 
+		// shared machines and the recipients of a shared machine are peers regardless of the policies,
+		// but without their routes
+		for _, peer := range slices.Concat(sharedPeers, shareRecipients) {
+			if syncedPeerIDs[peer.ID] {
+				continue
+			}
+
+			if !keyAuthority.IsTrusted(&peer) {
+				continue
+			}
+
+			peer.AllowIPs = nil
+			peer.AutoAllowIPs = nil
+
+			isConnected := h.sessionManager.HasSession(peer.TailnetID, peer.ID)
+
+			n, u, err := ToNode(h.req.Version, &peer, &tailnet, serviceUser, true, isConnected, noRoutes)
+			if err != nil {
+				return nil, err
+			}
+			changedPeers = append(changedPeers, n)
+			syncedPeerIDs[peer.ID] = true
+			delete(h.prevSyncedPeerIDs, peer.ID)
+
+			if _, ok := syncedUserIDs[u.ID]; !ok {
+				users = append(users, *u)
+				syncedUserIDs[u.ID] = true
+			}
+		}
+
 		for p, _ := range h.prevSyncedPeerIDs {
 			removedPeers = append(removedPeers, tailcfg.NodeID(p))
 		}
 
 		filterRules = policies.BuildFilterRules(candidatePeers, m)
 
+		// only the recipients can connect to a shared machine, not the other way around
+		if rule, ok := domain.ShareFilterRule(shareRecipients); ok {
+			filterRules = append(filterRules, rule)
+		}
+
 		if tailnet.SSHEnabled && hostinfo.TailscaleSSHEnabled() {
-			sshPolicy = policies.BuildSSHPolicy(candidatePeers, m)
+			isConnected := func(r *domain.Machine) bool { return h.sessionManager.HasSession(r.TailnetID, r.ID) }
+			sshPolicy = policies.BuildSSHPolicy(candidatePeers, m, isConnected)
 		}
 	}
 
@@ -125,7 +188,7 @@ func (h *PollNetMapper) CreateMapResponse(ctx context.Context, delta bool) (*Map
 		mapResponse = tailcfg.MapResponse{
 			KeepAlive:       false,
 			Node:            node,
-			DNSConfig:       ToDNSConfig(m, &m.Tailnet, &dnsConfig),
+			DNSConfig:       withSharedDomains(ToDNSConfig(m, validPeers, &m.Tailnet, &dnsConfig), sharedPeers),
 			PacketFilter:    filterRules,
 			SSHPolicy:       sshPolicy,
 			DERPMap:         &derpMap.DERPMap,
@@ -141,7 +204,7 @@ func (h *PollNetMapper) CreateMapResponse(ctx context.Context, delta bool) (*Map
 	} else {
 		mapResponse = tailcfg.MapResponse{
 			Node:            node,
-			DNSConfig:       ToDNSConfig(m, &m.Tailnet, &dnsConfig),
+			DNSConfig:       withSharedDomains(ToDNSConfig(m, validPeers, &m.Tailnet, &dnsConfig), sharedPeers),
 			PacketFilter:    filterRules,
 			SSHPolicy:       sshPolicy,
 			Domain:          domain.SanitizeTailnetName(m.Tailnet.Name),
@@ -157,6 +220,10 @@ func (h *PollNetMapper) CreateMapResponse(ctx context.Context, delta bool) (*Map
 		}
 	}
 
+	if keyAuthority != nil {
+		mapResponse.TKAInfo = keyAuthority.Info()
+	}
+
 	if h.req.OmitPeers {
 		mapResponse.PeersChanged = nil
 		mapResponse.PeersRemoved = nil
@@ -169,22 +236,78 @@ func (h *PollNetMapper) CreateMapResponse(ctx context.Context, delta bool) (*Map
 	return &MapResponse{MapResponse: mapResponse, PacketFilter: filterRules}, nil
 }
 
+// loadSharedPeers returns the machines shared with the machine, and the machines the machine is shared with.
+func (h *PollNetMapper) loadSharedPeers(ctx context.Context, m *domain.Machine) (domain.Machines, domain.Machines, error) {
+	var shared domain.Machines
+	var recipients domain.Machines
+
+	received, err := h.repository.ListMachineSharesByRecipient(ctx, m.TailnetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, s := range received {
+		if s.IsRecipient(m) && !slices.ContainsFunc(shared, func(x domain.Machine) bool { return x.ID == s.MachineID }) {
+			shared = append(shared, s.Machine)
+		}
+	}
+
+	shares, err := h.repository.ListMachineSharesByMachine(ctx, m.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	machinesByTailnet := map[uint64]domain.Machines{}
+	for _, s := range shares {
+		if !s.IsAccepted() {
+			continue
+		}
+
+		machines, ok := machinesByTailnet[s.RecipientTailnetID]
+		if !ok {
+			machines, err = h.repository.ListMachineByTailnet(ctx, s.RecipientTailnetID)
+			if err != nil {
+				return nil, nil, err
+			}
+			machinesByTailnet[s.RecipientTailnetID] = machines
+		}
+
+		for _, r := range s.Recipients(machines) {
+			if !slices.ContainsFunc(recipients, func(x domain.Machine) bool { return x.ID == r.ID }) {
+				recipients = append(recipients, r)
+			}
+		}
+	}
+
+	return shared, recipients, nil
+}
+
+func noRoutes(*domain.Machine) []netip.Prefix {
+	return nil
+}
+
+// newPrimaryRoutesCollector looks up the primary router of every route served in the tailnet.
+// The candidates are the same for every machine of the tailnet, so that all of them agree on the primary routers.
+func (h *PollNetMapper) newPrimaryRoutesCollector(m *domain.Machine, peers domain.Machines, keyAuthority *domain.TailnetKeyAuthority) *primaryRoutesCollector {
+	machines := append(domain.Machines{*m}, peers...)
+
+	primaries := map[netip.Prefix]uint64{}
+	for r, ids := range machines.RouteCandidates(keyAuthority) {
+		primaries[r] = h.sessionManager.PrimaryRouter(m.TailnetID, r, ids)
+	}
+
+	return &primaryRoutesCollector{primaries: primaries}
+}
+
 type primaryRoutesCollector struct {
-	flagged map[netip.Prefix]bool
+	primaries map[netip.Prefix]uint64
 }
 
 func (p *primaryRoutesCollector) filter(m *domain.Machine) []netip.Prefix {
 	var result []netip.Prefix
-	for _, r := range m.AllowIPs {
-		if _, ok := p.flagged[r]; r.Bits() != 0 && !ok {
-			result = append(result, r)
-			p.flagged[r] = true
-		}
-	}
-	for _, r := range m.AutoAllowIPs {
-		if _, ok := p.flagged[r]; r.Bits() != 0 && !ok {
+	for _, r := range slices.Concat(m.AllowIPs, m.AutoAllowIPs) {
+		if r.Bits() != 0 && p.primaries[r] == m.ID && !slices.Contains(result, r) {
 			result = append(result, r)
-			p.flagged[r] = true
 		}
 	}
 	return result
@@ -0,0 +1,126 @@
+package mapping
+
+import (
+	"context"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/database"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/util"
+	"github.com/jsiebens/ionscale/pkg/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/netip"
+	"tailscale.com/tailcfg"
+	"tailscale.com/tka"
+	"tailscale.com/types/key"
+	"testing"
+	"time"
+)
+
+func TestPollNetMapper_OnlySignedPeersWithTailnetLock(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+	sessionManager := core.NewPollMapSessionManager()
+
+	tailnet := &domain.Tailnet{
+		ID:        util.NextID(),
+		Name:      "example",
+		IAMPolicy: domain.NewHuJSON(&domain.IAMPolicy{}),
+		ACLPolicy: domain.NewHuJSON(&domain.ACLPolicy{ACLPolicy: *defaults.DefaultACLPolicy()}),
+	}
+	require.NoError(t, repository.SaveTailnet(ctx, tailnet))
+
+	signer := key.NewNLPrivate()
+	_, genesis, err := tka.Create(&tka.Mem{}, tka.State{
+		Keys:               []tka.Key{{Kind: tka.Key25519, Public: signer.Public().Verifier(), Votes: 1}},
+		DisablementSecrets: [][]byte{tka.DisablementKDF([]byte("secret"))},
+	}, signer)
+	require.NoError(t, err)
+
+	self := createTestMachine(t, repository, tailnet, "self", "100.64.0.1", "fd7a:115c:a1e0::1", signer)
+	signed := createTestMachine(t, repository, tailnet, "signed", "100.64.0.2", "fd7a:115c:a1e0::2", signer)
+	unsigned := createTestMachine(t, repository, tailnet, "unsigned", "100.64.0.3", "fd7a:115c:a1e0::3", key.NewNLPrivate())
+
+	peerIDs := func() []tailcfg.NodeID {
+		resp, err := NewPollNetMapper(&tailcfg.MapRequest{Version: 74}, self.ID, repository, sessionManager).CreateMapResponse(ctx, false)
+		require.NoError(t, err)
+
+		var ids []tailcfg.NodeID
+		for _, p := range resp.Peers {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	// without Tailnet Lock, all peers are distributed
+	assert.ElementsMatch(t, []tailcfg.NodeID{tailcfg.NodeID(signed.ID), tailcfg.NodeID(unsigned.ID)}, peerIDs())
+
+	authority, err := domain.NewTailnetKeyAuthority(tailnet.ID, self.ID, genesis.Serialize())
+	require.NoError(t, err)
+	authority.State = domain.TailnetKeyAuthorityEnabled
+	require.NoError(t, repository.SaveTailnetKeyAuthority(ctx, authority))
+
+	// only peers signed by a trusted key are distributed
+	assert.ElementsMatch(t, []tailcfg.NodeID{tailcfg.NodeID(signed.ID)}, peerIDs())
+
+	authority.Disable([]byte("secret"))
+	require.NoError(t, repository.SaveTailnetKeyAuthority(ctx, authority))
+
+	assert.ElementsMatch(t, []tailcfg.NodeID{tailcfg.NodeID(signed.ID), tailcfg.NodeID(unsigned.ID)}, peerIDs())
+}
+
+func createTestMachine(t *testing.T, repository domain.Repository, tailnet *domain.Tailnet, name string, ipv4 string, ipv6 string, signer key.NLPrivate) *domain.Machine {
+	user, _, err := repository.GetOrCreateServiceUser(context.Background(), tailnet)
+	require.NoError(t, err)
+
+	nodeKey := key.NewNode().Public()
+
+	m := &domain.Machine{
+		ID:           util.NextID(),
+		Name:         name,
+		MachineKey:   key.NewMachine().Public().String(),
+		NodeKey:      nodeKey.String(),
+		KeySignature: signNodeKey(t, signer, nodeKey),
+		IPv4:         testIP(ipv4),
+		IPv6:         testIP(ipv6),
+		Authorized:   true,
+		Tags:         domain.Tags{"tag:server"},
+		CreatedAt:    time.Now().UTC(),
+		ExpiresAt:    time.Now().UTC().Add(24 * time.Hour),
+		TailnetID:    tailnet.ID,
+		UserID:       user.ID,
+	}
+	require.NoError(t, repository.SaveMachine(context.Background(), m))
+	return m
+}
+
+func signNodeKey(t *testing.T, signer key.NLPrivate, nodeKey key.NodePublic) []byte {
+	pub, err := nodeKey.MarshalBinary()
+	require.NoError(t, err)
+
+	sig := tka.NodeKeySignature{SigKind: tka.SigDirect, KeyID: signer.KeyID(), Pubkey: pub}
+	sig.Signature, err = signer.SignNKS(sig.SigHash())
+	require.NoError(t, err)
+
+	return sig.Serialize()
+}
+
+func testIP(s string) domain.IP {
+	ip := netip.MustParseAddr(s)
+	return domain.IP{Addr: &ip}
+}
+
+func openTestRepository(t *testing.T) domain.Repository {
+	t.Setenv("IONSCALE_MACHINE_ID", "1")
+
+	_, repository, err := database.OpenDB(&config.Database{
+		Type:         "sqlite",
+		Url:          t.TempDir() + "/ionscale.db?_pragma=busy_timeout(5000)",
+		MaxOpenConns: 1,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	return repository
+}
@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/jsiebens/ionscale/internal/config"
+	"strings"
+	"time"
+)
+
+const KeyExpiryEvent = "machine.key_expiry"
+
+// Message is a notification about a machine, delivered to every configured channel.
+type Message struct {
+	Event     string    `json:"event"`
+	Subject   string    `json:"subject"`
+	Text      string    `json:"text"`
+	TailnetID uint64    `json:"tailnet_id"`
+	Tailnet   string    `json:"tailnet"`
+	MachineID uint64    `json:"machine_id"`
+	Machine   string    `json:"machine"`
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type Channel interface {
+	// ID identifies the channel across restarts, to keep track of the messages delivered through it.
+	ID() string
+	Send(ctx context.Context, m *Message) error
+}
+
+// Notifier holds the configured channels, messages are delivered to each of them.
+type Notifier interface {
+	Enabled() bool
+	Channels() []Channel
+}
+
+func NewNotifier(c config.Notifications) (Notifier, error) {
+	var channels []Channel
+	for _, ch := range c.Channels {
+		switch ch.Type {
+		case config.NotificationChannelSMTP:
+			channels = append(channels, &smtpChannel{config: ch})
+		case config.NotificationChannelWebhook:
+			channels = append(channels, &webhookChannel{url: ch.Url})
+		case config.NotificationChannelSlack:
+			channels = append(channels, &slackChannel{url: ch.Url})
+		default:
+			return nil, fmt.Errorf("unknown notification channel: %s", ch.Type)
+		}
+	}
+	return &notifier{channels: channels}, nil
+}
+
+type notifier struct {
+	channels []Channel
+}
+
+func (n *notifier) Enabled() bool {
+	return len(n.channels) != 0
+}
+
+func (n *notifier) Channels() []Channel {
+	return n.channels
+}
+
+// channelID derives the id of a channel from its destination, hashed as webhook urls may contain secrets.
+func channelID(typ string, destination ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(destination, "\n")))
+	return typ + ":" + hex.EncodeToString(sum[:8])
+}
+
+func NewKeyExpiryMessage(tailnetID uint64, tailnet string, machineID uint64, machine string, owner string, expiresAt time.Time) *Message {
+	return &Message{
+		Event:     KeyExpiryEvent,
+		Subject:   fmt.Sprintf("Key of machine %s expires on %s", machine, expiresAt.UTC().Format(time.RFC1123)),
+		Text:      fmt.Sprintf("The key of machine %s (owner %s) in tailnet %s expires on %s. Re-authenticate the machine to keep it connected.", machine, owner, tailnet, expiresAt.UTC().Format(time.RFC1123)),
+		TailnetID: tailnetID,
+		Tailnet:   tailnet,
+		MachineID: machineID,
+		Machine:   machine,
+		Owner:     owner,
+		ExpiresAt: expiresAt.UTC(),
+	}
+}
@@ -0,0 +1,232 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewNotifier(t *testing.T) {
+	n, err := NewNotifier(config.Notifications{})
+	require.NoError(t, err)
+	assert.False(t, n.Enabled())
+
+	n, err = NewNotifier(config.Notifications{Channels: []config.NotificationChannel{
+		{Type: config.NotificationChannelWebhook, Url: "https://example.com/a"},
+		{Type: config.NotificationChannelWebhook, Url: "https://example.com/b"},
+		{Type: config.NotificationChannelSlack, Url: "https://example.com/a"},
+		{Type: config.NotificationChannelSMTP, Host: "localhost", From: "ionscale@example.com", To: []string{"admin@example.com"}},
+	}})
+	require.NoError(t, err)
+	assert.True(t, n.Enabled())
+
+	// every channel has its own id, without exposing the destination
+	ids := map[string]bool{}
+	for _, c := range n.Channels() {
+		assert.NotContains(t, c.ID(), "example.com")
+		ids[c.ID()] = true
+	}
+	assert.Len(t, ids, 4)
+
+	_, err = NewNotifier(config.Notifications{Channels: []config.NotificationChannel{{Type: "pager"}}})
+	assert.Error(t, err)
+}
+
+func TestWebhookChannel(t *testing.T) {
+	var received []Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var m Message
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&m))
+		received = append(received, m)
+	}))
+	defer server.Close()
+
+	msg := testMessage()
+	require.NoError(t, (&webhookChannel{url: server.URL}).Send(context.Background(), msg))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, *msg, received[0])
+}
+
+func TestSlackChannel(t *testing.T) {
+	var received []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received = append(received, body)
+	}))
+	defer server.Close()
+
+	msg := testMessage()
+	require.NoError(t, (&slackChannel{url: server.URL}).Send(context.Background(), msg))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, map[string]string{"text": msg.Text}, received[0])
+}
+
+func TestWebhookChannel_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	assert.Error(t, (&webhookChannel{url: server.URL}).Send(context.Background(), testMessage()))
+	assert.Error(t, (&slackChannel{url: server.URL}).Send(context.Background(), testMessage()))
+}
+
+func TestSMTPChannel(t *testing.T) {
+	server := startSMTPServer(t)
+
+	channel := &smtpChannel{config: config.NotificationChannel{
+		Type:        config.NotificationChannelSMTP,
+		Host:        "127.0.0.1",
+		Port:        server.port,
+		From:        "ionscale@example.com",
+		To:          []string{"admin@example.com"},
+		NotifyOwner: true,
+	}}
+
+	msg := testMessage()
+	require.NoError(t, channel.Send(context.Background(), msg))
+
+	mails := server.received()
+	require.Len(t, mails, 1)
+	assert.Equal(t, "ionscale@example.com", mails[0].from)
+	assert.Equal(t, []string{"admin@example.com", "john@example.com"}, mails[0].to)
+	assert.Contains(t, mails[0].data, "Subject: "+msg.Subject+"\r\n")
+	assert.Contains(t, mails[0].data, msg.Text)
+
+	// owners that are not an email address are not notified
+	msg.Owner = "tagged-devices"
+	require.NoError(t, channel.Send(context.Background(), msg))
+
+	mails = server.received()
+	require.Len(t, mails, 2)
+	assert.Equal(t, []string{"admin@example.com"}, mails[1].to)
+
+	// without recipients, nothing is sent
+	channel.config.To = nil
+	require.NoError(t, channel.Send(context.Background(), msg))
+	assert.Len(t, server.received(), 2)
+}
+
+func TestSMTPChannel_ServerUnavailable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	channel := &smtpChannel{config: config.NotificationChannel{Host: "127.0.0.1", Port: port, From: "ionscale@example.com", To: []string{"admin@example.com"}}}
+	assert.Error(t, channel.Send(context.Background(), testMessage()))
+}
+
+func testMessage() *Message {
+	return NewKeyExpiryMessage(1, "example", 2, "laptop", "john@example.com", time.Now().Add(24*time.Hour).Truncate(time.Second))
+}
+
+type mail struct {
+	from string
+	to   []string
+	data string
+}
+
+// smtpServer accepts mails with the minimal set of commands used by net/smtp.
+type smtpServer struct {
+	port  int
+	mu    sync.Mutex
+	mails []mail
+}
+
+func (s *smtpServer) received() []mail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]mail{}, s.mails...)
+}
+
+func startSMTPServer(t *testing.T) *smtpServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	s := &smtpServer{port: l.Addr().(*net.TCPAddr).Port}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(code int, text string) {
+		_, _ = conn.Write([]byte(strconv.Itoa(code) + " " + text + "\r\n"))
+	}
+
+	var current mail
+	reply(220, "localhost")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply(250, "localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			current = mail{from: strings.Trim(line[len("MAIL FROM:"):], "<>")}
+			reply(250, "ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			current.to = append(current.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply(250, "ok")
+		case cmd == "DATA":
+			reply(354, "go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			current.data = data.String()
+			s.mu.Lock()
+			s.mails = append(s.mails, current)
+			s.mu.Unlock()
+			reply(250, "ok")
+		case cmd == "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(250, "ok")
+		}
+	}
+}
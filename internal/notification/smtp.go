@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"github.com/jsiebens/ionscale/internal/config"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type smtpChannel struct {
+	config config.NotificationChannel
+}
+
+func (s *smtpChannel) ID() string {
+	return channelID(config.NotificationChannelSMTP, s.config.Host, strconv.Itoa(s.config.Port), s.config.From, strings.Join(s.config.To, ","), strconv.FormatBool(s.config.NotifyOwner))
+}
+
+func (s *smtpChannel) Send(_ context.Context, m *Message) error {
+	to := append([]string{}, s.config.To...)
+	if s.config.NotifyOwner && strings.Contains(m.Owner, "@") {
+		to = append(to, m.Owner)
+	}
+
+	if len(to) == 0 {
+		return nil
+	}
+
+	port := s.config.Port
+	if port == 0 {
+		port = 25
+	}
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(port))
+
+	var auth smtp.Auth
+	if len(s.config.Username) != 0 {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	return smtp.SendMail(addr, auth, s.config.From, to, s.message(to, m))
+}
+
+func (s *smtpChannel) message(to []string, m *Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", m.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(m.Text)
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
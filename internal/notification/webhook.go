@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/jsiebens/ionscale/internal/config"
+	"net/http"
+	"time"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// webhookChannel posts the message as a JSON document.
+type webhookChannel struct {
+	url string
+}
+
+func (w *webhookChannel) ID() string {
+	return channelID(config.NotificationChannelWebhook, w.url)
+}
+
+func (w *webhookChannel) Send(ctx context.Context, m *Message) error {
+	return postJson(ctx, w.url, m)
+}
+
+// slackChannel posts the message to a Slack-compatible incoming webhook.
+type slackChannel struct {
+	url string
+}
+
+func (s *slackChannel) ID() string {
+	return channelID(config.NotificationChannelSlack, s.url)
+}
+
+func (s *slackChannel) Send(ctx context.Context, m *Message) error {
+	return postJson(ctx, s.url, map[string]string{"text": m.Text})
+}
+
+func postJson(ctx context.Context, url string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code from %s: %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/jsiebens/ionscale/internal/dns"
 	"github.com/jsiebens/ionscale/internal/domain"
 	"github.com/jsiebens/ionscale/internal/handlers"
+	"github.com/jsiebens/ionscale/internal/notification"
 	"github.com/jsiebens/ionscale/internal/service"
 	"github.com/jsiebens/ionscale/internal/stunserver"
 	"github.com/jsiebens/ionscale/internal/templates"
@@ -69,6 +70,10 @@ func Start(ctx context.Context, c *config.Config) error {
 	}
 
 	sessionManager := core.NewPollMapSessionManager()
+	requestNotifier := core.NewRequestNotifier()
+	if c.Database.Type == "postgres" || c.Database.Type == "postgresql" {
+		requestNotifier = core.NewPostgresRequestNotifier(ctx, db, c.Database.Url)
+	}
 
 	defaultControlKeys, err := repository.GetControlKeys(ctx)
 	if err != nil {
@@ -80,8 +85,6 @@ func Start(ctx context.Context, c *config.Config) error {
 		return logError(err)
 	}
 
-	core.StartWorker(repository, sessionManager)
-
 	// prepare CertMagic
 	if c.Tls.AcmeEnabled {
 		storage, err := certmagicsql.NewStorage(ctx, db, certmagicsql.Options{})
@@ -113,15 +116,25 @@ func Start(ctx context.Context, c *config.Config) error {
 		return logError(err)
 	}
 
+	dnsPublisher := dns.NewPublisher(dnsProvider, repository)
+
+	notifier, err := notification.NewNotifier(c.Notifications)
+	if err != nil {
+		return logError(err)
+	}
+
+	core.StartWorker(repository, sessionManager, dnsProvider, dnsPublisher, notifier, c.Notifications.KeyExpiry.Thresholds)
+
 	promMiddleware := echoprometheus.NewMiddleware("http")
 
 	createPeerHandler := func(machinePublicKey key.MachinePublic) http.Handler {
-		registrationHandlers := handlers.NewRegistrationHandlers(machinePublicKey, c, sessionManager, repository)
+		registrationHandlers := handlers.NewRegistrationHandlers(machinePublicKey, c, sessionManager, requestNotifier, dnsPublisher, repository)
 		pollNetMapHandler := handlers.NewPollNetMapHandler(machinePublicKey, sessionManager, repository)
-		dnsHandlers := handlers.NewDNSHandlers(machinePublicKey, dnsProvider)
+		dnsHandlers := handlers.NewDNSHandlers(machinePublicKey, c, dnsProvider, repository)
 		idTokenHandlers := handlers.NewIDTokenHandlers(machinePublicKey, c, repository)
-		sshActionHandlers := handlers.NewSSHActionHandlers(machinePublicKey, c, repository)
+		sshActionHandlers := handlers.NewSSHActionHandlers(machinePublicKey, c, requestNotifier, repository)
 		queryFeatureHandlers := handlers.NewQueryFeatureHandlers(machinePublicKey, dnsProvider, repository)
+		tkaHandlers := handlers.NewTKAHandlers(machinePublicKey, sessionManager, repository)
 
 		e := echo.New()
 		e.Binder = handlers.JsonBinder{}
@@ -134,21 +147,33 @@ func Start(ctx context.Context, c *config.Config) error {
 		e.GET("/machine/ssh/action/:src_machine_id/to/:dst_machine_id/:check_period", sshActionHandlers.StartAuth)
 		e.GET("/machine/ssh/action/check/:key", sshActionHandlers.CheckAuth)
 		e.POST("/machine/feature/query", queryFeatureHandlers.QueryFeature)
+		e.GET("/machine/tka/init/begin", tkaHandlers.InitBegin)
+		e.GET("/machine/tka/init/finish", tkaHandlers.InitFinish)
+		e.GET("/machine/tka/bootstrap", tkaHandlers.Bootstrap)
+		e.GET("/machine/tka/sync/offer", tkaHandlers.SyncOffer)
+		e.GET("/machine/tka/sync/send", tkaHandlers.SyncSend)
+		e.GET("/machine/tka/disable", tkaHandlers.Disable)
+		e.GET("/machine/tka/sign", tkaHandlers.Sign)
+		e.GET("/machine/tka/affected-sigs", tkaHandlers.AffectedSigs)
 
 		return e
 	}
 
 	noiseHandlers := handlers.NewNoiseHandlers(serverKey.ControlKey, createPeerHandler)
 	oidcConfigHandlers := handlers.NewOIDCConfigHandlers(c, repository)
+	sshEventHandlers := handlers.NewSSHEventHandlers(c, repository)
 
 	authenticationHandlers := handlers.NewAuthenticationHandlers(
 		c,
 		authProvider,
 		systemIAMPolicy,
+		requestNotifier,
+		sessionManager,
+		dnsPublisher,
 		repository,
 	)
 
-	rpcService := service.NewService(c, authProvider, dnsProvider, repository, sessionManager)
+	rpcService := service.NewService(c, authProvider, dnsProvider, dnsPublisher, repository, sessionManager, requestNotifier)
 	rpcPath, rpcHandler := NewRpcHandler(serverKey.SystemAdminKey, repository, rpcService)
 
 	metricsMux := echo.New()
@@ -168,6 +193,7 @@ func Start(ctx context.Context, c *config.Config) error {
 	webMux.POST("/ts2021", noiseHandlers.Upgrade)
 	webMux.GET("/.well-known/jwks", oidcConfigHandlers.Jwks)
 	webMux.GET("/.well-known/openid-configuration", oidcConfigHandlers.OpenIDConfig)
+	webMux.POST("/ssh/events", sshEventHandlers.Report)
 
 	csrf := middleware.CSRFWithConfig(middleware.CSRFConfig{TokenLookup: "form:_csrf"})
 	webMux.GET("/a/:flow/:key", authenticationHandlers.StartAuth, csrf)
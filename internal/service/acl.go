@@ -44,6 +44,10 @@ func (s *Service) SetACLPolicy(ctx context.Context, req *connect.Request[api.Set
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid acl policy: %w", err))
 	}
 
+	if err := newPolicy.Get().Validate(); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid acl policy: %w", err))
+	}
+
 	oldPolicy := tailnet.ACLPolicy
 	if oldPolicy.Equal(newPolicy) {
 		return connect.NewResponse(&api.SetACLPolicyResponse{}), nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/core"
 	"github.com/jsiebens/ionscale/internal/domain"
 	"github.com/jsiebens/ionscale/internal/util"
 	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
@@ -32,7 +33,11 @@ func (s *Service) Authenticate(ctx context.Context, req *connect.Request[api.Aut
 	}
 
 	notify := ctx.Done()
-	tick := time.NewTicker(1 * time.Second)
+
+	events, unsubscribe := s.notifier.Subscribe(core.AuthenticationRequestTopic(key))
+	defer unsubscribe()
+
+	tick := time.NewTicker(core.RequestRecheckInterval)
 
 	defer func() {
 		tick.Stop()
@@ -41,33 +46,34 @@ func (s *Service) Authenticate(ctx context.Context, req *connect.Request[api.Aut
 
 	for {
 		select {
+		case <-events:
 		case <-tick.C:
-			m, err := s.repository.GetAuthenticationRequest(ctx, key)
-			if err != nil {
+			// keep the stream alive while the user is authenticating
+			if err := stream.Send(&api.AuthenticateResponse{AuthUrl: authUrl}); err != nil {
 				return logError(err)
 			}
+		case <-notify:
+			return nil
+		}
 
-			if m == nil {
-				return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid authentication request"))
-			}
-
-			if len(m.Token) != 0 {
-				if err := stream.Send(&api.AuthenticateResponse{Token: m.Token, TailnetId: m.TailnetID}); err != nil {
-					return logError(err)
-				}
-				return nil
-			}
+		m, err := s.repository.GetAuthenticationRequest(ctx, key)
+		if err != nil {
+			return logError(err)
+		}
 
-			if len(m.Error) != 0 {
-				return connect.NewError(connect.CodePermissionDenied, fmt.Errorf(m.Error))
-			}
+		if m == nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid authentication request"))
+		}
 
-			if err := stream.Send(&api.AuthenticateResponse{AuthUrl: authUrl}); err != nil {
+		if len(m.Token) != 0 {
+			if err := stream.Send(&api.AuthenticateResponse{Token: m.Token, TailnetId: m.TailnetID}); err != nil {
 				return logError(err)
 			}
-
-		case <-notify:
 			return nil
 		}
+
+		if len(m.Error) != 0 {
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf(m.Error))
+		}
 	}
 }
@@ -86,28 +86,50 @@ func (s *Service) ListAuthKeys(ctx context.Context, req *connect.Request[api.Lis
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
 	}
 
-	response := api.ListAuthKeysResponse{}
+	// auth keys are listed in the order they were created
+	order := ""
+	if req.Msg.Descending {
+		order = "desc"
+	}
 
-	if principal.IsSystemAdmin() {
-		authKeys, err := s.repository.ListAuthKeys(ctx, req.Msg.TailnetId)
-		if err != nil {
-			return nil, logError(err)
-		}
+	token, err := decodePageToken(req.Msg.PageToken, order)
+	if err != nil {
+		return nil, err
+	}
 
-		response.AuthKeys = mapAuthKeysToApi(authKeys)
-		return connect.NewResponse(&response), nil
+	limit, err := pageLimit(req.Msg.PageSize)
+	if err != nil {
+		return nil, err
 	}
 
-	if principal.User != nil {
-		authKeys, err := s.repository.ListAuthKeysByTailnetAndUser(ctx, req.Msg.TailnetId, principal.User.ID)
-		if err != nil {
-			return nil, logError(err)
+	response := api.ListAuthKeysResponse{}
+
+	filter := domain.AuthKeyFilter{TailnetID: req.Msg.TailnetId, UserID: req.Msg.UserId, Descending: req.Msg.Descending, Limit: limit}
+	if token != nil {
+		filter.AfterID = token.ID
+	}
+
+	if !principal.IsSystemAdmin() {
+		if principal.User == nil {
+			return connect.NewResponse(&response), nil
+		}
+		if filter.UserID != 0 && filter.UserID != principal.User.ID {
+			return connect.NewResponse(&response), nil
 		}
+		filter.UserID = principal.User.ID
+	}
+
+	authKeys, err := s.repository.ListAuthKeys(ctx, filter)
+	if err != nil {
+		return nil, logError(err)
+	}
 
-		response.AuthKeys = mapAuthKeysToApi(authKeys)
-		return connect.NewResponse(&response), nil
+	authKeys, last := nextPage(authKeys, limit)
+	if last != nil {
+		response.NextPageToken = pageToken{ID: last.ID, Order: order}.encode()
 	}
 
+	response.AuthKeys = mapAuthKeysToApi(authKeys)
 	return connect.NewResponse(&response), nil
 }
 
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/dns"
+	"github.com/jsiebens/ionscale/internal/domain"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+)
+
+func TestListAuthKeys(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	john := createTestUser(t, repository, tailnet, "john@example.com")
+	jane := createTestUser(t, repository, tailnet, "jane@example.com")
+
+	var all, johns []uint64
+	for i := 0; i < 5; i++ {
+		user := john
+		if i%2 == 1 {
+			user = jane
+		}
+		_, authKey := domain.CreateAuthKey(tailnet, user, false, false, nil, nil)
+		require.NoError(t, repository.SaveAuthKey(ctx, authKey))
+
+		all = append(all, authKey.ID)
+		if user == john {
+			johns = append(johns, authKey.ID)
+		}
+	}
+
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, core.NewPollMapSessionManager(), nil)
+	adminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+
+	list := func(req *api.ListAuthKeysRequest) (*api.ListAuthKeysResponse, error) {
+		req.TailnetId = tailnet.ID
+		resp, err := s.ListAuthKeys(adminCtx, connect.NewRequest(req))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	tests := []struct {
+		userID   uint64
+		expected []uint64
+	}{
+		{0, all},
+		{john.ID, johns},
+	}
+
+	for _, tt := range tests {
+		for _, descending := range []bool{false, true} {
+			expected := slices.Clone(tt.expected)
+			if descending {
+				slices.Reverse(expected)
+			}
+
+			t.Run(fmt.Sprintf("user=%d descending=%t", tt.userID, descending), func(t *testing.T) {
+				var pages []uint64
+				req := &api.ListAuthKeysRequest{UserId: tt.userID, Descending: descending, PageSize: 2}
+				for {
+					resp, err := list(req)
+					require.NoError(t, err)
+					require.LessOrEqual(t, len(resp.AuthKeys), 2)
+					for _, k := range resp.AuthKeys {
+						pages = append(pages, k.Id)
+					}
+					if resp.NextPageToken == "" {
+						break
+					}
+					req.PageToken = resp.NextPageToken
+				}
+				require.Equal(t, expected, pages)
+			})
+		}
+	}
+
+	t.Run("page token of another order", func(t *testing.T) {
+		resp, err := list(&api.ListAuthKeysRequest{PageSize: 2})
+		require.NoError(t, err)
+
+		_, err = list(&api.ListAuthKeysRequest{PageSize: 2, PageToken: resp.NextPageToken, Descending: true})
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
@@ -49,6 +49,18 @@ func (s *Service) SetDNSConfig(ctx context.Context, req *connect.Request[api.Set
 		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("A DNS provider must be configured when enabling HTTPS Certs"))
 	}
 
+	if dnsConfig.PublishRecords && !dnsConfig.MagicDns {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("MagicDNS must be enabled when publishing records"))
+	}
+
+	if dnsConfig.PublishRecords && s.dnsProvider == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("A DNS provider must be configured when publishing records"))
+	}
+
+	if err := validateDNSOverlays(dnsConfig.Overlays); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	tailnet, err := s.repository.GetTailnet(ctx, req.Msg.TailnetId)
 	if err != nil {
 		return nil, logError(err)
@@ -70,6 +82,7 @@ func (s *Service) SetDNSConfig(ctx context.Context, req *connect.Request[api.Set
 	}
 
 	s.sessionManager.NotifyAll(tailnet.ID)
+	s.dnsPublisher.SyncTailnet(tailnet.ID)
 
 	return connect.NewResponse(&api.SetDNSConfigResponse{Config: domainDNSConfigToApiDNSConfig(tailnet)}), nil
 }
@@ -125,6 +138,8 @@ func apiDNSConfigToDomainDNSConfig(dnsConfig *api.DNSConfig) domain.DNSConfig {
 		Routes:            apiRoutesToDomainRoutes(dnsConfig.Routes),
 		SearchDomains:     dnsConfig.SearchDomains,
 		ExtraRecords:      apiExtraRecordsToDomainExtraRecords(dnsConfig.ExtraRecords),
+		PublishRecords:    dnsConfig.PublishRecords,
+		Overlays:          apiDNSOverlaysToDomainDNSOverlays(dnsConfig.Overlays),
 	}
 }
 
@@ -140,5 +155,46 @@ func domainDNSConfigToApiDNSConfig(tailnet *domain.Tailnet) *api.DNSConfig {
 		Routes:           domainRoutesToApiRoutes(dnsConfig.Routes),
 		SearchDomains:    dnsConfig.SearchDomains,
 		ExtraRecords:     domainExtraRecordsToApiExtraRecords(dnsConfig.ExtraRecords),
+		PublishRecords:   dnsConfig.PublishRecords,
+		Overlays:         domainDNSOverlaysToApiDNSOverlays(dnsConfig.Overlays),
+	}
+}
+
+func validateDNSOverlays(overlays []*api.DNSOverlay) error {
+	for _, o := range overlays {
+		if len(o.Targets) == 0 {
+			return fmt.Errorf("invalid dns overlay: at least one target is required")
+		}
+
+		for _, t := range o.Targets {
+			if t != "*" && !strings.Contains(t, "@") && !strings.HasPrefix(t, "tag:") && !strings.HasPrefix(t, "group:") {
+				return fmt.Errorf("invalid dns overlay target [%s], must be a user, a tag or a group", t)
+			}
+		}
+	}
+	return nil
+}
+
+func apiDNSOverlaysToDomainDNSOverlays(overlays []*api.DNSOverlay) (result []domain.DNSOverlay) {
+	for _, o := range overlays {
+		result = append(result, domain.DNSOverlay{
+			Targets:       o.Targets,
+			Nameservers:   o.Nameservers,
+			Routes:        apiRoutesToDomainRoutes(o.Routes),
+			SearchDomains: o.SearchDomains,
+		})
 	}
+	return
+}
+
+func domainDNSOverlaysToApiDNSOverlays(overlays []domain.DNSOverlay) (result []*api.DNSOverlay) {
+	for _, o := range overlays {
+		result = append(result, &api.DNSOverlay{
+			Targets:       o.Targets,
+			Nameservers:   o.Nameservers,
+			Routes:        domainRoutesToApiRoutes(o.Routes),
+			SearchDomains: o.SearchDomains,
+		})
+	}
+	return
 }
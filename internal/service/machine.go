@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/config"
 	"github.com/jsiebens/ionscale/internal/domain"
 	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"net/netip"
+	"slices"
+	"tailscale.com/util/dnsname"
 	"time"
 )
 
@@ -29,6 +32,12 @@ func (s *Service) machineToApi(m *domain.Machine) *api.Machine {
 		endpoints = append(endpoints, e.String())
 	}
 
+	connectivity := &api.ClientConnectivity{Endpoints: endpoints}
+	if m.HostInfo.NetInfo != nil {
+		connectivity.PreferredDerp = int32(m.HostInfo.NetInfo.PreferredDERP)
+		connectivity.MappingVariesByDestIp = m.HostInfo.NetInfo.MappingVariesByDestIP.EqualBool(true)
+	}
+
 	return &api.Machine{
 		Id:                m.ID,
 		Name:              name,
@@ -42,6 +51,7 @@ func (s *Service) machineToApi(m *domain.Machine) *api.Machine {
 		KeyExpiryDisabled: m.KeyExpiryDisabled,
 		Connected:         online,
 		Os:                m.HostInfo.OS,
+		OsVersion:         m.HostInfo.OSVersion,
 		ClientVersion:     m.HostInfo.IPNVersion,
 		Tailnet: &api.Ref{
 			Id:   m.Tailnet.ID,
@@ -51,14 +61,14 @@ func (s *Service) machineToApi(m *domain.Machine) *api.Machine {
 			Id:   m.User.ID,
 			Name: m.User.Name,
 		},
-		ClientConnectivity: &api.ClientConnectivity{
-			Endpoints: endpoints,
-		},
+		ClientConnectivity: connectivity,
 		AdvertisedRoutes:   m.AdvertisedPrefixes(),
 		EnabledRoutes:      m.AllowedPrefixes(),
 		AdvertisedExitNode: m.IsAdvertisedExitNode(),
 		EnabledExitNode:    m.IsAllowedExitNode(),
 		Authorized:         m.Authorized,
+		Aliases:            m.Aliases,
+		AutoGenerateName:   !m.CustomName,
 	}
 }
 
@@ -76,19 +86,99 @@ func (s *Service) ListMachines(ctx context.Context, req *connect.Request[api.Lis
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
 	}
 
-	machines, err := s.repository.ListMachineByTailnet(ctx, tailnet.ID)
+	orderBy, order, err := listOrder(req.Msg.OrderBy, req.Msg.Descending, domain.MachineOrderByName, domain.MachineOrderByLastSeen, domain.MachineOrderByCreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := decodePageToken(req.Msg.PageToken, order)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := pageLimit(req.Msg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.MachineFilter{
+		TailnetID:  tailnet.ID,
+		Tag:        req.Msg.Tag,
+		UserID:     req.Msg.UserId,
+		Connected:  req.Msg.Connected,
+		Expired:    req.Msg.Expired,
+		Authorized: req.Msg.Authorized,
+		OS:         req.Msg.Os,
+		NamePrefix: req.Msg.NamePrefix,
+		OrderBy:    orderBy,
+		Descending: req.Msg.Descending,
+		Limit:      limit,
+	}
+
+	if filter.Connected != nil {
+		filter.ConnectedIDs = s.sessionManager.ListSessions(tailnet.ID)
+	}
+
+	if req.Msg.LastSeenAfter != nil {
+		t := req.Msg.LastSeenAfter.AsTime()
+		filter.LastSeenAfter = &t
+	}
+
+	if req.Msg.LastSeenBefore != nil {
+		t := req.Msg.LastSeenBefore.AsTime()
+		filter.LastSeenBefore = &t
+	}
+
+	if token != nil {
+		filter.After = &domain.MachineCursor{ID: token.ID, Name: token.Name, NameIdx: token.NameIdx}
+		if token.Time != nil {
+			filter.After.Time = *token.Time
+		}
+	}
+
+	machines, err := s.repository.ListMachinesByFilter(ctx, filter)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	latest, err := s.latestClientVersions(ctx, tailnet.ID)
 	if err != nil {
 		return nil, logError(err)
 	}
 
 	response := &api.ListMachinesResponse{}
+
+	machines, last := nextPage(machines, limit)
+	if last != nil {
+		cursor := filter.CursorOf(last)
+		next := pageToken{ID: cursor.ID, Name: cursor.Name, NameIdx: cursor.NameIdx, Order: order}
+		if !cursor.Time.IsZero() {
+			next.Time = &cursor.Time
+		}
+		response.NextPageToken = next.encode()
+	}
+
 	for _, m := range machines {
-		response.Machines = append(response.Machines, s.machineToApi(&m))
+		machine := s.machineToApi(&m)
+		machine.UpdateAvailable = latest.UpdateAvailable(m.ClientVersion())
+		response.Machines = append(response.Machines, machine)
 	}
 
 	return connect.NewResponse(response), nil
 }
 
+// latestClientVersions returns the latest released client versions as configured, or the newest versions
+// running in the tailnet when those are more recent.
+func (s *Service) latestClientVersions(ctx context.Context, tailnetID uint64) (domain.ClientVersions, error) {
+	latest, err := s.repository.GetLatestClientVersions(ctx, tailnetID)
+	if err != nil {
+		return domain.ClientVersions{}, err
+	}
+
+	released := domain.ClientVersions{Stable: s.config.ClientVersions.Stable, Unstable: s.config.ClientVersions.Unstable}
+	return latest.Merge(released), nil
+}
+
 func (s *Service) GetMachine(ctx context.Context, req *connect.Request[api.GetMachineRequest]) (*connect.Response[api.GetMachineResponse], error) {
 	principal := CurrentPrincipal(ctx)
 
@@ -105,7 +195,15 @@ func (s *Service) GetMachine(ctx context.Context, req *connect.Request[api.GetMa
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
 	}
 
-	return connect.NewResponse(&api.GetMachineResponse{Machine: s.machineToApi(m)}), nil
+	latest, err := s.latestClientVersions(ctx, m.TailnetID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	machine := s.machineToApi(m)
+	machine.UpdateAvailable = latest.UpdateAvailable(m.ClientVersion())
+
+	return connect.NewResponse(&api.GetMachineResponse{Machine: machine}), nil
 }
 
 func (s *Service) DeleteMachine(ctx context.Context, req *connect.Request[api.DeleteMachineRequest]) (*connect.Response[api.DeleteMachineResponse], error) {
@@ -124,11 +222,20 @@ func (s *Service) DeleteMachine(ctx context.Context, req *connect.Request[api.De
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
 	}
 
+	sharingTailnets, err := s.repository.ListSharingTailnets(ctx, m.TailnetID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
 	if _, err := s.repository.DeleteMachine(ctx, req.Msg.MachineId); err != nil {
 		return nil, logError(err)
 	}
 
 	s.sessionManager.NotifyAll(m.TailnetID)
+	for _, id := range sharingTailnets {
+		s.sessionManager.NotifyAll(id)
+	}
+	s.dnsPublisher.SyncTailnet(m.TailnetID)
 
 	return connect.NewResponse(&api.DeleteMachineResponse{}), nil
 }
@@ -186,6 +293,7 @@ func (s *Service) AuthorizeMachine(ctx context.Context, req *connect.Request[api
 	}
 
 	s.sessionManager.NotifyAll(m.TailnetID)
+	s.dnsPublisher.SyncTailnet(m.TailnetID)
 
 	return connect.NewResponse(&api.AuthorizeMachineResponse{}), nil
 }
@@ -206,6 +314,14 @@ func (s *Service) GetMachineRoutes(ctx context.Context, req *connect.Request[api
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
 	}
 
+	// the primary routers are elected while mapping the tailnet, they are only looked up here
+	primaryRouters := map[string]uint64{}
+	for _, r := range slices.Concat(m.AllowIPs, m.AutoAllowIPs) {
+		if primary, ok := s.sessionManager.CurrentPrimaryRouter(m.TailnetID, r); ok && r.Bits() != 0 {
+			primaryRouters[r.String()] = primary
+		}
+	}
+
 	response := api.GetMachineRoutesResponse{
 		MachineId: m.ID,
 		Routes: &api.MachineRoutes{
@@ -213,6 +329,7 @@ func (s *Service) GetMachineRoutes(ctx context.Context, req *connect.Request[api
 			EnabledRoutes:      m.AllowedPrefixes(),
 			AdvertisedExitNode: m.IsAdvertisedExitNode(),
 			EnabledExitNode:    m.IsAllowedExitNode(),
+			PrimaryRouters:     primaryRouters,
 		},
 	}
 
@@ -445,3 +562,340 @@ func (s *Service) SetMachineKeyExpiry(ctx context.Context, req *connect.Request[
 
 	return connect.NewResponse(&api.SetMachineKeyExpiryResponse{}), nil
 }
+
+func (s *Service) RenameMachine(ctx context.Context, req *connect.Request[api.RenameMachineRequest]) (*connect.Response[api.RenameMachineResponse], error) {
+	principal := CurrentPrincipal(ctx)
+
+	m, err := s.repository.GetMachine(ctx, req.Msg.MachineId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if m == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("machine not found"))
+	}
+
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(m.TailnetID) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	var name string
+	if req.Msg.AutoGenerateName {
+		name = dnsname.SanitizeHostname(m.HostInfo.Hostname)
+		if name == "" {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("machine has not reported a hostname to generate a name from"))
+		}
+	} else {
+		name = dnsname.SanitizeLabel(req.Msg.Name)
+		if name == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid machine name [%s]", req.Msg.Name))
+		}
+	}
+
+	m.CustomName = !req.Msg.AutoGenerateName
+
+	err = s.repository.Transaction(func(rp domain.Repository) error {
+		tailnet, err := rp.GetTailnetForUpdate(ctx, m.TailnetID)
+		if err != nil {
+			return err
+		}
+		if tailnet == nil {
+			return fmt.Errorf("tailnet %d not found", m.TailnetID)
+		}
+
+		if m.Name != name {
+			nameIdx, err := domain.NextMachineNameIndex(ctx, rp, tailnet, config.MagicDNSSuffix(), m.ID, name)
+			if err != nil {
+				return err
+			}
+			m.Name = name
+			m.NameIdx = nameIdx
+		}
+
+		return rp.SaveMachine(ctx, m)
+	})
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	s.sessionManager.NotifyAll(m.TailnetID)
+	s.dnsPublisher.SyncTailnet(m.TailnetID)
+
+	return connect.NewResponse(&api.RenameMachineResponse{Machine: s.machineToApi(m)}), nil
+}
+
+// MoveMachine re-homes a machine in another tailnet. Tagged machines are owned by the service user of the target
+// tailnet, other machines by the user of the same account in the target tailnet. Addresses are allocated uniquely
+// across all tailnets, so the machine keeps its addresses and the client picks up the new tailnet on its existing session.
+func (s *Service) MoveMachine(ctx context.Context, req *connect.Request[api.MoveMachineRequest]) (*connect.Response[api.MoveMachineResponse], error) {
+	principal := CurrentPrincipal(ctx)
+
+	m, err := s.repository.GetMachine(ctx, req.Msg.MachineId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if m == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("machine not found"))
+	}
+
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(m.TailnetID) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	if m.TailnetID == req.Msg.TailnetId {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("machine is already part of the tailnet"))
+	}
+
+	target, err := s.repository.GetTailnet(ctx, req.Msg.TailnetId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if target == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
+	}
+
+	if !principal.IsSystemAdmin() && !isAdminOfTailnet(principal, target) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	keyAuthority, err := s.repository.GetTailnetKeyAuthority(ctx, target.ID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if keyAuthority != nil && keyAuthority.IsEnabled() {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("target tailnet has tailnet lock enabled"))
+	}
+
+	policy := target.ACLPolicy.Get()
+
+	if m.HasTags() {
+		if err := policy.CheckTagsDefined(m.Tags); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if !principal.IsSystemAdmin() {
+			if err := policy.CheckTagOwners(m.Tags, principal.User); err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+		}
+	} else if m.User.Account == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("machine is not owned by a user with an account"))
+	}
+
+	sourceTailnetID := m.TailnetID
+
+	sharingTailnets, err := s.repository.ListSharingTailnets(ctx, sourceTailnetID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	var conflict error
+
+	err = s.repository.Transaction(func(rp domain.Repository) error {
+		if _, err := rp.GetTailnetForUpdate(ctx, target.ID); err != nil {
+			return err
+		}
+
+		targetMachines, err := rp.ListMachineByTailnet(ctx, target.ID)
+		if err != nil {
+			return err
+		}
+
+		// the aliases of the machine have to be available in the target tailnet
+		if conflict = targetMachines.CheckAliases(m, target, config.MagicDNSSuffix()); conflict != nil {
+			return nil
+		}
+
+		// the name index is determined by the machines of the target tailnet
+		nameIdx, err := domain.NextMachineNameIndex(ctx, rp, target, config.MagicDNSSuffix(), m.ID, m.Name)
+		if err != nil {
+			return err
+		}
+
+		var user *domain.User
+		if m.HasTags() {
+			user, _, err = rp.GetOrCreateServiceUser(ctx, target)
+		} else {
+			user, _, err = rp.GetOrCreateUserWithAccount(ctx, target, m.User.Account)
+		}
+		if err != nil {
+			return err
+		}
+
+		m.TailnetID = target.ID
+		m.Tailnet = *target
+		m.UserID = user.ID
+		m.User = *user
+		m.NameIdx = nameIdx
+
+		// signatures and route approvals belong to the previous tailnet
+		m.KeySignature = nil
+		m.AllowIPs = domain.AllowIPs{}
+		m.AutoAllowIPs = policy.FindAutoApprovedIPs(m.HostInfo.RoutableIPs, m.Tags, &m.User, targetMachines.SubnetRoutes())
+
+		// shares are granted by the previous tailnet
+		if err := rp.DeleteMachineSharesByMachine(ctx, m.ID); err != nil {
+			return err
+		}
+
+		return rp.SaveMachine(ctx, m)
+	})
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if conflict != nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, conflict)
+	}
+
+	s.sessionManager.NotifyAll(sourceTailnetID)
+	s.sessionManager.NotifyAll(target.ID)
+	for _, id := range sharingTailnets {
+		s.sessionManager.NotifyAll(id)
+	}
+	s.dnsPublisher.SyncTailnet(sourceTailnetID)
+	s.dnsPublisher.SyncTailnet(target.ID)
+
+	return connect.NewResponse(&api.MoveMachineResponse{Machine: s.machineToApi(m)}), nil
+}
+
+// isAdminOfTailnet returns whether the principal is an admin of the tailnet, also when authenticated for another
+// tailnet, based on the role the IAM policy of the tailnet grants to the login name of the principal.
+func isAdminOfTailnet(principal domain.Principal, tailnet *domain.Tailnet) bool {
+	if principal.IsTailnetAdmin(tailnet.ID) {
+		return true
+	}
+	return principal.User != nil && principal.User.UserType == domain.UserTypePerson && tailnet.IAMPolicy.Get().GetRole(*principal.User).IsAdmin()
+}
+
+func (s *Service) SetMachineAliases(ctx context.Context, req *connect.Request[api.SetMachineAliasesRequest]) (*connect.Response[api.SetMachineAliasesResponse], error) {
+	principal := CurrentPrincipal(ctx)
+
+	m, err := s.repository.GetMachine(ctx, req.Msg.MachineId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if m == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("machine not found"))
+	}
+
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(m.TailnetID) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	aliases, err := domain.SanitizeAliases(req.Msg.Aliases)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var conflict error
+
+	// aliases are checked and saved while holding a lock on the tailnet, so that concurrent updates can't claim the same alias
+	err = s.repository.Transaction(func(rp domain.Repository) error {
+		tailnet, err := rp.GetTailnetForUpdate(ctx, m.TailnetID)
+		if err != nil {
+			return err
+		}
+		if tailnet == nil {
+			return fmt.Errorf("tailnet %d not found", m.TailnetID)
+		}
+
+		machines, err := rp.ListMachineByTailnet(ctx, tailnet.ID)
+		if err != nil {
+			return err
+		}
+
+		m.Aliases = aliases
+		if conflict = machines.CheckAliases(m, tailnet, config.MagicDNSSuffix()); conflict != nil {
+			return nil
+		}
+
+		return rp.SaveMachine(ctx, m)
+	})
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if conflict != nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, conflict)
+	}
+
+	s.sessionManager.NotifyAll(m.TailnetID)
+
+	return connect.NewResponse(&api.SetMachineAliasesResponse{Machine: s.machineToApi(m)}), nil
+}
+
+func (s *Service) SetMachineTags(ctx context.Context, req *connect.Request[api.SetMachineTagsRequest]) (*connect.Response[api.SetMachineTagsResponse], error) {
+	principal := CurrentPrincipal(ctx)
+
+	m, err := s.repository.GetMachine(ctx, req.Msg.MachineId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if m == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("machine not found"))
+	}
+
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(m.TailnetID) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	if err := domain.CheckTags(req.Msg.Tags); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	policy := m.Tailnet.ACLPolicy.Get()
+
+	if !principal.IsSystemAdmin() {
+		if err := policy.CheckTagOwners(req.Msg.Tags, principal.User); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	tags := domain.SanitizeTags(req.Msg.Tags)
+
+	// tagged machines are owned by the service user of the tailnet,
+	// removing all tags hands the machine over to the user applying the change
+	if len(tags) != 0 && m.User.UserType != domain.UserTypeService {
+		serviceUser, _, err := s.repository.GetOrCreateServiceUser(ctx, &m.Tailnet)
+		if err != nil {
+			return nil, logError(err)
+		}
+		m.User = *serviceUser
+		m.UserID = serviceUser.ID
+	}
+
+	if len(tags) == 0 && m.User.UserType == domain.UserTypeService {
+		if principal.User == nil || principal.User.TailnetID != m.TailnetID {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("tags can only be removed by a user of the tailnet taking ownership of the machine"))
+		}
+		m.User = *principal.User
+		m.UserID = principal.User.ID
+	}
+
+	if m.HasTags() != (len(tags) != 0) {
+		m.KeyExpiryDisabled = len(tags) != 0
+		m.ExpiresAt = time.Now().Add(domain.DefaultKeyExpiry).UTC()
+	}
+
+	m.RegisteredTags = tags
+	m.Tags = tags
+
+	tailnetMachines, err := s.repository.ListMachineByTailnet(ctx, m.TailnetID)
+	if err != nil {
+		return nil, logError(err)
+	}
+	m.AutoAllowIPs = policy.FindAutoApprovedIPs(m.HostInfo.RoutableIPs, m.Tags, &m.User, tailnetMachines.SubnetRoutes())
+
+	if err := s.repository.SaveMachine(ctx, m); err != nil {
+		return nil, logError(err)
+	}
+
+	s.sessionManager.NotifyAll(m.TailnetID)
+
+	return connect.NewResponse(&api.SetMachineTagsResponse{Machine: s.machineToApi(m)}), nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/domain"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"net/netip"
+	"path"
+	"time"
+)
+
+// bulkOperation changes a selected machine in memory, and returns false when the machine is left unchanged.
+type bulkOperation func(m *domain.Machine) bool
+
+// bulkPersist stores a changed machine.
+type bulkPersist func(ctx context.Context, rp domain.Repository, m *domain.Machine) error
+
+func saveMachine(ctx context.Context, rp domain.Repository, m *domain.Machine) error {
+	return rp.SaveMachine(ctx, m)
+}
+
+func deleteMachine(ctx context.Context, rp domain.Repository, m *domain.Machine) error {
+	_, err := rp.DeleteMachine(ctx, m.ID)
+	return err
+}
+
+func (s *Service) BulkExpireMachines(ctx context.Context, req *connect.Request[api.BulkExpireMachinesRequest]) (*connect.Response[api.BulkExpireMachinesResponse], error) {
+	expire := func(m *domain.Machine) bool {
+		if m.IsExpired() {
+			return false
+		}
+		m.ExpiresAt = time.Unix(123, 0)
+		m.KeyExpiryDisabled = false
+		return true
+	}
+
+	machines, err := s.bulkMachineOperation(ctx, req.Msg.Selector, req.Msg.DryRun, expire, saveMachine)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&api.BulkExpireMachinesResponse{Machines: machines}), nil
+}
+
+func (s *Service) BulkDeleteMachines(ctx context.Context, req *connect.Request[api.BulkDeleteMachinesRequest]) (*connect.Response[api.BulkDeleteMachinesResponse], error) {
+	remove := func(m *domain.Machine) bool {
+		return true
+	}
+
+	machines, err := s.bulkMachineOperation(ctx, req.Msg.Selector, req.Msg.DryRun, remove, deleteMachine)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&api.BulkDeleteMachinesResponse{Machines: machines}), nil
+}
+
+func (s *Service) BulkAuthorizeMachines(ctx context.Context, req *connect.Request[api.BulkAuthorizeMachinesRequest]) (*connect.Response[api.BulkAuthorizeMachinesResponse], error) {
+	authorize := func(m *domain.Machine) bool {
+		if m.Authorized {
+			return false
+		}
+		m.Authorized = true
+		return true
+	}
+
+	machines, err := s.bulkMachineOperation(ctx, req.Msg.Selector, req.Msg.DryRun, authorize, saveMachine)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&api.BulkAuthorizeMachinesResponse{Machines: machines}), nil
+}
+
+func (s *Service) BulkEnableMachineRoutes(ctx context.Context, req *connect.Request[api.BulkEnableMachineRoutesRequest]) (*connect.Response[api.BulkEnableMachineRoutesResponse], error) {
+	var routes []netip.Prefix
+	for _, r := range req.Msg.Routes {
+		prefix, err := netip.ParsePrefix(r)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid route [%s]", r))
+		}
+		routes = append(routes, prefix)
+	}
+
+	enable := func(m *domain.Machine) bool {
+		return m.EnableAdvertisedRoutes(routes)
+	}
+
+	machines, err := s.bulkMachineOperation(ctx, req.Msg.Selector, req.Msg.DryRun, enable, saveMachine)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&api.BulkEnableMachineRoutesResponse{Machines: machines}), nil
+}
+
+// bulkMachineOperation applies the operation to all selected machines in a single transaction, and returns
+// the machines that changed, or would change in a dry run.
+func (s *Service) bulkMachineOperation(ctx context.Context, selector *api.MachineSelector, dryRun bool, op bulkOperation, persist bulkPersist) ([]*api.Machine, error) {
+	if selector == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("selector is required"))
+	}
+
+	principal := CurrentPrincipal(ctx)
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(selector.TailnetId) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	if selector.UserId == 0 && selector.Tag == "" && selector.Name == "" && selector.LastSeenBefore == nil && selector.LastSeenAfter == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one selector criterion is required"))
+	}
+
+	if _, err := path.Match(selector.Name, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid name pattern [%s]", selector.Name))
+	}
+
+	tailnet, err := s.repository.GetTailnet(ctx, selector.TailnetId)
+	if err != nil {
+		return nil, logError(err)
+	}
+	if tailnet == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
+	}
+
+	filter := domain.MachineFilter{
+		TailnetID: tailnet.ID,
+		Tag:       selector.Tag,
+		UserID:    selector.UserId,
+	}
+
+	if selector.LastSeenBefore != nil {
+		t := selector.LastSeenBefore.AsTime()
+		filter.LastSeenBefore = &t
+	}
+
+	if selector.LastSeenAfter != nil {
+		t := selector.LastSeenAfter.AsTime()
+		filter.LastSeenAfter = &t
+	}
+
+	// deleted machines are no longer part of their shares, so the sharing tailnets are looked up in advance
+	sharingTailnets, err := s.repository.ListSharingTailnets(ctx, tailnet.ID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	var changed domain.Machines
+
+	err = s.repository.Transaction(func(rp domain.Repository) error {
+		machines, err := rp.ListMachinesByFilter(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range machines {
+			if selector.Name != "" && !m.MatchesName(selector.Name) {
+				continue
+			}
+
+			if !op(&m) {
+				continue
+			}
+
+			if !dryRun {
+				if err := persist(ctx, rp, &m); err != nil {
+					return err
+				}
+			}
+
+			changed = append(changed, m)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if !dryRun && len(changed) != 0 {
+		s.sessionManager.NotifyAll(tailnet.ID)
+		for _, id := range sharingTailnets {
+			s.sessionManager.NotifyAll(id)
+		}
+		s.dnsPublisher.SyncTailnet(tailnet.ID)
+	}
+
+	var result []*api.Machine
+	for _, m := range changed {
+		result = append(result, s.machineToApi(&m))
+	}
+
+	return result, nil
+}
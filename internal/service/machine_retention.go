@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/domain"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func (s *Service) GetMachineRetentionPolicy(ctx context.Context, req *connect.Request[api.GetMachineRetentionPolicyRequest]) (*connect.Response[api.GetMachineRetentionPolicyResponse], error) {
+	principal := CurrentPrincipal(ctx)
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(req.Msg.TailnetId) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	tailnet, err := s.repository.GetTailnet(ctx, req.Msg.TailnetId)
+	if err != nil {
+		return nil, logError(err)
+	}
+	if tailnet == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
+	}
+
+	return connect.NewResponse(&api.GetMachineRetentionPolicyResponse{Rules: retentionRulesToApi(tailnet.MachineRetentionPolicy)}), nil
+}
+
+func (s *Service) SetMachineRetentionPolicy(ctx context.Context, req *connect.Request[api.SetMachineRetentionPolicyRequest]) (*connect.Response[api.SetMachineRetentionPolicyResponse], error) {
+	principal := CurrentPrincipal(ctx)
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(req.Msg.TailnetId) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	tailnet, err := s.repository.GetTailnet(ctx, req.Msg.TailnetId)
+	if err != nil {
+		return nil, logError(err)
+	}
+	if tailnet == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
+	}
+
+	policy := apiToRetentionPolicy(req.Msg.Rules)
+	if err := policy.Validate(); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	tailnet.MachineRetentionPolicy = policy
+	if err := s.repository.SaveTailnet(ctx, tailnet); err != nil {
+		return nil, logError(err)
+	}
+
+	return connect.NewResponse(&api.SetMachineRetentionPolicyResponse{Rules: retentionRulesToApi(policy)}), nil
+}
+
+func (s *Service) PreviewMachineRetentionPolicy(ctx context.Context, req *connect.Request[api.PreviewMachineRetentionPolicyRequest]) (*connect.Response[api.PreviewMachineRetentionPolicyResponse], error) {
+	principal := CurrentPrincipal(ctx)
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(req.Msg.TailnetId) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	tailnet, err := s.repository.GetTailnet(ctx, req.Msg.TailnetId)
+	if err != nil {
+		return nil, logError(err)
+	}
+	if tailnet == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
+	}
+
+	policy := tailnet.MachineRetentionPolicy
+	if len(req.Msg.Rules) != 0 {
+		policy = apiToRetentionPolicy(req.Msg.Rules)
+		if err := policy.Validate(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	machines, err := s.repository.ListMachineByTailnet(ctx, tailnet.ID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	isConnected := func(m *domain.Machine) bool { return s.sessionManager.HasSession(m.TailnetID, m.ID) }
+
+	response := &api.PreviewMachineRetentionPolicyResponse{}
+	for _, c := range policy.Evaluate(machines, time.Now().UTC(), isConnected) {
+		candidate := &api.MachineRetentionCandidate{
+			MachineId: c.Machine.ID,
+			Name:      c.Machine.CompleteName(),
+			Action:    c.Action,
+		}
+		if c.Machine.LastSeen != nil {
+			candidate.LastSeen = timestamppb.New(*c.Machine.LastSeen)
+		}
+		response.Machines = append(response.Machines, candidate)
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+func retentionRulesToApi(policy domain.MachineRetentionPolicy) []*api.MachineRetentionRule {
+	var result []*api.MachineRetentionRule
+	for _, r := range policy.Rules {
+		result = append(result, &api.MachineRetentionRule{
+			Action:      r.Action,
+			Selector:    r.Selector,
+			InactiveFor: durationpb.New(r.InactiveFor),
+		})
+	}
+	return result
+}
+
+func apiToRetentionPolicy(rules []*api.MachineRetentionRule) domain.MachineRetentionPolicy {
+	var result domain.MachineRetentionPolicy
+	for _, r := range rules {
+		result.Rules = append(result.Rules, domain.MachineRetentionRule{
+			Action:      r.Action,
+			Selector:    r.Selector,
+			InactiveFor: r.InactiveFor.AsDuration(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/util"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func (s *Service) CreateMachineShare(ctx context.Context, req *connect.Request[api.CreateMachineShareRequest]) (*connect.Response[api.CreateMachineShareResponse], error) {
+	principal := CurrentPrincipal(ctx)
+
+	m, err := s.repository.GetMachine(ctx, req.Msg.MachineId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if m == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("machine not found"))
+	}
+
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(m.TailnetID) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	if req.Msg.LoginName != "" && req.Msg.TailnetId != 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("a machine is shared with a user or a tailnet, not both"))
+	}
+
+	if req.Msg.TailnetId != 0 {
+		if req.Msg.TailnetId == m.TailnetID {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("machine is already part of the tailnet"))
+		}
+
+		tailnet, err := s.repository.GetTailnet(ctx, req.Msg.TailnetId)
+		if err != nil {
+			return nil, logError(err)
+		}
+		if tailnet == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
+		}
+	}
+
+	share := &domain.MachineShare{
+		ID:              util.NextID(),
+		Code:            util.RandStringBytes(16),
+		MachineID:       m.ID,
+		TailnetID:       m.TailnetID,
+		InviteLoginName: req.Msg.LoginName,
+		InviteTailnetID: req.Msg.TailnetId,
+		CreatedAt:       time.Now().UTC(),
+	}
+
+	if err := s.repository.SaveMachineShare(ctx, share); err != nil {
+		return nil, logError(err)
+	}
+
+	result, err := s.machineShareToApi(ctx, share, m, true)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	return connect.NewResponse(&api.CreateMachineShareResponse{Share: result}), nil
+}
+
+func (s *Service) ListMachineShares(ctx context.Context, req *connect.Request[api.ListMachineSharesRequest]) (*connect.Response[api.ListMachineSharesResponse], error) {
+	principal := CurrentPrincipal(ctx)
+
+	response := &api.ListMachineSharesResponse{}
+
+	if req.Msg.MachineId != 0 {
+		m, err := s.repository.GetMachine(ctx, req.Msg.MachineId)
+		if err != nil {
+			return nil, logError(err)
+		}
+
+		if m == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("machine not found"))
+		}
+
+		if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(m.TailnetID) {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+		}
+
+		shares, err := s.repository.ListMachineSharesByMachine(ctx, m.ID)
+		if err != nil {
+			return nil, logError(err)
+		}
+
+		for _, share := range shares {
+			result, err := s.machineShareToApi(ctx, &share, m, true)
+			if err != nil {
+				return nil, logError(err)
+			}
+			response.Shares = append(response.Shares, result)
+		}
+
+		return connect.NewResponse(response), nil
+	}
+
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(req.Msg.TailnetId) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	shares, err := s.repository.ListMachineSharesByRecipient(ctx, req.Msg.TailnetId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	for _, share := range shares {
+		result, err := s.machineShareToApi(ctx, &share, &share.Machine, false)
+		if err != nil {
+			return nil, logError(err)
+		}
+		response.Shares = append(response.Shares, result)
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+func (s *Service) AcceptMachineShare(ctx context.Context, req *connect.Request[api.AcceptMachineShareRequest]) (*connect.Response[api.AcceptMachineShareResponse], error) {
+	principal := CurrentPrincipal(ctx)
+
+	share, err := s.repository.GetMachineShareByCode(ctx, req.Msg.Code)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if share == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("share not found"))
+	}
+
+	now := time.Now().UTC()
+
+	if share.IsTailnetInvite() {
+		tailnet, err := s.repository.GetTailnet(ctx, share.InviteTailnetID)
+		if err != nil {
+			return nil, logError(err)
+		}
+		if tailnet == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
+		}
+		if !principal.IsSystemAdmin() && !isAdminOfTailnet(principal, tailnet) {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+		}
+		err = share.AcceptByTailnet(tailnet.ID, now)
+	} else {
+		if principal.User == nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("a machine shared with a user is accepted by a user"))
+		}
+		err = share.AcceptByUser(principal.User, now)
+	}
+
+	if errors.Is(err, domain.ErrShareAlreadyAccepted) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	if err := s.repository.SaveMachineShare(ctx, share); err != nil {
+		return nil, logError(err)
+	}
+
+	m, err := s.repository.GetMachine(ctx, share.MachineID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if m == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("machine not found"))
+	}
+
+	s.sessionManager.NotifyAll(share.TailnetID)
+	s.sessionManager.NotifyAll(share.RecipientTailnetID)
+
+	result, err := s.machineShareToApi(ctx, share, m, false)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	return connect.NewResponse(&api.AcceptMachineShareResponse{Share: result}), nil
+}
+
+func (s *Service) DeleteMachineShare(ctx context.Context, req *connect.Request[api.DeleteMachineShareRequest]) (*connect.Response[api.DeleteMachineShareResponse], error) {
+	principal := CurrentPrincipal(ctx)
+
+	share, err := s.repository.GetMachineShare(ctx, req.Msg.ShareId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if share == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("share not found"))
+	}
+
+	// the share is revoked by the tailnet of the machine, or declined by the recipient tailnet
+	isRecipientAdmin := share.IsAccepted() && principal.IsTailnetAdmin(share.RecipientTailnetID)
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(share.TailnetID) && !isRecipientAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	if err := s.repository.DeleteMachineShare(ctx, share.ID); err != nil {
+		return nil, logError(err)
+	}
+
+	if share.IsAccepted() {
+		s.sessionManager.NotifyAll(share.TailnetID)
+		s.sessionManager.NotifyAll(share.RecipientTailnetID)
+	}
+
+	return connect.NewResponse(&api.DeleteMachineShareResponse{}), nil
+}
+
+func (s *Service) machineShareToApi(ctx context.Context, share *domain.MachineShare, m *domain.Machine, includeInvite bool) (*api.MachineShare, error) {
+	result := &api.MachineShare{
+		Id:              share.ID,
+		Machine:         &api.Ref{Id: m.ID, Name: m.CompleteName()},
+		Tailnet:         &api.Ref{Id: m.Tailnet.ID, Name: m.Tailnet.Name},
+		InviteLoginName: share.InviteLoginName,
+		InviteTailnetId: share.InviteTailnetID,
+		CreatedAt:       timestamppb.New(share.CreatedAt),
+	}
+
+	if includeInvite && !share.IsAccepted() {
+		result.InviteCode = share.Code
+		result.InviteUrl = s.config.CreateUrl("/a/m/%s", share.Code)
+	}
+
+	if !share.IsAccepted() {
+		return result, nil
+	}
+
+	result.AcceptedAt = timestamppb.New(*share.AcceptedAt)
+
+	tailnet, err := s.repository.GetTailnet(ctx, share.RecipientTailnetID)
+	if err != nil {
+		return nil, err
+	}
+	if tailnet != nil {
+		result.RecipientTailnet = &api.Ref{Id: tailnet.ID, Name: tailnet.Name}
+	}
+
+	if share.RecipientUserID != 0 {
+		user, err := s.repository.GetUser(ctx, share.RecipientUserID)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			result.RecipientUser = &api.Ref{Id: user.ID, Name: user.Name}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/dns"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/mapping"
+	"github.com/jsiebens/ionscale/internal/util"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"github.com/stretchr/testify/require"
+	"tailscale.com/tailcfg"
+	"testing"
+	"time"
+)
+
+func TestBulkDeleteSharedMachine(t *testing.T) {
+	ctx := context.Background()
+
+	repository := openTestRepository(t)
+
+	owner := createTestTailnet(t, repository, "owner")
+	recipient := createTestTailnet(t, repository, "recipient")
+
+	web := createTestMachine(t, repository, owner, "web", "100.64.0.1", "fd7a:115c:a1e0::1")
+	laptop := createTestMachine(t, repository, recipient, "laptop", "100.64.0.2", "fd7a:115c:a1e0::2")
+
+	now := time.Now().UTC()
+	share := &domain.MachineShare{ID: util.NextID(), Code: "code", MachineID: web.ID, TailnetID: owner.ID, InviteTailnetID: recipient.ID, CreatedAt: now}
+	require.NoError(t, share.AcceptByTailnet(recipient.ID, now))
+	require.NoError(t, repository.SaveMachineShare(ctx, share))
+
+	sessionManager := core.NewPollMapSessionManager()
+	mapper := mapping.NewPollNetMapper(&tailcfg.MapRequest{Version: 74}, laptop.ID, repository, sessionManager)
+
+	response, err := mapper.CreateMapResponse(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, response.Peers, 1)
+	require.Equal(t, tailcfg.NodeID(web.ID), response.Peers[0].ID)
+
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, sessionManager, nil)
+	adminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+
+	_, err = s.BulkDeleteMachines(adminCtx, connect.NewRequest(&api.BulkDeleteMachinesRequest{
+		Selector: &api.MachineSelector{TailnetId: owner.ID, Name: "web"},
+	}))
+	require.NoError(t, err)
+
+	shares, err := repository.ListMachineSharesByMachine(ctx, web.ID)
+	require.NoError(t, err)
+	require.Empty(t, shares)
+
+	response, err = mapping.NewPollNetMapper(&tailcfg.MapRequest{Version: 74}, laptop.ID, repository, sessionManager).CreateMapResponse(ctx, false)
+	require.NoError(t, err)
+	require.Empty(t, response.Peers)
+}
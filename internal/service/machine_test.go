@@ -0,0 +1,751 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/dns"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/mapping"
+	"github.com/jsiebens/ionscale/internal/util"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"github.com/stretchr/testify/require"
+	"net/netip"
+	"slices"
+	"tailscale.com/tailcfg"
+	"testing"
+	"time"
+)
+
+func TestGetLatestClientVersions(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	other := createTestTailnet(t, repository, "other")
+
+	for i, v := range []string{"1.62.0-t1a2b3c", "1.64.2-tabc", "1.9.0", "1.65.10-tdef", "1.64.2-tghi", ""} {
+		m := createTestMachine(t, repository, tailnet, fmt.Sprintf("m%d", i), fmt.Sprintf("100.64.0.%d", i+1), fmt.Sprintf("fd7a:115c:a1e0::%d", i+1))
+		m.HostInfo.IPNVersion = v
+		require.NoError(t, repository.SaveMachine(ctx, m))
+	}
+
+	m := createTestMachine(t, repository, other, "m", "100.64.1.1", "fd7a:115c:a1e0::101")
+	m.HostInfo.IPNVersion = "1.66.0-tabc"
+	require.NoError(t, repository.SaveMachine(ctx, m))
+
+	latest, err := repository.GetLatestClientVersions(ctx, tailnet.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.ClientVersions{Stable: "1.64.2", Unstable: "1.65.10"}, latest)
+}
+
+func TestGetMachineUpdateAvailable(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	m := createTestMachine(t, repository, tailnet, "laptop", "100.64.0.1", "fd7a:115c:a1e0::1")
+	m.HostInfo.IPNVersion = "1.64.2-tabc"
+	require.NoError(t, repository.SaveMachine(ctx, m))
+
+	adminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+
+	updateAvailable := func(c *config.Config) bool {
+		s := NewService(c, nil, nil, dns.NewPublisher(nil, repository), repository, core.NewPollMapSessionManager(), nil)
+		resp, err := s.GetMachine(adminCtx, connect.NewRequest(&api.GetMachineRequest{MachineId: m.ID}))
+		require.NoError(t, err)
+		return resp.Msg.Machine.UpdateAvailable
+	}
+
+	// the only machine of the tailnet runs the newest version in the tailnet
+	require.False(t, updateAvailable(&config.Config{}))
+
+	// a newer release is known
+	require.True(t, updateAvailable(&config.Config{ClientVersions: config.ClientVersions{Stable: "1.66.4"}}))
+	require.False(t, updateAvailable(&config.Config{ClientVersions: config.ClientVersions{Stable: "1.62.0", Unstable: "1.67.2"}}))
+}
+
+func TestGetMachineRoutesOnlyReadsPrimaryRouter(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	route := netip.MustParsePrefix("10.0.0.0/24")
+
+	var routers []*domain.Machine
+	for i, name := range []string{"r1", "r2"} {
+		m := createTestMachine(t, repository, tailnet, name, fmt.Sprintf("100.64.0.%d", i+1), fmt.Sprintf("fd7a:115c:a1e0::%d", i+1))
+		m.AllowIPs = []netip.Prefix{route}
+		require.NoError(t, repository.SaveMachine(ctx, m))
+		routers = append(routers, m)
+	}
+
+	sessionManager := core.NewPollMapSessionManager()
+	sessionManager.Register(tailnet.ID, routers[0].ID, make(chan *core.Ping, 1))
+	sessionManager.Register(tailnet.ID, routers[1].ID, make(chan *core.Ping, 1))
+
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, sessionManager, nil)
+	adminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+
+	getPrimary := func() map[string]uint64 {
+		resp, err := s.GetMachineRoutes(adminCtx, connect.NewRequest(&api.GetMachineRoutesRequest{MachineId: routers[1].ID}))
+		require.NoError(t, err)
+		return resp.Msg.Routes.PrimaryRouters
+	}
+
+	// no primary is elected before the tailnet is mapped
+	require.Empty(t, getPrimary())
+
+	_, err := mapping.NewPollNetMapper(&tailcfg.MapRequest{Version: 74}, routers[1].ID, repository, sessionManager).CreateMapResponse(ctx, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{route.String(): routers[0].ID}, getPrimary())
+
+	// the primary going offline is only picked up by the next map response
+	sessionManager.Deregister(tailnet.ID, routers[0].ID)
+	require.Equal(t, map[string]uint64{route.String(): routers[0].ID}, getPrimary())
+
+	_, err = mapping.NewPollNetMapper(&tailcfg.MapRequest{Version: 74, OmitPeers: true}, routers[1].ID, repository, sessionManager).CreateMapResponse(ctx, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{route.String(): routers[1].ID}, getPrimary())
+}
+
+func TestSetMachineTags(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	policy := tailnet.ACLPolicy.Get()
+	policy.TagOwners = map[string][]string{"tag:web": {"john@example.com"}, "tag:db": {"jane@example.com"}}
+	tailnet.ACLPolicy = domain.NewHuJSON(policy)
+	require.NoError(t, repository.SaveTailnet(ctx, tailnet))
+
+	john := createTestUser(t, repository, tailnet, "john@example.com")
+	serviceUser, _, err := repository.GetOrCreateServiceUser(ctx, tailnet)
+	require.NoError(t, err)
+
+	m := createTestMachine(t, repository, tailnet, "laptop", "100.64.0.1", "fd7a:115c:a1e0::1")
+	m.Tags = nil
+	m.RegisteredTags = nil
+	m.UserID = john.ID
+	m.ExpiresAt = time.Now().UTC().Add(time.Hour)
+	require.NoError(t, repository.SaveMachine(ctx, m))
+
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, core.NewPollMapSessionManager(), nil)
+	systemAdminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+	johnCtx := context.WithValue(ctx, principalKey, domain.Principal{User: john, UserRole: domain.UserRoleAdmin})
+
+	setTags := func(ctx context.Context, tags ...string) (*domain.Machine, error) {
+		_, err := s.SetMachineTags(ctx, connect.NewRequest(&api.SetMachineTagsRequest{MachineId: m.ID, Tags: tags}))
+		if err != nil {
+			return nil, err
+		}
+		updated, err := repository.GetMachine(ctx, m.ID)
+		require.NoError(t, err)
+		return updated, nil
+	}
+
+	t.Run("tag owners", func(t *testing.T) {
+		// a tailnet admin can only apply the tags it owns
+		_, err := setTags(johnCtx, "tag:db")
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = setTags(johnCtx, "tag:web", "tag:db")
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = setTags(johnCtx, "invalid")
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		unchanged, err := repository.GetMachine(ctx, m.ID)
+		require.NoError(t, err)
+		require.Empty(t, unchanged.Tags)
+		require.Equal(t, john.ID, unchanged.UserID)
+	})
+
+	t.Run("tagging moves the machine to the service user", func(t *testing.T) {
+		before := time.Now().UTC()
+		updated, err := setTags(johnCtx, "tag:web")
+		require.NoError(t, err)
+
+		require.Equal(t, domain.Tags{"tag:web"}, updated.Tags)
+		require.Equal(t, domain.Tags{"tag:web"}, updated.RegisteredTags)
+		require.Equal(t, serviceUser.ID, updated.UserID)
+		require.True(t, updated.KeyExpiryDisabled)
+		require.False(t, updated.ExpiresAt.Before(before.Add(domain.DefaultKeyExpiry)))
+	})
+
+	t.Run("retagging keeps the key expiry", func(t *testing.T) {
+		tagged, err := repository.GetMachine(ctx, m.ID)
+		require.NoError(t, err)
+
+		// a system admin is not restricted by the tag owners
+		updated, err := setTags(systemAdminCtx, "tag:db")
+		require.NoError(t, err)
+
+		require.Equal(t, domain.Tags{"tag:db"}, updated.Tags)
+		require.Equal(t, serviceUser.ID, updated.UserID)
+		require.True(t, updated.KeyExpiryDisabled)
+		require.Equal(t, tagged.ExpiresAt, updated.ExpiresAt)
+	})
+
+	t.Run("untagging hands the machine over to the user", func(t *testing.T) {
+		// a system admin isn't a user of the tailnet, so it can't take ownership
+		_, err := setTags(systemAdminCtx)
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+		before := time.Now().UTC()
+		updated, err := setTags(johnCtx)
+		require.NoError(t, err)
+
+		require.Empty(t, updated.Tags)
+		require.Equal(t, john.ID, updated.UserID)
+		require.False(t, updated.KeyExpiryDisabled)
+		require.False(t, updated.ExpiresAt.Before(before.Add(domain.DefaultKeyExpiry)))
+	})
+}
+
+func TestListMachinesByFilter(t *testing.T) {
+	testListMachinesByFilter(t, openTestRepository(t))
+}
+
+func TestPostgresListMachinesByFilter(t *testing.T) {
+	testListMachinesByFilter(t, openPostgresTestRepository(t))
+}
+
+func testListMachinesByFilter(t *testing.T, repository domain.Repository) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Second)
+
+	tailnet := createTestTailnet(t, repository, fmt.Sprintf("example-%d", util.NextID()))
+	john := createTestUser(t, repository, tailnet, "john@example.com")
+
+	var ip byte
+	create := func(name string, nameIdx uint64, tags domain.Tags, os string, lastSeen *time.Time, createdAt time.Time) *domain.Machine {
+		ip++
+		m := createTestMachine(t, repository, tailnet, fmt.Sprintf("tmp-%d", ip), fmt.Sprintf("100.64.0.%d", ip), fmt.Sprintf("fd7a:115c:a1e0::%d", ip))
+		m.Name = name
+		m.NameIdx = nameIdx
+		m.Tags = tags
+		m.HostInfo = domain.HostInfo{OS: os}
+		m.LastSeen = lastSeen
+		m.CreatedAt = createdAt
+		require.NoError(t, repository.SaveMachine(ctx, m))
+		return m
+	}
+	ago := func(d time.Duration) *time.Time {
+		v := base.Add(-d)
+		return &v
+	}
+
+	ci1 := create("ci", 0, domain.Tags{"tag:ci"}, "linux", ago(time.Hour), base)
+	ci2 := create("ci", 1, domain.Tags{"tag:ci"}, "linux", ago(48*time.Hour), base)
+	cia := create("cia", 0, domain.Tags{"tag:ci", "tag:db"}, "Linux", ago(10*time.Minute), base.Add(time.Minute))
+	web := create("web", 0, domain.Tags{"tag:web"}, "windows", nil, base.Add(-time.Minute))
+
+	ci2.ExpiresAt = base.Add(-time.Hour)
+	require.NoError(t, repository.SaveMachine(ctx, ci2))
+
+	web.Authorized = false
+	web.UserID = john.ID
+	require.NoError(t, repository.SaveMachine(ctx, web))
+
+	// machines of other tailnets are never listed
+	other := createTestTailnet(t, repository, fmt.Sprintf("other-%d", util.NextID()))
+	createTestMachine(t, repository, other, "ci", "100.64.1.1", "fd7a:115c:a1e0::1:1")
+
+	ids := func(machines ...*domain.Machine) []uint64 {
+		result := []uint64{}
+		for _, m := range machines {
+			result = append(result, m.ID)
+		}
+		return result
+	}
+	list := func(filter domain.MachineFilter) []uint64 {
+		filter.TailnetID = tailnet.ID
+		machines, err := repository.ListMachinesByFilter(ctx, filter)
+		require.NoError(t, err)
+		result := []uint64{}
+		for _, m := range machines {
+			result = append(result, m.ID)
+		}
+		return result
+	}
+
+	yes, no := true, false
+
+	t.Run("filters", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			filter   domain.MachineFilter
+			expected []uint64
+		}{
+			{"none", domain.MachineFilter{}, ids(ci1, ci2, cia, web)},
+			{"tag", domain.MachineFilter{Tag: "tag:ci"}, ids(ci1, ci2, cia)},
+			{"second tag", domain.MachineFilter{Tag: "tag:db"}, ids(cia)},
+			{"tag with wildcards", domain.MachineFilter{Tag: "tag:c_"}, ids()},
+			{"tag with percent", domain.MachineFilter{Tag: "tag:%"}, ids()},
+			{"user", domain.MachineFilter{UserID: john.ID}, ids(web)},
+			{"connected", domain.MachineFilter{Connected: &yes, ConnectedIDs: ids(ci1)}, ids(ci1)},
+			{"connected without sessions", domain.MachineFilter{Connected: &yes}, ids()},
+			{"disconnected", domain.MachineFilter{Connected: &no, ConnectedIDs: ids(ci1)}, ids(ci2, cia, web)},
+			{"expired", domain.MachineFilter{Expired: &yes}, ids(ci2)},
+			{"not expired", domain.MachineFilter{Expired: &no}, ids(ci1, cia, web)},
+			{"authorized", domain.MachineFilter{Authorized: &yes}, ids(ci1, ci2, cia)},
+			{"unauthorized", domain.MachineFilter{Authorized: &no}, ids(web)},
+			{"os", domain.MachineFilter{OS: "linux"}, ids(ci1, ci2, cia)},
+			{"os ignores case", domain.MachineFilter{OS: "WINDOWS"}, ids(web)},
+			{"name prefix ignores case", domain.MachineFilter{NamePrefix: "CI"}, ids(ci1, ci2, cia)},
+			{"name prefix with wildcards", domain.MachineFilter{NamePrefix: "c_"}, ids()},
+			{"name prefix with percent", domain.MachineFilter{NamePrefix: "%"}, ids()},
+			{"last seen after", domain.MachineFilter{LastSeenAfter: ago(2 * time.Hour)}, ids(ci1, cia)},
+			{"last seen before", domain.MachineFilter{LastSeenBefore: ago(2 * time.Hour)}, ids(ci2)},
+			{"combined", domain.MachineFilter{Tag: "tag:ci", OS: "linux", Expired: &no}, ids(ci1, cia)},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				require.Equal(t, tt.expected, list(tt.filter))
+			})
+		}
+	})
+
+	t.Run("order and pages", func(t *testing.T) {
+		tests := []struct {
+			orderBy  string
+			expected []uint64
+		}{
+			{domain.MachineOrderByName, ids(ci1, ci2, cia, web)},
+			{domain.MachineOrderByLastSeen, ids(web, ci2, ci1, cia)},
+			{domain.MachineOrderByCreatedAt, ids(web, ci1, ci2, cia)},
+		}
+
+		for _, tt := range tests {
+			for _, descending := range []bool{false, true} {
+				expected := slices.Clone(tt.expected)
+				if descending {
+					slices.Reverse(expected)
+				}
+
+				t.Run(fmt.Sprintf("%s descending=%t", tt.orderBy, descending), func(t *testing.T) {
+					filter := domain.MachineFilter{OrderBy: tt.orderBy, Descending: descending}
+					require.Equal(t, expected, list(filter))
+
+					for _, pageSize := range []int{1, 2, 3} {
+						var pages []uint64
+						filter := domain.MachineFilter{TailnetID: tailnet.ID, OrderBy: tt.orderBy, Descending: descending, Limit: pageSize}
+						for {
+							page, err := repository.ListMachinesByFilter(ctx, filter)
+							require.NoError(t, err)
+							for _, m := range page {
+								pages = append(pages, m.ID)
+							}
+							if len(page) < pageSize {
+								break
+							}
+							cursor := filter.CursorOf(&page[len(page)-1])
+							filter.After = &cursor
+						}
+						require.Equal(t, expected, pages, "page size %d", pageSize)
+					}
+				})
+			}
+		}
+	})
+}
+
+func TestListMachines(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	for i := 1; i <= 5; i++ {
+		m := createTestMachine(t, repository, tailnet, fmt.Sprintf("machine-%d", i), fmt.Sprintf("100.64.0.%d", i), fmt.Sprintf("fd7a:115c:a1e0::%d", i))
+		if i%2 == 0 {
+			lastSeen := time.Now().UTC().Add(-time.Duration(i) * time.Hour)
+			m.LastSeen = &lastSeen
+			require.NoError(t, repository.SaveMachine(ctx, m))
+		}
+	}
+
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, core.NewPollMapSessionManager(), nil)
+	adminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+
+	list := func(req *api.ListMachinesRequest) (*api.ListMachinesResponse, error) {
+		req.TailnetId = tailnet.ID
+		resp, err := s.ListMachines(adminCtx, connect.NewRequest(req))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	for _, orderBy := range []string{"", domain.MachineOrderByName, domain.MachineOrderByLastSeen, domain.MachineOrderByCreatedAt} {
+		for _, descending := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s descending=%t", orderBy, descending), func(t *testing.T) {
+				all, err := list(&api.ListMachinesRequest{OrderBy: orderBy, Descending: descending})
+				require.NoError(t, err)
+				require.Len(t, all.Machines, 5)
+				require.Empty(t, all.NextPageToken)
+
+				var expected []uint64
+				for _, m := range all.Machines {
+					expected = append(expected, m.Id)
+				}
+
+				var pages []uint64
+				req := &api.ListMachinesRequest{OrderBy: orderBy, Descending: descending, PageSize: 2}
+				for {
+					resp, err := list(req)
+					require.NoError(t, err)
+					require.LessOrEqual(t, len(resp.Machines), 2)
+					for _, m := range resp.Machines {
+						pages = append(pages, m.Id)
+					}
+					if resp.NextPageToken == "" {
+						break
+					}
+					req.PageToken = resp.NextPageToken
+				}
+				require.Equal(t, expected, pages)
+			})
+		}
+	}
+
+	t.Run("page size matching the number of machines", func(t *testing.T) {
+		resp, err := list(&api.ListMachinesRequest{PageSize: 5})
+		require.NoError(t, err)
+		require.Len(t, resp.Machines, 5)
+		require.Empty(t, resp.NextPageToken)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := list(&api.ListMachinesRequest{OrderBy: "ip"})
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		resp, err := list(&api.ListMachinesRequest{PageSize: 2})
+		require.NoError(t, err)
+
+		// a page token can only be used for the listing it was issued for
+		_, err = list(&api.ListMachinesRequest{PageSize: 2, PageToken: resp.NextPageToken, Descending: true})
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = list(&api.ListMachinesRequest{PageSize: 2, PageToken: resp.NextPageToken, OrderBy: domain.MachineOrderByLastSeen})
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestSetMachineAliases(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	tailnet.DNSConfig.ExtraRecords = []tailcfg.DNSRecord{{Name: "dns.internal", Value: "10.0.0.1"}}
+	require.NoError(t, repository.SaveTailnet(ctx, tailnet))
+
+	web := createTestMachine(t, repository, tailnet, "web", "100.64.0.1", "fd7a:115c:a1e0::1")
+	db := createTestMachine(t, repository, tailnet, "db", "100.64.0.2", "fd7a:115c:a1e0::2")
+
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, core.NewPollMapSessionManager(), nil)
+	adminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+
+	setAliases := func(m *domain.Machine, aliases ...string) error {
+		_, err := s.SetMachineAliases(adminCtx, connect.NewRequest(&api.SetMachineAliasesRequest{MachineId: m.ID, Aliases: aliases}))
+		return err
+	}
+	aliasesOf := func(m *domain.Machine) domain.Tags {
+		m, err := repository.GetMachine(ctx, m.ID)
+		require.NoError(t, err)
+		return m.Aliases
+	}
+
+	require.NoError(t, setAliases(web, "Grafana.Internal."))
+	require.Equal(t, domain.Tags{"grafana.internal"}, aliasesOf(web))
+
+	// the machine keeps its own aliases
+	require.NoError(t, setAliases(web, "grafana.internal", "prometheus.internal"))
+
+	for _, alias := range []string{"grafana.internal", "web.example.ionscale.net", "dns.internal."} {
+		err := setAliases(db, "db.internal", alias)
+		require.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err), alias)
+	}
+	require.Empty(t, aliasesOf(db))
+
+	err := setAliases(db, "invalid_alias!")
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	t.Run("concurrent updates claim an alias once", func(t *testing.T) {
+		var machines []*domain.Machine
+		for i := 0; i < 5; i++ {
+			machines = append(machines, createTestMachine(t, repository, tailnet, fmt.Sprintf("m%d", i), fmt.Sprintf("100.64.1.%d", i+1), fmt.Sprintf("fd7a:115c:a1e0::1:%d", i+1)))
+		}
+
+		errs := make(chan error, len(machines))
+		for _, m := range machines {
+			go func() { errs <- setAliases(m, "shared.internal") }()
+		}
+
+		var claimed int
+		for range machines {
+			if err := <-errs; err == nil {
+				claimed++
+			} else {
+				require.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+			}
+		}
+		require.Equal(t, 1, claimed)
+	})
+
+	t.Run("renaming skips names in use as an alias", func(t *testing.T) {
+		require.NoError(t, setAliases(web, "grafana.internal", "api.example.ionscale.net"))
+
+		resp, err := s.RenameMachine(adminCtx, connect.NewRequest(&api.RenameMachineRequest{MachineId: db.ID, Name: "api"}))
+		require.NoError(t, err)
+		require.Equal(t, "api-1", resp.Msg.Machine.Name)
+
+		// and the alias of a machine can't take the name of another machine
+		err = setAliases(web, "api-1.example.ionscale.net")
+		require.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+	})
+}
+
+func TestRenameMachine(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	web := createTestMachine(t, repository, tailnet, "web", "100.64.0.1", "fd7a:115c:a1e0::1")
+	laptop := createTestMachine(t, repository, tailnet, "laptop", "100.64.0.2", "fd7a:115c:a1e0::2")
+	laptop.HostInfo.Hostname = "Johns-Laptop"
+	require.NoError(t, repository.SaveMachine(ctx, laptop))
+
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, core.NewPollMapSessionManager(), nil)
+	adminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+
+	rename := func(m *domain.Machine, name string, autoGenerate bool) (*domain.Machine, error) {
+		_, err := s.RenameMachine(adminCtx, connect.NewRequest(&api.RenameMachineRequest{MachineId: m.ID, Name: name, AutoGenerateName: autoGenerate}))
+		if err != nil {
+			return nil, err
+		}
+		updated, err := repository.GetMachine(ctx, m.ID)
+		require.NoError(t, err)
+		return updated, nil
+	}
+
+	t.Run("custom name", func(t *testing.T) {
+		m, err := rename(laptop, "Build Server", false)
+		require.NoError(t, err)
+		require.Equal(t, "build-server", m.CompleteName())
+		require.True(t, m.CustomName)
+
+		// renaming to the current name keeps the index
+		m, err = rename(laptop, "build-server", false)
+		require.NoError(t, err)
+		require.Equal(t, "build-server", m.CompleteName())
+	})
+
+	t.Run("name in use", func(t *testing.T) {
+		m, err := rename(laptop, "web", false)
+		require.NoError(t, err)
+		require.Equal(t, "web-1", m.CompleteName())
+
+		// an index taken by a machine named after it is skipped
+		other := createTestMachine(t, repository, tailnet, "db", "100.64.0.3", "fd7a:115c:a1e0::3")
+		m, err = rename(other, "web-2", false)
+		require.NoError(t, err)
+		require.Equal(t, "web-2", m.CompleteName())
+
+		m, err = rename(web, "backend", false)
+		require.NoError(t, err)
+		m, err = rename(m, "web", false)
+		require.NoError(t, err)
+		require.Equal(t, "web-3", m.CompleteName())
+	})
+
+	t.Run("auto generated name", func(t *testing.T) {
+		m, err := rename(laptop, "", true)
+		require.NoError(t, err)
+		require.Equal(t, "johns-laptop", m.CompleteName())
+		require.False(t, m.CustomName)
+
+		m, err = rename(laptop, "laptop", false)
+		require.NoError(t, err)
+		require.Equal(t, "laptop", m.CompleteName())
+		require.True(t, m.CustomName)
+
+		m, err = rename(laptop, "", true)
+		require.NoError(t, err)
+		require.Equal(t, "johns-laptop", m.CompleteName())
+	})
+
+	t.Run("invalid names", func(t *testing.T) {
+		_, err := rename(laptop, "", false)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = rename(laptop, "!!!", false)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		// a machine without a hostname has no name to generate
+		_, err = rename(web, "", true)
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+		m, err := repository.GetMachine(ctx, web.ID)
+		require.NoError(t, err)
+		require.Equal(t, "web-3", m.CompleteName())
+	})
+}
+
+func TestMoveMachine(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	source := createTestTailnet(t, repository, "source")
+	target := createTestTailnet(t, repository, "target")
+	recipient := createTestTailnet(t, repository, "recipient")
+
+	john := createTestUser(t, repository, source, "john@example.com")
+
+	server := createTestMachine(t, repository, source, "web", "100.64.0.1", "fd7a:115c:a1e0::1")
+	server.KeySignature = []byte("signature")
+	server.Aliases = domain.Tags{"grafana.internal"}
+	require.NoError(t, repository.SaveMachine(ctx, server))
+
+	laptop := createTestMachine(t, repository, source, "laptop", "100.64.0.2", "fd7a:115c:a1e0::2")
+	laptop.Tags = nil
+	laptop.RegisteredTags = nil
+	laptop.UserID = john.ID
+	require.NoError(t, repository.SaveMachine(ctx, laptop))
+
+	now := time.Now().UTC()
+	share := &domain.MachineShare{ID: util.NextID(), Code: "code", MachineID: server.ID, TailnetID: source.ID, InviteTailnetID: recipient.ID, CreatedAt: now}
+	require.NoError(t, share.AcceptByTailnet(recipient.ID, now))
+	require.NoError(t, repository.SaveMachineShare(ctx, share))
+
+	// a machine with the same name and one using the alias of the moved machine
+	createTestMachine(t, repository, target, "web", "100.64.1.1", "fd7a:115c:a1e0::1:1")
+	db := createTestMachine(t, repository, target, "db", "100.64.1.2", "fd7a:115c:a1e0::1:2")
+	db.Aliases = domain.Tags{"grafana.internal"}
+	require.NoError(t, repository.SaveMachine(ctx, db))
+
+	sessionManager := core.NewPollMapSessionManager()
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, sessionManager, nil)
+	systemAdminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+	johnCtx := context.WithValue(ctx, principalKey, domain.Principal{User: john, UserRole: domain.UserRoleAdmin})
+
+	move := func(ctx context.Context, m *domain.Machine, tailnetID uint64) (*domain.Machine, error) {
+		_, err := s.MoveMachine(ctx, connect.NewRequest(&api.MoveMachineRequest{MachineId: m.ID, TailnetId: tailnetID}))
+		if err != nil {
+			return nil, err
+		}
+		moved, err := repository.GetMachine(ctx, m.ID)
+		require.NoError(t, err)
+		return moved, nil
+	}
+	requireInTailnet := func(m *domain.Machine, tailnetID uint64) {
+		current, err := repository.GetMachine(ctx, m.ID)
+		require.NoError(t, err)
+		require.Equal(t, tailnetID, current.TailnetID)
+	}
+	setPolicy := func(tailnet *domain.Tailnet, f func(acl *domain.ACLPolicy, iam *domain.IAMPolicy)) {
+		acl, iam := tailnet.ACLPolicy.Get(), tailnet.IAMPolicy.Get()
+		f(acl, iam)
+		tailnet.ACLPolicy, tailnet.IAMPolicy = domain.NewHuJSON(acl), domain.NewHuJSON(iam)
+		require.NoError(t, repository.SaveTailnet(ctx, tailnet))
+	}
+
+	t.Run("invalid targets", func(t *testing.T) {
+		_, err := move(systemAdminCtx, server, source.ID)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = move(systemAdminCtx, server, util.NextID())
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		// an admin of the source tailnet has to be an admin of the target tailnet as well
+		_, err = move(johnCtx, laptop, target.ID)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		requireInTailnet(laptop, source.ID)
+	})
+
+	t.Run("tags have to be defined in the target tailnet", func(t *testing.T) {
+		_, err := move(systemAdminCtx, server, target.ID)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		requireInTailnet(server, source.ID)
+	})
+
+	setPolicy(target, func(acl *domain.ACLPolicy, iam *domain.IAMPolicy) {
+		acl.TagOwners = map[string][]string{"tag:server": {"jane@example.com"}}
+		iam.Roles = map[string]domain.UserRole{"john@example.com": domain.UserRoleAdmin}
+	})
+
+	t.Run("tag owners are checked for tailnet admins", func(t *testing.T) {
+		_, err := move(johnCtx, server, target.ID)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		requireInTailnet(server, source.ID)
+	})
+
+	t.Run("aliases have to be available in the target tailnet", func(t *testing.T) {
+		_, err := move(systemAdminCtx, server, target.ID)
+		require.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+		requireInTailnet(server, source.ID)
+
+		db.Aliases = nil
+		require.NoError(t, repository.SaveMachine(ctx, db))
+	})
+
+	t.Run("tagged machine", func(t *testing.T) {
+		// a session of the target tailnet is notified about the new peer
+		updates := make(chan *core.Ping, 10)
+		sessionManager.Register(target.ID, db.ID, updates)
+		defer sessionManager.Deregister(target.ID, db.ID)
+
+		moved, err := move(systemAdminCtx, server, target.ID)
+		require.NoError(t, err)
+
+		serviceUser, _, err := repository.GetOrCreateServiceUser(ctx, target)
+		require.NoError(t, err)
+
+		require.Equal(t, target.ID, moved.TailnetID)
+		require.Equal(t, serviceUser.ID, moved.UserID)
+		require.Equal(t, "web-1", moved.CompleteName())
+		require.Equal(t, domain.Tags{"tag:server"}, moved.Tags)
+		require.Equal(t, domain.Tags{"grafana.internal"}, moved.Aliases)
+		require.Empty(t, moved.KeySignature)
+
+		// the machine keeps its addresses
+		require.Equal(t, server.IPv4.String(), moved.IPv4.String())
+		require.Equal(t, server.IPv6.String(), moved.IPv6.String())
+
+		// shares are granted by the previous tailnet
+		shares, err := repository.ListMachineSharesByMachine(ctx, server.ID)
+		require.NoError(t, err)
+		require.Empty(t, shares)
+
+		select {
+		case <-updates:
+		case <-time.After(time.Second):
+			require.Fail(t, "session of the target tailnet not notified")
+		}
+	})
+
+	t.Run("machine owned by a user", func(t *testing.T) {
+		moved, err := move(johnCtx, laptop, target.ID)
+		require.NoError(t, err)
+
+		owner, err := repository.GetUser(ctx, moved.UserID)
+		require.NoError(t, err)
+
+		require.Equal(t, target.ID, moved.TailnetID)
+		require.Equal(t, target.ID, owner.TailnetID)
+		require.Equal(t, john.AccountID, owner.AccountID)
+		require.Equal(t, "laptop", moved.CompleteName())
+	})
+
+	t.Run("target tailnet with tailnet lock", func(t *testing.T) {
+		authority := &domain.TailnetKeyAuthority{TailnetID: source.ID, State: domain.TailnetKeyAuthorityEnabled}
+		require.NoError(t, repository.SaveTailnetKeyAuthority(ctx, authority))
+
+		_, err := move(systemAdminCtx, server, source.ID)
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		requireInTailnet(server, target.ID)
+	})
+}
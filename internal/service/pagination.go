@@ -0,0 +1,91 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	"slices"
+	"strings"
+	"time"
+)
+
+const maxPageSize = 1000
+
+// pageToken is the position after the last item of a page, handed to the client as an opaque value.
+type pageToken struct {
+	ID      uint64     `json:"i,omitempty"`
+	Name    string     `json:"n,omitempty"`
+	NameIdx uint64     `json:"x,omitempty"`
+	Time    *time.Time `json:"t,omitempty"`
+
+	// Order is the order of the listing the token was issued for.
+	Order string `json:"o,omitempty"`
+}
+
+func (t pageToken) encode() string {
+	v, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(v)
+}
+
+// decodePageToken returns the position encoded in the token, which has to be issued for a listing in the same order.
+func decodePageToken(v string, order string) (*pageToken, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page token"))
+	}
+
+	var t pageToken
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page token"))
+	}
+
+	if t.Order != order {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("page token was issued for another order"))
+	}
+
+	return &t, nil
+}
+
+// listOrder validates the requested order, and returns the order of the listing as recorded in the page tokens.
+// The first of the supported values is the default.
+func listOrder(orderBy string, descending bool, supported ...string) (string, string, error) {
+	if orderBy == "" {
+		orderBy = supported[0]
+	}
+
+	if !slices.Contains(supported, orderBy) {
+		return "", "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid order, expected one of %s", strings.Join(supported, ", ")))
+	}
+
+	if descending {
+		return orderBy, "-" + orderBy, nil
+	}
+
+	return orderBy, orderBy, nil
+}
+
+// pageLimit returns how many items to query for a page, one more than the page size to find out
+// whether a next page exists, or 0 when all items are requested.
+func pageLimit(pageSize int32) (int, error) {
+	if pageSize < 0 {
+		return 0, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("page size must not be negative"))
+	}
+	if pageSize == 0 {
+		return 0, nil
+	}
+	return int(min(pageSize, maxPageSize)) + 1, nil
+}
+
+// nextPage drops the extra item queried according to pageLimit, and returns the last item of the page when a next page exists.
+func nextPage[T any](items []T, limit int) ([]T, *T) {
+	if limit == 0 || len(items) < limit {
+		return items, nil
+	}
+	items = items[:limit-1]
+	return items, &items[len(items)-1]
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"github.com/bufbuild/connect-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDecodePageToken(t *testing.T) {
+	now := time.Now().UTC()
+
+	token, err := decodePageToken(pageToken{ID: 10, Name: "web", NameIdx: 2, Time: &now, Order: "-last_seen"}.encode(), "-last_seen")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), token.ID)
+	assert.Equal(t, "web", token.Name)
+	assert.Equal(t, uint64(2), token.NameIdx)
+	assert.True(t, now.Equal(*token.Time))
+
+	token, err = decodePageToken("", "name")
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	for _, v := range []string{"not a token", "bm90IGpzb24"} {
+		_, err = decodePageToken(v, "name")
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	}
+
+	_, err = decodePageToken(pageToken{ID: 10, Order: "name"}.encode(), "-name")
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestListOrder(t *testing.T) {
+	orderBy, order, err := listOrder("", false, "name", "last_seen")
+	require.NoError(t, err)
+	assert.Equal(t, "name", orderBy)
+	assert.Equal(t, "name", order)
+
+	orderBy, order, err = listOrder("last_seen", true, "name", "last_seen")
+	require.NoError(t, err)
+	assert.Equal(t, "last_seen", orderBy)
+	assert.Equal(t, "-last_seen", order)
+
+	_, _, err = listOrder("created_at", false, "name", "last_seen")
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestPageLimit(t *testing.T) {
+	_, err := pageLimit(-1)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	for pageSize, expected := range map[int32]int{0: 0, 1: 2, 10: 11, maxPageSize: maxPageSize + 1, maxPageSize + 1: maxPageSize + 1} {
+		limit, err := pageLimit(pageSize)
+		require.NoError(t, err)
+		assert.Equal(t, expected, limit, "page size %d", pageSize)
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	items, last := nextPage([]int{1, 2, 3}, 0)
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.Nil(t, last)
+
+	items, last = nextPage([]int{1, 2}, 3)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Nil(t, last)
+
+	items, last = nextPage([]int{1, 2, 3}, 3)
+	assert.Equal(t, []int{1, 2}, items)
+	require.NotNil(t, last)
+	assert.Equal(t, 2, *last)
+}
@@ -15,13 +15,15 @@ import (
 	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
 )
 
-func NewService(config *config.Config, authProvider auth.Provider, dnsProvider dns.Provider, repository domain.Repository, sessionManager core.PollMapSessionManager) *Service {
+func NewService(config *config.Config, authProvider auth.Provider, dnsProvider dns.Provider, dnsPublisher dns.Publisher, repository domain.Repository, sessionManager core.PollMapSessionManager, notifier core.RequestNotifier) *Service {
 	return &Service{
 		config:         config,
 		authProvider:   authProvider,
 		dnsProvider:    dnsProvider,
+		dnsPublisher:   dnsPublisher,
 		repository:     repository,
 		sessionManager: sessionManager,
+		notifier:       notifier,
 	}
 }
 
@@ -29,8 +31,10 @@ type Service struct {
 	config         *config.Config
 	authProvider   auth.Provider
 	dnsProvider    dns.Provider
+	dnsPublisher   dns.Publisher
 	repository     domain.Repository
 	sessionManager core.PollMapSessionManager
+	notifier       core.RequestNotifier
 }
 
 func (s *Service) GetVersion(_ context.Context, _ *connect.Request[api.GetVersionRequest]) (*connect.Response[api.GetVersionResponse], error) {
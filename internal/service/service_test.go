@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/database"
+	"github.com/jsiebens/ionscale/internal/domain"
+	"github.com/jsiebens/ionscale/internal/util"
+	"github.com/jsiebens/ionscale/pkg/defaults"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/netip"
+	"os"
+	"tailscale.com/types/key"
+	"testing"
+	"time"
+)
+
+func openTestRepository(t *testing.T) domain.Repository {
+	t.Setenv("IONSCALE_MACHINE_ID", "1")
+
+	_, repository, err := database.OpenDB(&config.Database{
+		Type:         "sqlite",
+		Url:          t.TempDir() + "/ionscale.db?_pragma=busy_timeout(5000)",
+		MaxOpenConns: 1,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	return repository
+}
+
+// openPostgresTestRepository opens a postgres database when IONSCALE_TEST_POSTGRES_URL is set.
+func openPostgresTestRepository(t *testing.T) domain.Repository {
+	url := os.Getenv("IONSCALE_TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("IONSCALE_TEST_POSTGRES_URL not set")
+	}
+
+	t.Setenv("IONSCALE_MACHINE_ID", "1")
+
+	_, repository, err := database.OpenDB(&config.Database{
+		Type:         "postgres",
+		Url:          url,
+		MaxOpenConns: 5,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	return repository
+}
+
+func createTestTailnet(t *testing.T, repository domain.Repository, name string) *domain.Tailnet {
+	tailnet := &domain.Tailnet{
+		ID:        util.NextID(),
+		Name:      name,
+		IAMPolicy: domain.NewHuJSON(&domain.IAMPolicy{}),
+		ACLPolicy: domain.NewHuJSON(&domain.ACLPolicy{ACLPolicy: *defaults.DefaultACLPolicy()}),
+	}
+	require.NoError(t, repository.SaveTailnet(context.Background(), tailnet))
+	return tailnet
+}
+
+func createTestUser(t *testing.T, repository domain.Repository, tailnet *domain.Tailnet, loginName string) *domain.User {
+	account, _, err := repository.GetOrCreateAccount(context.Background(), loginName, loginName)
+	require.NoError(t, err)
+
+	user, _, err := repository.GetOrCreateUserWithAccount(context.Background(), tailnet, account)
+	require.NoError(t, err)
+	return user
+}
+
+func createTestMachine(t *testing.T, repository domain.Repository, tailnet *domain.Tailnet, name string, ipv4 string, ipv6 string) *domain.Machine {
+	user, _, err := repository.GetOrCreateServiceUser(context.Background(), tailnet)
+	require.NoError(t, err)
+
+	m := &domain.Machine{
+		ID:         util.NextID(),
+		Name:       name,
+		MachineKey: key.NewMachine().Public().String(),
+		NodeKey:    key.NewNode().Public().String(),
+		IPv4:       testIP(ipv4),
+		IPv6:       testIP(ipv6),
+		Authorized: true,
+		Tags:       domain.Tags{"tag:server"},
+		CreatedAt:  time.Now().UTC(),
+		ExpiresAt:  time.Now().UTC().Add(24 * time.Hour),
+		TailnetID:  tailnet.ID,
+		UserID:     user.ID,
+	}
+	require.NoError(t, repository.SaveMachine(context.Background(), m))
+	return m
+}
+
+func testIP(s string) domain.IP {
+	ip := netip.MustParseAddr(s)
+	return domain.IP{Addr: &ip}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/domain"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func (s *Service) ApproveSSHRequest(ctx context.Context, req *connect.Request[api.ApproveSSHRequestRequest]) (*connect.Response[api.ApproveSSHRequestResponse], error) {
+	principal := CurrentPrincipal(ctx)
+	if principal.User == nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	sshActionReq, err := s.repository.GetSSHActionRequest(ctx, req.Msg.Key)
+	if err != nil {
+		return nil, logError(err)
+	}
+	if sshActionReq == nil || !sshActionReq.RequiresApproval() || !principal.IsTailnetMember(sshActionReq.TailnetID) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("ssh request does not exist"))
+	}
+
+	tailnet, err := s.repository.GetTailnet(ctx, sshActionReq.TailnetID)
+	if err != nil {
+		return nil, logError(err)
+	}
+	if tailnet == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet does not exist"))
+	}
+
+	src, err := s.repository.GetMachine(ctx, sshActionReq.SrcMachineID)
+	if err != nil {
+		return nil, logError(err)
+	}
+	if src == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("machine does not exist"))
+	}
+
+	approver := principal.User.Name
+	if !sshActionReq.CanBeApprovedBy(*tailnet.ACLPolicy.Get(), &src.User, approver) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	var decided = true
+	err = s.repository.Transaction(func(tx domain.Repository) error {
+		// reload the request, the connecting user may have authenticated in the meantime
+		r, err := tx.GetSSHActionRequest(ctx, req.Msg.Key)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if r == nil || !r.IsPending() || r.IsExpired(now) {
+			decided = false
+			return nil
+		}
+
+		decision := domain.SSHApprovalApproved
+		if req.Msg.Reject {
+			r.Reject()
+			decision = domain.SSHApprovalRejected
+		} else {
+			r.Approve(approver)
+		}
+
+		if err := tx.SaveSSHActionRequest(ctx, r); err != nil {
+			return err
+		}
+
+		return tx.SaveSSHApproval(ctx, r.Audit(decision, approver, now))
+	})
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if !decided {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("ssh request is already decided or expired"))
+	}
+
+	s.notifier.Notify(core.SSHActionRequestTopic(req.Msg.Key))
+
+	zap.L().Info("ssh request decided by approver",
+		zap.String("key", req.Msg.Key),
+		zap.Bool("rejected", req.Msg.Reject),
+		zap.String("approver", approver))
+
+	return connect.NewResponse(&api.ApproveSSHRequestResponse{}), nil
+}
+
+func (s *Service) ListSSHSessionEvents(ctx context.Context, req *connect.Request[api.ListSSHSessionEventsRequest]) (*connect.Response[api.ListSSHSessionEventsResponse], error) {
+	principal := CurrentPrincipal(ctx)
+	if !principal.IsSystemAdmin() && !principal.IsTailnetAdmin(req.Msg.TailnetId) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+	}
+
+	tailnet, err := s.repository.GetTailnet(ctx, req.Msg.TailnetId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	if tailnet == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tailnet not found"))
+	}
+
+	filter := domain.SSHSessionEventFilter{
+		MachineID: req.Msg.MachineId,
+		Limit:     int(req.Msg.Limit),
+	}
+
+	if req.Msg.Since != nil {
+		since := req.Msg.Since.AsTime()
+		filter.Since = &since
+	}
+
+	events, err := s.repository.ListSSHSessionEvents(ctx, tailnet.ID, filter)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	response := &api.ListSSHSessionEventsResponse{}
+	for _, e := range events {
+		response.Events = append(response.Events, &api.SSHSessionEvent{
+			Id:             e.ID,
+			Type:           e.Type,
+			ConnectionId:   e.ConnectionID,
+			SrcMachineId:   e.SrcMachineID,
+			SrcMachineName: e.SrcMachineName,
+			DstMachineId:   e.DstMachineID,
+			DstMachineName: e.DstMachineName,
+			SshUser:        e.SSHUser,
+			LocalUser:      e.LocalUser,
+			Decision:       e.Decision,
+			Recording:      e.Recording,
+			CreatedAt:      timestamppb.New(e.CreatedAt),
+		})
+	}
+
+	return connect.NewResponse(response), nil
+}
@@ -61,6 +61,9 @@ func (s *Service) CreateTailnet(ctx context.Context, req *connect.Request[api.Cr
 		if err != nil {
 			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid acl policy: %w", err))
 		}
+		if err := newPolicy.Get().Validate(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid acl policy: %w", err))
+		}
 		aclPolicy = *newPolicy
 	}
 
@@ -125,6 +128,9 @@ func (s *Service) UpdateTailnet(ctx context.Context, req *connect.Request[api.Up
 		if err != nil {
 			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid acl policy: %w", err))
 		}
+		if err := newPolicy.Get().Validate(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid acl policy: %w", err))
+		}
 		tailnet.ACLPolicy = *newPolicy
 	}
 
@@ -142,6 +148,7 @@ func (s *Service) UpdateTailnet(ctx context.Context, req *connect.Request[api.Up
 	}
 
 	s.sessionManager.NotifyAll(tailnet.ID)
+	s.dnsPublisher.SyncTailnet(tailnet.ID)
 
 	t, err := domainTailnetToApiTailnet(tailnet)
 	if err != nil {
@@ -219,6 +226,11 @@ func (s *Service) DeleteTailnet(ctx context.Context, req *connect.Request[api.De
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("tailnet is not empty, number of machines: %d", count))
 	}
 
+	sharingTailnets, err := s.repository.ListSharingTailnets(ctx, req.Msg.TailnetId)
+	if err != nil {
+		return nil, logError(err)
+	}
+
 	err = s.repository.Transaction(func(tx domain.Repository) error {
 		if err := tx.DeleteMachineByTailnet(ctx, req.Msg.TailnetId); err != nil {
 			return err
@@ -236,6 +248,14 @@ func (s *Service) DeleteTailnet(ctx context.Context, req *connect.Request[api.De
 			return err
 		}
 
+		if err := tx.DeleteTailnetKeyAuthority(ctx, req.Msg.TailnetId); err != nil {
+			return err
+		}
+
+		if err := tx.DeleteMachineSharesByTailnet(ctx, req.Msg.TailnetId); err != nil {
+			return err
+		}
+
 		if err := tx.DeleteTailnet(ctx, req.Msg.TailnetId); err != nil {
 			return err
 		}
@@ -248,6 +268,10 @@ func (s *Service) DeleteTailnet(ctx context.Context, req *connect.Request[api.De
 	}
 
 	s.sessionManager.NotifyAll(req.Msg.TailnetId)
+	for _, id := range sharingTailnets {
+		s.sessionManager.NotifyAll(id)
+	}
+	s.dnsPublisher.SyncTailnet(req.Msg.TailnetId)
 
 	return connect.NewResponse(&api.DeleteTailnetResponse{}), nil
 }
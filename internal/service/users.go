@@ -24,12 +24,45 @@ func (s *Service) ListUsers(ctx context.Context, req *connect.Request[api.ListUs
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
 	}
 
-	users, err := s.repository.ListUsers(ctx, tailnet.ID)
+	orderBy, order, err := listOrder(req.Msg.OrderBy, req.Msg.Descending, domain.UserOrderByID, domain.UserOrderByName)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := decodePageToken(req.Msg.PageToken, order)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := pageLimit(req.Msg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.UserFilter{
+		TailnetID:  tailnet.ID,
+		NamePrefix: req.Msg.NamePrefix,
+		OrderBy:    orderBy,
+		Descending: req.Msg.Descending,
+		Limit:      limit,
+	}
+
+	if token != nil {
+		filter.After = &domain.UserCursor{ID: token.ID, Name: token.Name}
+	}
+
+	users, err := s.repository.ListUsers(ctx, filter)
 	if err != nil {
 		return nil, logError(err)
 	}
 
 	resp := &api.ListUsersResponse{}
+
+	users, last := nextPage(users, limit)
+	if last != nil {
+		resp.NextPageToken = pageToken{ID: last.ID, Name: last.Name, Order: order}.encode()
+	}
+
 	for _, u := range users {
 		resp.Users = append(resp.Users, &api.User{
 			Id:   u.ID,
@@ -65,6 +98,11 @@ func (s *Service) DeleteUser(ctx context.Context, req *connect.Request[api.Delet
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unable delete service account"))
 	}
 
+	sharingTailnets, err := s.repository.ListSharingTailnets(ctx, user.TailnetID)
+	if err != nil {
+		return nil, logError(err)
+	}
+
 	err = s.repository.Transaction(func(tx domain.Repository) error {
 		if err := tx.DeleteMachineByUser(ctx, req.Msg.UserId); err != nil {
 			return err
@@ -90,6 +128,10 @@ func (s *Service) DeleteUser(ctx context.Context, req *connect.Request[api.Delet
 	}
 
 	s.sessionManager.NotifyAll(user.TailnetID)
+	for _, id := range sharingTailnets {
+		s.sessionManager.NotifyAll(id)
+	}
+	s.dnsPublisher.SyncTailnet(user.TailnetID)
 
 	return connect.NewResponse(&api.DeleteUserResponse{}), nil
 }
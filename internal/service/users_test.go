@@ -0,0 +1,129 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/bufbuild/connect-go"
+	"github.com/jsiebens/ionscale/internal/config"
+	"github.com/jsiebens/ionscale/internal/core"
+	"github.com/jsiebens/ionscale/internal/dns"
+	"github.com/jsiebens/ionscale/internal/domain"
+	api "github.com/jsiebens/ionscale/pkg/gen/ionscale/v1"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+)
+
+func TestListUsersByFilter(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	alice := createTestUser(t, repository, tailnet, "Alice@example.com")
+	bob := createTestUser(t, repository, tailnet, "bob@example.com")
+	bobSmith := createTestUser(t, repository, tailnet, "bob_smith@example.com")
+	carol := createTestUser(t, repository, tailnet, "carol@example.com")
+
+	// service users and users of other tailnets are never listed
+	_, _, err := repository.GetOrCreateServiceUser(ctx, tailnet)
+	require.NoError(t, err)
+	createTestUser(t, repository, createTestTailnet(t, repository, "other"), "bob@example.com")
+
+	list := func(namePrefix string) []uint64 {
+		users, err := repository.ListUsers(ctx, domain.UserFilter{TailnetID: tailnet.ID, NamePrefix: namePrefix})
+		require.NoError(t, err)
+		result := []uint64{}
+		for _, u := range users {
+			result = append(result, u.ID)
+		}
+		return result
+	}
+
+	require.Equal(t, []uint64{alice.ID, bob.ID, bobSmith.ID, carol.ID}, list(""))
+	require.Equal(t, []uint64{alice.ID}, list("alice"))
+	require.Equal(t, []uint64{bob.ID, bobSmith.ID}, list("BOB"))
+	require.Equal(t, []uint64{bobSmith.ID}, list("bob_"))
+	require.Equal(t, []uint64{}, list("%"))
+}
+
+func TestListUsers(t *testing.T) {
+	ctx := context.Background()
+	repository := openTestRepository(t)
+
+	tailnet := createTestTailnet(t, repository, "example")
+	var users []*domain.User
+	for _, name := range []string{"dave@example.com", "alice@example.com", "carol@example.com", "bob@example.com", "erin@example.com"} {
+		users = append(users, createTestUser(t, repository, tailnet, name))
+	}
+
+	s := NewService(&config.Config{}, nil, nil, dns.NewPublisher(nil, repository), repository, core.NewPollMapSessionManager(), nil)
+	adminCtx := context.WithValue(ctx, principalKey, domain.Principal{SystemRole: domain.SystemRoleAdmin})
+
+	list := func(req *api.ListUsersRequest) (*api.ListUsersResponse, error) {
+		req.TailnetId = tailnet.ID
+		resp, err := s.ListUsers(adminCtx, connect.NewRequest(req))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	byID := slices.Clone(users)
+	slices.SortFunc(byID, func(a, b *domain.User) int { return cmp.Compare(a.ID, b.ID) })
+	byName := slices.Clone(users)
+	slices.SortFunc(byName, func(a, b *domain.User) int { return cmp.Compare(a.Name, b.Name) })
+
+	tests := []struct {
+		orderBy  string
+		expected []*domain.User
+	}{
+		{"", byID},
+		{domain.UserOrderByID, byID},
+		{domain.UserOrderByName, byName},
+	}
+
+	for _, tt := range tests {
+		for _, descending := range []bool{false, true} {
+			var expected []uint64
+			for _, u := range tt.expected {
+				expected = append(expected, u.ID)
+			}
+			if descending {
+				slices.Reverse(expected)
+			}
+
+			t.Run(fmt.Sprintf("%s descending=%t", tt.orderBy, descending), func(t *testing.T) {
+				var pages []uint64
+				req := &api.ListUsersRequest{OrderBy: tt.orderBy, Descending: descending, PageSize: 2}
+				for {
+					resp, err := list(req)
+					require.NoError(t, err)
+					require.LessOrEqual(t, len(resp.Users), 2)
+					for _, u := range resp.Users {
+						pages = append(pages, u.Id)
+					}
+					if resp.NextPageToken == "" {
+						break
+					}
+					req.PageToken = resp.NextPageToken
+				}
+				require.Equal(t, expected, pages)
+			})
+		}
+	}
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := list(&api.ListUsersRequest{OrderBy: "role"})
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = list(&api.ListUsersRequest{PageSize: -1})
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		resp, err := list(&api.ListUsersRequest{PageSize: 2})
+		require.NoError(t, err)
+
+		_, err = list(&api.ListUsersRequest{PageSize: 2, PageToken: resp.NextPageToken, OrderBy: domain.UserOrderByName})
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
@@ -58,3 +58,16 @@ func Checksum(v interface{}) string {
 	sum := md5.Sum(marshal)
 	return hex.EncodeToString(sum[:])
 }
+
+func CopyViaJson[F any, T any](f F, t T) error {
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(raw, t); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -26,6 +26,8 @@ type ACLPolicy struct {
 	SSH           []ACLSSH            `json:"ssh,omitempty" hujson:"SSH,omitempty"`
 	NodeAttrs     []ACLNodeAttrGrant  `json:"nodeAttrs,omitempty" hujson:"NodeAttrs,omitempty"`
 	Grants        []ACLGrant          `json:"grants,omitempty" hujson:"Grants,omitempty"`
+	AppConnectors []ACLAppConnector   `json:"appConnectors,omitempty" hujson:"AppConnectors,omitempty"`
+	Recorders     []string            `json:"recorders,omitempty" hujson:"Recorders,omitempty"`
 }
 
 func (a ACLPolicy) Marshal() string {
@@ -46,18 +48,25 @@ type ACLEntry struct {
 }
 
 type ACLSSH struct {
-	Action          string   `json:"action,omitempty" hujson:"Action,omitempty"`
-	Source          []string `json:"src,omitempty" hujson:"Src,omitempty"`
-	Destination     []string `json:"dst,omitempty" hujson:"Dst,omitempty"`
-	Users           []string `json:"users,omitempty" hujson:"Users,omitempty"`
-	CheckPeriod     string   `json:"checkPeriod,omitempty" hujson:"CheckPeriod,omitempty"`
-	Recorder        []string `json:"recorder,omitempty" hujson:"Recorder,omitempty"`
-	EnforceRecorder bool     `json:"enforceRecorder,omitempty" hujson:"EnforceRecorder,omitempty"`
+	Action                    string   `json:"action,omitempty" hujson:"Action,omitempty"`
+	Source                    []string `json:"src,omitempty" hujson:"Src,omitempty"`
+	Destination               []string `json:"dst,omitempty" hujson:"Dst,omitempty"`
+	Users                     []string `json:"users,omitempty" hujson:"Users,omitempty"`
+	CheckPeriod               string   `json:"checkPeriod,omitempty" hujson:"CheckPeriod,omitempty"`
+	Recorder                  []string `json:"recorder,omitempty" hujson:"Recorder,omitempty"`
+	EnforceRecorder           bool     `json:"enforceRecorder,omitempty" hujson:"EnforceRecorder,omitempty"`
+	Approvers                 []string `json:"approvers,omitempty" hujson:"Approvers,omitempty"`
+	SessionDuration           string   `json:"sessionDuration,omitempty" hujson:"SessionDuration,omitempty"`
+	AllowAgentForwarding      *bool    `json:"allowAgentForwarding,omitempty" hujson:"AllowAgentForwarding,omitempty"`
+	AllowLocalPortForwarding  *bool    `json:"allowLocalPortForwarding,omitempty" hujson:"AllowLocalPortForwarding,omitempty"`
+	AllowRemotePortForwarding bool     `json:"allowRemotePortForwarding,omitempty" hujson:"AllowRemotePortForwarding,omitempty"`
+	Message                   string   `json:"message,omitempty" hujson:"Message,omitempty"`
 }
 
 type ACLNodeAttrGrant struct {
-	Target []string `json:"target,omitempty" hujson:"Target,omitempty"`
-	Attr   []string `json:"attr,omitempty" hujson:"Attr,omitempty"`
+	Target []string           `json:"target,omitempty" hujson:"Target,omitempty"`
+	Attr   []string           `json:"attr,omitempty" hujson:"Attr,omitempty"`
+	App    tailcfg.NodeCapMap `json:"app,omitempty" hujson:"App,omitempty"`
 }
 
 type ACLGrant struct {
@@ -66,3 +75,9 @@ type ACLGrant struct {
 	IP          []tailcfg.ProtoPortRange `json:"ip,omitempty" hujson:"Ip,omitempty"`
 	App         tailcfg.PeerCapMap       `json:"app,omitempty" hujson:"App,omitempty"`
 }
+
+type ACLAppConnector struct {
+	Name       string   `json:"name,omitempty" hujson:"Name,omitempty"`
+	Connectors []string `json:"connectors,omitempty" hujson:"Connectors,omitempty"`
+	Domains    []string `json:"domains,omitempty" hujson:"Domains,omitempty"`
+}
@@ -90,6 +90,15 @@ const (
 	// IonscaleServiceDisableMachineAuthorizationProcedure is the fully-qualified name of the
 	// IonscaleService's DisableMachineAuthorization RPC.
 	IonscaleServiceDisableMachineAuthorizationProcedure = "/ionscale.v1.IonscaleService/DisableMachineAuthorization"
+	// IonscaleServiceGetMachineRetentionPolicyProcedure is the fully-qualified name of the
+	// IonscaleService's GetMachineRetentionPolicy RPC.
+	IonscaleServiceGetMachineRetentionPolicyProcedure = "/ionscale.v1.IonscaleService/GetMachineRetentionPolicy"
+	// IonscaleServiceSetMachineRetentionPolicyProcedure is the fully-qualified name of the
+	// IonscaleService's SetMachineRetentionPolicy RPC.
+	IonscaleServiceSetMachineRetentionPolicyProcedure = "/ionscale.v1.IonscaleService/SetMachineRetentionPolicy"
+	// IonscaleServicePreviewMachineRetentionPolicyProcedure is the fully-qualified name of the
+	// IonscaleService's PreviewMachineRetentionPolicy RPC.
+	IonscaleServicePreviewMachineRetentionPolicyProcedure = "/ionscale.v1.IonscaleService/PreviewMachineRetentionPolicy"
 	// IonscaleServiceGetDNSConfigProcedure is the fully-qualified name of the IonscaleService's
 	// GetDNSConfig RPC.
 	IonscaleServiceGetDNSConfigProcedure = "/ionscale.v1.IonscaleService/GetDNSConfig"
@@ -144,6 +153,30 @@ const (
 	// IonscaleServiceSetMachineKeyExpiryProcedure is the fully-qualified name of the IonscaleService's
 	// SetMachineKeyExpiry RPC.
 	IonscaleServiceSetMachineKeyExpiryProcedure = "/ionscale.v1.IonscaleService/SetMachineKeyExpiry"
+	// IonscaleServiceRenameMachineProcedure is the fully-qualified name of the IonscaleService's
+	// RenameMachine RPC.
+	IonscaleServiceRenameMachineProcedure = "/ionscale.v1.IonscaleService/RenameMachine"
+	// IonscaleServiceMoveMachineProcedure is the fully-qualified name of the IonscaleService's
+	// MoveMachine RPC.
+	IonscaleServiceMoveMachineProcedure = "/ionscale.v1.IonscaleService/MoveMachine"
+	// IonscaleServiceCreateMachineShareProcedure is the fully-qualified name of the IonscaleService's
+	// CreateMachineShare RPC.
+	IonscaleServiceCreateMachineShareProcedure = "/ionscale.v1.IonscaleService/CreateMachineShare"
+	// IonscaleServiceListMachineSharesProcedure is the fully-qualified name of the IonscaleService's
+	// ListMachineShares RPC.
+	IonscaleServiceListMachineSharesProcedure = "/ionscale.v1.IonscaleService/ListMachineShares"
+	// IonscaleServiceAcceptMachineShareProcedure is the fully-qualified name of the IonscaleService's
+	// AcceptMachineShare RPC.
+	IonscaleServiceAcceptMachineShareProcedure = "/ionscale.v1.IonscaleService/AcceptMachineShare"
+	// IonscaleServiceDeleteMachineShareProcedure is the fully-qualified name of the IonscaleService's
+	// DeleteMachineShare RPC.
+	IonscaleServiceDeleteMachineShareProcedure = "/ionscale.v1.IonscaleService/DeleteMachineShare"
+	// IonscaleServiceSetMachineAliasesProcedure is the fully-qualified name of the IonscaleService's
+	// SetMachineAliases RPC.
+	IonscaleServiceSetMachineAliasesProcedure = "/ionscale.v1.IonscaleService/SetMachineAliases"
+	// IonscaleServiceSetMachineTagsProcedure is the fully-qualified name of the IonscaleService's
+	// SetMachineTags RPC.
+	IonscaleServiceSetMachineTagsProcedure = "/ionscale.v1.IonscaleService/SetMachineTags"
 	// IonscaleServiceGetMachineRoutesProcedure is the fully-qualified name of the IonscaleService's
 	// GetMachineRoutes RPC.
 	IonscaleServiceGetMachineRoutesProcedure = "/ionscale.v1.IonscaleService/GetMachineRoutes"
@@ -153,12 +186,30 @@ const (
 	// IonscaleServiceDisableMachineRoutesProcedure is the fully-qualified name of the IonscaleService's
 	// DisableMachineRoutes RPC.
 	IonscaleServiceDisableMachineRoutesProcedure = "/ionscale.v1.IonscaleService/DisableMachineRoutes"
+	// IonscaleServiceBulkExpireMachinesProcedure is the fully-qualified name of the IonscaleService's
+	// BulkExpireMachines RPC.
+	IonscaleServiceBulkExpireMachinesProcedure = "/ionscale.v1.IonscaleService/BulkExpireMachines"
+	// IonscaleServiceBulkDeleteMachinesProcedure is the fully-qualified name of the IonscaleService's
+	// BulkDeleteMachines RPC.
+	IonscaleServiceBulkDeleteMachinesProcedure = "/ionscale.v1.IonscaleService/BulkDeleteMachines"
+	// IonscaleServiceBulkAuthorizeMachinesProcedure is the fully-qualified name of the
+	// IonscaleService's BulkAuthorizeMachines RPC.
+	IonscaleServiceBulkAuthorizeMachinesProcedure = "/ionscale.v1.IonscaleService/BulkAuthorizeMachines"
+	// IonscaleServiceBulkEnableMachineRoutesProcedure is the fully-qualified name of the
+	// IonscaleService's BulkEnableMachineRoutes RPC.
+	IonscaleServiceBulkEnableMachineRoutesProcedure = "/ionscale.v1.IonscaleService/BulkEnableMachineRoutes"
 	// IonscaleServiceEnableExitNodeProcedure is the fully-qualified name of the IonscaleService's
 	// EnableExitNode RPC.
 	IonscaleServiceEnableExitNodeProcedure = "/ionscale.v1.IonscaleService/EnableExitNode"
 	// IonscaleServiceDisableExitNodeProcedure is the fully-qualified name of the IonscaleService's
 	// DisableExitNode RPC.
 	IonscaleServiceDisableExitNodeProcedure = "/ionscale.v1.IonscaleService/DisableExitNode"
+	// IonscaleServiceApproveSSHRequestProcedure is the fully-qualified name of the IonscaleService's
+	// ApproveSSHRequest RPC.
+	IonscaleServiceApproveSSHRequestProcedure = "/ionscale.v1.IonscaleService/ApproveSSHRequest"
+	// IonscaleServiceListSSHSessionEventsProcedure is the fully-qualified name of the IonscaleService's
+	// ListSSHSessionEvents RPC.
+	IonscaleServiceListSSHSessionEventsProcedure = "/ionscale.v1.IonscaleService/ListSSHSessionEvents"
 )
 
 // IonscaleServiceClient is a client for the ionscale.v1.IonscaleService service.
@@ -182,6 +233,9 @@ type IonscaleServiceClient interface {
 	DisableSSH(context.Context, *connect_go.Request[v1.DisableSSHRequest]) (*connect_go.Response[v1.DisableSSHResponse], error)
 	EnableMachineAuthorization(context.Context, *connect_go.Request[v1.EnableMachineAuthorizationRequest]) (*connect_go.Response[v1.EnableMachineAuthorizationResponse], error)
 	DisableMachineAuthorization(context.Context, *connect_go.Request[v1.DisableMachineAuthorizationRequest]) (*connect_go.Response[v1.DisableMachineAuthorizationResponse], error)
+	GetMachineRetentionPolicy(context.Context, *connect_go.Request[v1.GetMachineRetentionPolicyRequest]) (*connect_go.Response[v1.GetMachineRetentionPolicyResponse], error)
+	SetMachineRetentionPolicy(context.Context, *connect_go.Request[v1.SetMachineRetentionPolicyRequest]) (*connect_go.Response[v1.SetMachineRetentionPolicyResponse], error)
+	PreviewMachineRetentionPolicy(context.Context, *connect_go.Request[v1.PreviewMachineRetentionPolicyRequest]) (*connect_go.Response[v1.PreviewMachineRetentionPolicyResponse], error)
 	GetDNSConfig(context.Context, *connect_go.Request[v1.GetDNSConfigRequest]) (*connect_go.Response[v1.GetDNSConfigResponse], error)
 	SetDNSConfig(context.Context, *connect_go.Request[v1.SetDNSConfigRequest]) (*connect_go.Response[v1.SetDNSConfigResponse], error)
 	GetIAMPolicy(context.Context, *connect_go.Request[v1.GetIAMPolicyRequest]) (*connect_go.Response[v1.GetIAMPolicyResponse], error)
@@ -200,11 +254,25 @@ type IonscaleServiceClient interface {
 	ExpireMachine(context.Context, *connect_go.Request[v1.ExpireMachineRequest]) (*connect_go.Response[v1.ExpireMachineResponse], error)
 	DeleteMachine(context.Context, *connect_go.Request[v1.DeleteMachineRequest]) (*connect_go.Response[v1.DeleteMachineResponse], error)
 	SetMachineKeyExpiry(context.Context, *connect_go.Request[v1.SetMachineKeyExpiryRequest]) (*connect_go.Response[v1.SetMachineKeyExpiryResponse], error)
+	RenameMachine(context.Context, *connect_go.Request[v1.RenameMachineRequest]) (*connect_go.Response[v1.RenameMachineResponse], error)
+	MoveMachine(context.Context, *connect_go.Request[v1.MoveMachineRequest]) (*connect_go.Response[v1.MoveMachineResponse], error)
+	CreateMachineShare(context.Context, *connect_go.Request[v1.CreateMachineShareRequest]) (*connect_go.Response[v1.CreateMachineShareResponse], error)
+	ListMachineShares(context.Context, *connect_go.Request[v1.ListMachineSharesRequest]) (*connect_go.Response[v1.ListMachineSharesResponse], error)
+	AcceptMachineShare(context.Context, *connect_go.Request[v1.AcceptMachineShareRequest]) (*connect_go.Response[v1.AcceptMachineShareResponse], error)
+	DeleteMachineShare(context.Context, *connect_go.Request[v1.DeleteMachineShareRequest]) (*connect_go.Response[v1.DeleteMachineShareResponse], error)
+	SetMachineAliases(context.Context, *connect_go.Request[v1.SetMachineAliasesRequest]) (*connect_go.Response[v1.SetMachineAliasesResponse], error)
+	SetMachineTags(context.Context, *connect_go.Request[v1.SetMachineTagsRequest]) (*connect_go.Response[v1.SetMachineTagsResponse], error)
 	GetMachineRoutes(context.Context, *connect_go.Request[v1.GetMachineRoutesRequest]) (*connect_go.Response[v1.GetMachineRoutesResponse], error)
 	EnableMachineRoutes(context.Context, *connect_go.Request[v1.EnableMachineRoutesRequest]) (*connect_go.Response[v1.EnableMachineRoutesResponse], error)
 	DisableMachineRoutes(context.Context, *connect_go.Request[v1.DisableMachineRoutesRequest]) (*connect_go.Response[v1.DisableMachineRoutesResponse], error)
+	BulkExpireMachines(context.Context, *connect_go.Request[v1.BulkExpireMachinesRequest]) (*connect_go.Response[v1.BulkExpireMachinesResponse], error)
+	BulkDeleteMachines(context.Context, *connect_go.Request[v1.BulkDeleteMachinesRequest]) (*connect_go.Response[v1.BulkDeleteMachinesResponse], error)
+	BulkAuthorizeMachines(context.Context, *connect_go.Request[v1.BulkAuthorizeMachinesRequest]) (*connect_go.Response[v1.BulkAuthorizeMachinesResponse], error)
+	BulkEnableMachineRoutes(context.Context, *connect_go.Request[v1.BulkEnableMachineRoutesRequest]) (*connect_go.Response[v1.BulkEnableMachineRoutesResponse], error)
 	EnableExitNode(context.Context, *connect_go.Request[v1.EnableExitNodeRequest]) (*connect_go.Response[v1.EnableExitNodeResponse], error)
 	DisableExitNode(context.Context, *connect_go.Request[v1.DisableExitNodeRequest]) (*connect_go.Response[v1.DisableExitNodeResponse], error)
+	ApproveSSHRequest(context.Context, *connect_go.Request[v1.ApproveSSHRequestRequest]) (*connect_go.Response[v1.ApproveSSHRequestResponse], error)
+	ListSSHSessionEvents(context.Context, *connect_go.Request[v1.ListSSHSessionEventsRequest]) (*connect_go.Response[v1.ListSSHSessionEventsResponse], error)
 }
 
 // NewIonscaleServiceClient constructs a client for the ionscale.v1.IonscaleService service. By
@@ -312,6 +380,21 @@ func NewIonscaleServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+IonscaleServiceDisableMachineAuthorizationProcedure,
 			opts...,
 		),
+		getMachineRetentionPolicy: connect_go.NewClient[v1.GetMachineRetentionPolicyRequest, v1.GetMachineRetentionPolicyResponse](
+			httpClient,
+			baseURL+IonscaleServiceGetMachineRetentionPolicyProcedure,
+			opts...,
+		),
+		setMachineRetentionPolicy: connect_go.NewClient[v1.SetMachineRetentionPolicyRequest, v1.SetMachineRetentionPolicyResponse](
+			httpClient,
+			baseURL+IonscaleServiceSetMachineRetentionPolicyProcedure,
+			opts...,
+		),
+		previewMachineRetentionPolicy: connect_go.NewClient[v1.PreviewMachineRetentionPolicyRequest, v1.PreviewMachineRetentionPolicyResponse](
+			httpClient,
+			baseURL+IonscaleServicePreviewMachineRetentionPolicyProcedure,
+			opts...,
+		),
 		getDNSConfig: connect_go.NewClient[v1.GetDNSConfigRequest, v1.GetDNSConfigResponse](
 			httpClient,
 			baseURL+IonscaleServiceGetDNSConfigProcedure,
@@ -402,6 +485,46 @@ func NewIonscaleServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+IonscaleServiceSetMachineKeyExpiryProcedure,
 			opts...,
 		),
+		renameMachine: connect_go.NewClient[v1.RenameMachineRequest, v1.RenameMachineResponse](
+			httpClient,
+			baseURL+IonscaleServiceRenameMachineProcedure,
+			opts...,
+		),
+		moveMachine: connect_go.NewClient[v1.MoveMachineRequest, v1.MoveMachineResponse](
+			httpClient,
+			baseURL+IonscaleServiceMoveMachineProcedure,
+			opts...,
+		),
+		createMachineShare: connect_go.NewClient[v1.CreateMachineShareRequest, v1.CreateMachineShareResponse](
+			httpClient,
+			baseURL+IonscaleServiceCreateMachineShareProcedure,
+			opts...,
+		),
+		listMachineShares: connect_go.NewClient[v1.ListMachineSharesRequest, v1.ListMachineSharesResponse](
+			httpClient,
+			baseURL+IonscaleServiceListMachineSharesProcedure,
+			opts...,
+		),
+		acceptMachineShare: connect_go.NewClient[v1.AcceptMachineShareRequest, v1.AcceptMachineShareResponse](
+			httpClient,
+			baseURL+IonscaleServiceAcceptMachineShareProcedure,
+			opts...,
+		),
+		deleteMachineShare: connect_go.NewClient[v1.DeleteMachineShareRequest, v1.DeleteMachineShareResponse](
+			httpClient,
+			baseURL+IonscaleServiceDeleteMachineShareProcedure,
+			opts...,
+		),
+		setMachineAliases: connect_go.NewClient[v1.SetMachineAliasesRequest, v1.SetMachineAliasesResponse](
+			httpClient,
+			baseURL+IonscaleServiceSetMachineAliasesProcedure,
+			opts...,
+		),
+		setMachineTags: connect_go.NewClient[v1.SetMachineTagsRequest, v1.SetMachineTagsResponse](
+			httpClient,
+			baseURL+IonscaleServiceSetMachineTagsProcedure,
+			opts...,
+		),
 		getMachineRoutes: connect_go.NewClient[v1.GetMachineRoutesRequest, v1.GetMachineRoutesResponse](
 			httpClient,
 			baseURL+IonscaleServiceGetMachineRoutesProcedure,
@@ -417,6 +540,26 @@ func NewIonscaleServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+IonscaleServiceDisableMachineRoutesProcedure,
 			opts...,
 		),
+		bulkExpireMachines: connect_go.NewClient[v1.BulkExpireMachinesRequest, v1.BulkExpireMachinesResponse](
+			httpClient,
+			baseURL+IonscaleServiceBulkExpireMachinesProcedure,
+			opts...,
+		),
+		bulkDeleteMachines: connect_go.NewClient[v1.BulkDeleteMachinesRequest, v1.BulkDeleteMachinesResponse](
+			httpClient,
+			baseURL+IonscaleServiceBulkDeleteMachinesProcedure,
+			opts...,
+		),
+		bulkAuthorizeMachines: connect_go.NewClient[v1.BulkAuthorizeMachinesRequest, v1.BulkAuthorizeMachinesResponse](
+			httpClient,
+			baseURL+IonscaleServiceBulkAuthorizeMachinesProcedure,
+			opts...,
+		),
+		bulkEnableMachineRoutes: connect_go.NewClient[v1.BulkEnableMachineRoutesRequest, v1.BulkEnableMachineRoutesResponse](
+			httpClient,
+			baseURL+IonscaleServiceBulkEnableMachineRoutesProcedure,
+			opts...,
+		),
 		enableExitNode: connect_go.NewClient[v1.EnableExitNodeRequest, v1.EnableExitNodeResponse](
 			httpClient,
 			baseURL+IonscaleServiceEnableExitNodeProcedure,
@@ -427,53 +570,80 @@ func NewIonscaleServiceClient(httpClient connect_go.HTTPClient, baseURL string,
 			baseURL+IonscaleServiceDisableExitNodeProcedure,
 			opts...,
 		),
+		approveSSHRequest: connect_go.NewClient[v1.ApproveSSHRequestRequest, v1.ApproveSSHRequestResponse](
+			httpClient,
+			baseURL+IonscaleServiceApproveSSHRequestProcedure,
+			opts...,
+		),
+		listSSHSessionEvents: connect_go.NewClient[v1.ListSSHSessionEventsRequest, v1.ListSSHSessionEventsResponse](
+			httpClient,
+			baseURL+IonscaleServiceListSSHSessionEventsProcedure,
+			opts...,
+		),
 	}
 }
 
 // ionscaleServiceClient implements IonscaleServiceClient.
 type ionscaleServiceClient struct {
-	getVersion                  *connect_go.Client[v1.GetVersionRequest, v1.GetVersionResponse]
-	authenticate                *connect_go.Client[v1.AuthenticateRequest, v1.AuthenticateResponse]
-	getDefaultDERPMap           *connect_go.Client[v1.GetDefaultDERPMapRequest, v1.GetDefaultDERPMapResponse]
-	createTailnet               *connect_go.Client[v1.CreateTailnetRequest, v1.CreateTailnetResponse]
-	updateTailnet               *connect_go.Client[v1.UpdateTailnetRequest, v1.UpdateTailnetResponse]
-	getTailnet                  *connect_go.Client[v1.GetTailnetRequest, v1.GetTailnetResponse]
-	listTailnets                *connect_go.Client[v1.ListTailnetsRequest, v1.ListTailnetsResponse]
-	deleteTailnet               *connect_go.Client[v1.DeleteTailnetRequest, v1.DeleteTailnetResponse]
-	getDERPMap                  *connect_go.Client[v1.GetDERPMapRequest, v1.GetDERPMapResponse]
-	setDERPMap                  *connect_go.Client[v1.SetDERPMapRequest, v1.SetDERPMapResponse]
-	resetDERPMap                *connect_go.Client[v1.ResetDERPMapRequest, v1.ResetDERPMapResponse]
-	enableFileSharing           *connect_go.Client[v1.EnableFileSharingRequest, v1.EnableFileSharingResponse]
-	disableFileSharing          *connect_go.Client[v1.DisableFileSharingRequest, v1.DisableFileSharingResponse]
-	enableServiceCollection     *connect_go.Client[v1.EnableServiceCollectionRequest, v1.EnableServiceCollectionResponse]
-	disableServiceCollection    *connect_go.Client[v1.DisableServiceCollectionRequest, v1.DisableServiceCollectionResponse]
-	enableSSH                   *connect_go.Client[v1.EnableSSHRequest, v1.EnableSSHResponse]
-	disableSSH                  *connect_go.Client[v1.DisableSSHRequest, v1.DisableSSHResponse]
-	enableMachineAuthorization  *connect_go.Client[v1.EnableMachineAuthorizationRequest, v1.EnableMachineAuthorizationResponse]
-	disableMachineAuthorization *connect_go.Client[v1.DisableMachineAuthorizationRequest, v1.DisableMachineAuthorizationResponse]
-	getDNSConfig                *connect_go.Client[v1.GetDNSConfigRequest, v1.GetDNSConfigResponse]
-	setDNSConfig                *connect_go.Client[v1.SetDNSConfigRequest, v1.SetDNSConfigResponse]
-	getIAMPolicy                *connect_go.Client[v1.GetIAMPolicyRequest, v1.GetIAMPolicyResponse]
-	setIAMPolicy                *connect_go.Client[v1.SetIAMPolicyRequest, v1.SetIAMPolicyResponse]
-	getACLPolicy                *connect_go.Client[v1.GetACLPolicyRequest, v1.GetACLPolicyResponse]
-	setACLPolicy                *connect_go.Client[v1.SetACLPolicyRequest, v1.SetACLPolicyResponse]
-	getAuthKey                  *connect_go.Client[v1.GetAuthKeyRequest, v1.GetAuthKeyResponse]
-	createAuthKey               *connect_go.Client[v1.CreateAuthKeyRequest, v1.CreateAuthKeyResponse]
-	deleteAuthKey               *connect_go.Client[v1.DeleteAuthKeyRequest, v1.DeleteAuthKeyResponse]
-	listAuthKeys                *connect_go.Client[v1.ListAuthKeysRequest, v1.ListAuthKeysResponse]
-	listUsers                   *connect_go.Client[v1.ListUsersRequest, v1.ListUsersResponse]
-	deleteUser                  *connect_go.Client[v1.DeleteUserRequest, v1.DeleteUserResponse]
-	getMachine                  *connect_go.Client[v1.GetMachineRequest, v1.GetMachineResponse]
-	listMachines                *connect_go.Client[v1.ListMachinesRequest, v1.ListMachinesResponse]
-	authorizeMachine            *connect_go.Client[v1.AuthorizeMachineRequest, v1.AuthorizeMachineResponse]
-	expireMachine               *connect_go.Client[v1.ExpireMachineRequest, v1.ExpireMachineResponse]
-	deleteMachine               *connect_go.Client[v1.DeleteMachineRequest, v1.DeleteMachineResponse]
-	setMachineKeyExpiry         *connect_go.Client[v1.SetMachineKeyExpiryRequest, v1.SetMachineKeyExpiryResponse]
-	getMachineRoutes            *connect_go.Client[v1.GetMachineRoutesRequest, v1.GetMachineRoutesResponse]
-	enableMachineRoutes         *connect_go.Client[v1.EnableMachineRoutesRequest, v1.EnableMachineRoutesResponse]
-	disableMachineRoutes        *connect_go.Client[v1.DisableMachineRoutesRequest, v1.DisableMachineRoutesResponse]
-	enableExitNode              *connect_go.Client[v1.EnableExitNodeRequest, v1.EnableExitNodeResponse]
-	disableExitNode             *connect_go.Client[v1.DisableExitNodeRequest, v1.DisableExitNodeResponse]
+	getVersion                    *connect_go.Client[v1.GetVersionRequest, v1.GetVersionResponse]
+	authenticate                  *connect_go.Client[v1.AuthenticateRequest, v1.AuthenticateResponse]
+	getDefaultDERPMap             *connect_go.Client[v1.GetDefaultDERPMapRequest, v1.GetDefaultDERPMapResponse]
+	createTailnet                 *connect_go.Client[v1.CreateTailnetRequest, v1.CreateTailnetResponse]
+	updateTailnet                 *connect_go.Client[v1.UpdateTailnetRequest, v1.UpdateTailnetResponse]
+	getTailnet                    *connect_go.Client[v1.GetTailnetRequest, v1.GetTailnetResponse]
+	listTailnets                  *connect_go.Client[v1.ListTailnetsRequest, v1.ListTailnetsResponse]
+	deleteTailnet                 *connect_go.Client[v1.DeleteTailnetRequest, v1.DeleteTailnetResponse]
+	getDERPMap                    *connect_go.Client[v1.GetDERPMapRequest, v1.GetDERPMapResponse]
+	setDERPMap                    *connect_go.Client[v1.SetDERPMapRequest, v1.SetDERPMapResponse]
+	resetDERPMap                  *connect_go.Client[v1.ResetDERPMapRequest, v1.ResetDERPMapResponse]
+	enableFileSharing             *connect_go.Client[v1.EnableFileSharingRequest, v1.EnableFileSharingResponse]
+	disableFileSharing            *connect_go.Client[v1.DisableFileSharingRequest, v1.DisableFileSharingResponse]
+	enableServiceCollection       *connect_go.Client[v1.EnableServiceCollectionRequest, v1.EnableServiceCollectionResponse]
+	disableServiceCollection      *connect_go.Client[v1.DisableServiceCollectionRequest, v1.DisableServiceCollectionResponse]
+	enableSSH                     *connect_go.Client[v1.EnableSSHRequest, v1.EnableSSHResponse]
+	disableSSH                    *connect_go.Client[v1.DisableSSHRequest, v1.DisableSSHResponse]
+	enableMachineAuthorization    *connect_go.Client[v1.EnableMachineAuthorizationRequest, v1.EnableMachineAuthorizationResponse]
+	disableMachineAuthorization   *connect_go.Client[v1.DisableMachineAuthorizationRequest, v1.DisableMachineAuthorizationResponse]
+	getMachineRetentionPolicy     *connect_go.Client[v1.GetMachineRetentionPolicyRequest, v1.GetMachineRetentionPolicyResponse]
+	setMachineRetentionPolicy     *connect_go.Client[v1.SetMachineRetentionPolicyRequest, v1.SetMachineRetentionPolicyResponse]
+	previewMachineRetentionPolicy *connect_go.Client[v1.PreviewMachineRetentionPolicyRequest, v1.PreviewMachineRetentionPolicyResponse]
+	getDNSConfig                  *connect_go.Client[v1.GetDNSConfigRequest, v1.GetDNSConfigResponse]
+	setDNSConfig                  *connect_go.Client[v1.SetDNSConfigRequest, v1.SetDNSConfigResponse]
+	getIAMPolicy                  *connect_go.Client[v1.GetIAMPolicyRequest, v1.GetIAMPolicyResponse]
+	setIAMPolicy                  *connect_go.Client[v1.SetIAMPolicyRequest, v1.SetIAMPolicyResponse]
+	getACLPolicy                  *connect_go.Client[v1.GetACLPolicyRequest, v1.GetACLPolicyResponse]
+	setACLPolicy                  *connect_go.Client[v1.SetACLPolicyRequest, v1.SetACLPolicyResponse]
+	getAuthKey                    *connect_go.Client[v1.GetAuthKeyRequest, v1.GetAuthKeyResponse]
+	createAuthKey                 *connect_go.Client[v1.CreateAuthKeyRequest, v1.CreateAuthKeyResponse]
+	deleteAuthKey                 *connect_go.Client[v1.DeleteAuthKeyRequest, v1.DeleteAuthKeyResponse]
+	listAuthKeys                  *connect_go.Client[v1.ListAuthKeysRequest, v1.ListAuthKeysResponse]
+	listUsers                     *connect_go.Client[v1.ListUsersRequest, v1.ListUsersResponse]
+	deleteUser                    *connect_go.Client[v1.DeleteUserRequest, v1.DeleteUserResponse]
+	getMachine                    *connect_go.Client[v1.GetMachineRequest, v1.GetMachineResponse]
+	listMachines                  *connect_go.Client[v1.ListMachinesRequest, v1.ListMachinesResponse]
+	authorizeMachine              *connect_go.Client[v1.AuthorizeMachineRequest, v1.AuthorizeMachineResponse]
+	expireMachine                 *connect_go.Client[v1.ExpireMachineRequest, v1.ExpireMachineResponse]
+	deleteMachine                 *connect_go.Client[v1.DeleteMachineRequest, v1.DeleteMachineResponse]
+	setMachineKeyExpiry           *connect_go.Client[v1.SetMachineKeyExpiryRequest, v1.SetMachineKeyExpiryResponse]
+	renameMachine                 *connect_go.Client[v1.RenameMachineRequest, v1.RenameMachineResponse]
+	moveMachine                   *connect_go.Client[v1.MoveMachineRequest, v1.MoveMachineResponse]
+	createMachineShare            *connect_go.Client[v1.CreateMachineShareRequest, v1.CreateMachineShareResponse]
+	listMachineShares             *connect_go.Client[v1.ListMachineSharesRequest, v1.ListMachineSharesResponse]
+	acceptMachineShare            *connect_go.Client[v1.AcceptMachineShareRequest, v1.AcceptMachineShareResponse]
+	deleteMachineShare            *connect_go.Client[v1.DeleteMachineShareRequest, v1.DeleteMachineShareResponse]
+	setMachineAliases             *connect_go.Client[v1.SetMachineAliasesRequest, v1.SetMachineAliasesResponse]
+	setMachineTags                *connect_go.Client[v1.SetMachineTagsRequest, v1.SetMachineTagsResponse]
+	getMachineRoutes              *connect_go.Client[v1.GetMachineRoutesRequest, v1.GetMachineRoutesResponse]
+	enableMachineRoutes           *connect_go.Client[v1.EnableMachineRoutesRequest, v1.EnableMachineRoutesResponse]
+	disableMachineRoutes          *connect_go.Client[v1.DisableMachineRoutesRequest, v1.DisableMachineRoutesResponse]
+	bulkExpireMachines            *connect_go.Client[v1.BulkExpireMachinesRequest, v1.BulkExpireMachinesResponse]
+	bulkDeleteMachines            *connect_go.Client[v1.BulkDeleteMachinesRequest, v1.BulkDeleteMachinesResponse]
+	bulkAuthorizeMachines         *connect_go.Client[v1.BulkAuthorizeMachinesRequest, v1.BulkAuthorizeMachinesResponse]
+	bulkEnableMachineRoutes       *connect_go.Client[v1.BulkEnableMachineRoutesRequest, v1.BulkEnableMachineRoutesResponse]
+	enableExitNode                *connect_go.Client[v1.EnableExitNodeRequest, v1.EnableExitNodeResponse]
+	disableExitNode               *connect_go.Client[v1.DisableExitNodeRequest, v1.DisableExitNodeResponse]
+	approveSSHRequest             *connect_go.Client[v1.ApproveSSHRequestRequest, v1.ApproveSSHRequestResponse]
+	listSSHSessionEvents          *connect_go.Client[v1.ListSSHSessionEventsRequest, v1.ListSSHSessionEventsResponse]
 }
 
 // GetVersion calls ionscale.v1.IonscaleService.GetVersion.
@@ -571,6 +741,21 @@ func (c *ionscaleServiceClient) DisableMachineAuthorization(ctx context.Context,
 	return c.disableMachineAuthorization.CallUnary(ctx, req)
 }
 
+// GetMachineRetentionPolicy calls ionscale.v1.IonscaleService.GetMachineRetentionPolicy.
+func (c *ionscaleServiceClient) GetMachineRetentionPolicy(ctx context.Context, req *connect_go.Request[v1.GetMachineRetentionPolicyRequest]) (*connect_go.Response[v1.GetMachineRetentionPolicyResponse], error) {
+	return c.getMachineRetentionPolicy.CallUnary(ctx, req)
+}
+
+// SetMachineRetentionPolicy calls ionscale.v1.IonscaleService.SetMachineRetentionPolicy.
+func (c *ionscaleServiceClient) SetMachineRetentionPolicy(ctx context.Context, req *connect_go.Request[v1.SetMachineRetentionPolicyRequest]) (*connect_go.Response[v1.SetMachineRetentionPolicyResponse], error) {
+	return c.setMachineRetentionPolicy.CallUnary(ctx, req)
+}
+
+// PreviewMachineRetentionPolicy calls ionscale.v1.IonscaleService.PreviewMachineRetentionPolicy.
+func (c *ionscaleServiceClient) PreviewMachineRetentionPolicy(ctx context.Context, req *connect_go.Request[v1.PreviewMachineRetentionPolicyRequest]) (*connect_go.Response[v1.PreviewMachineRetentionPolicyResponse], error) {
+	return c.previewMachineRetentionPolicy.CallUnary(ctx, req)
+}
+
 // GetDNSConfig calls ionscale.v1.IonscaleService.GetDNSConfig.
 func (c *ionscaleServiceClient) GetDNSConfig(ctx context.Context, req *connect_go.Request[v1.GetDNSConfigRequest]) (*connect_go.Response[v1.GetDNSConfigResponse], error) {
 	return c.getDNSConfig.CallUnary(ctx, req)
@@ -661,6 +846,46 @@ func (c *ionscaleServiceClient) SetMachineKeyExpiry(ctx context.Context, req *co
 	return c.setMachineKeyExpiry.CallUnary(ctx, req)
 }
 
+// RenameMachine calls ionscale.v1.IonscaleService.RenameMachine.
+func (c *ionscaleServiceClient) RenameMachine(ctx context.Context, req *connect_go.Request[v1.RenameMachineRequest]) (*connect_go.Response[v1.RenameMachineResponse], error) {
+	return c.renameMachine.CallUnary(ctx, req)
+}
+
+// MoveMachine calls ionscale.v1.IonscaleService.MoveMachine.
+func (c *ionscaleServiceClient) MoveMachine(ctx context.Context, req *connect_go.Request[v1.MoveMachineRequest]) (*connect_go.Response[v1.MoveMachineResponse], error) {
+	return c.moveMachine.CallUnary(ctx, req)
+}
+
+// CreateMachineShare calls ionscale.v1.IonscaleService.CreateMachineShare.
+func (c *ionscaleServiceClient) CreateMachineShare(ctx context.Context, req *connect_go.Request[v1.CreateMachineShareRequest]) (*connect_go.Response[v1.CreateMachineShareResponse], error) {
+	return c.createMachineShare.CallUnary(ctx, req)
+}
+
+// ListMachineShares calls ionscale.v1.IonscaleService.ListMachineShares.
+func (c *ionscaleServiceClient) ListMachineShares(ctx context.Context, req *connect_go.Request[v1.ListMachineSharesRequest]) (*connect_go.Response[v1.ListMachineSharesResponse], error) {
+	return c.listMachineShares.CallUnary(ctx, req)
+}
+
+// AcceptMachineShare calls ionscale.v1.IonscaleService.AcceptMachineShare.
+func (c *ionscaleServiceClient) AcceptMachineShare(ctx context.Context, req *connect_go.Request[v1.AcceptMachineShareRequest]) (*connect_go.Response[v1.AcceptMachineShareResponse], error) {
+	return c.acceptMachineShare.CallUnary(ctx, req)
+}
+
+// DeleteMachineShare calls ionscale.v1.IonscaleService.DeleteMachineShare.
+func (c *ionscaleServiceClient) DeleteMachineShare(ctx context.Context, req *connect_go.Request[v1.DeleteMachineShareRequest]) (*connect_go.Response[v1.DeleteMachineShareResponse], error) {
+	return c.deleteMachineShare.CallUnary(ctx, req)
+}
+
+// SetMachineAliases calls ionscale.v1.IonscaleService.SetMachineAliases.
+func (c *ionscaleServiceClient) SetMachineAliases(ctx context.Context, req *connect_go.Request[v1.SetMachineAliasesRequest]) (*connect_go.Response[v1.SetMachineAliasesResponse], error) {
+	return c.setMachineAliases.CallUnary(ctx, req)
+}
+
+// SetMachineTags calls ionscale.v1.IonscaleService.SetMachineTags.
+func (c *ionscaleServiceClient) SetMachineTags(ctx context.Context, req *connect_go.Request[v1.SetMachineTagsRequest]) (*connect_go.Response[v1.SetMachineTagsResponse], error) {
+	return c.setMachineTags.CallUnary(ctx, req)
+}
+
 // GetMachineRoutes calls ionscale.v1.IonscaleService.GetMachineRoutes.
 func (c *ionscaleServiceClient) GetMachineRoutes(ctx context.Context, req *connect_go.Request[v1.GetMachineRoutesRequest]) (*connect_go.Response[v1.GetMachineRoutesResponse], error) {
 	return c.getMachineRoutes.CallUnary(ctx, req)
@@ -676,6 +901,26 @@ func (c *ionscaleServiceClient) DisableMachineRoutes(ctx context.Context, req *c
 	return c.disableMachineRoutes.CallUnary(ctx, req)
 }
 
+// BulkExpireMachines calls ionscale.v1.IonscaleService.BulkExpireMachines.
+func (c *ionscaleServiceClient) BulkExpireMachines(ctx context.Context, req *connect_go.Request[v1.BulkExpireMachinesRequest]) (*connect_go.Response[v1.BulkExpireMachinesResponse], error) {
+	return c.bulkExpireMachines.CallUnary(ctx, req)
+}
+
+// BulkDeleteMachines calls ionscale.v1.IonscaleService.BulkDeleteMachines.
+func (c *ionscaleServiceClient) BulkDeleteMachines(ctx context.Context, req *connect_go.Request[v1.BulkDeleteMachinesRequest]) (*connect_go.Response[v1.BulkDeleteMachinesResponse], error) {
+	return c.bulkDeleteMachines.CallUnary(ctx, req)
+}
+
+// BulkAuthorizeMachines calls ionscale.v1.IonscaleService.BulkAuthorizeMachines.
+func (c *ionscaleServiceClient) BulkAuthorizeMachines(ctx context.Context, req *connect_go.Request[v1.BulkAuthorizeMachinesRequest]) (*connect_go.Response[v1.BulkAuthorizeMachinesResponse], error) {
+	return c.bulkAuthorizeMachines.CallUnary(ctx, req)
+}
+
+// BulkEnableMachineRoutes calls ionscale.v1.IonscaleService.BulkEnableMachineRoutes.
+func (c *ionscaleServiceClient) BulkEnableMachineRoutes(ctx context.Context, req *connect_go.Request[v1.BulkEnableMachineRoutesRequest]) (*connect_go.Response[v1.BulkEnableMachineRoutesResponse], error) {
+	return c.bulkEnableMachineRoutes.CallUnary(ctx, req)
+}
+
 // EnableExitNode calls ionscale.v1.IonscaleService.EnableExitNode.
 func (c *ionscaleServiceClient) EnableExitNode(ctx context.Context, req *connect_go.Request[v1.EnableExitNodeRequest]) (*connect_go.Response[v1.EnableExitNodeResponse], error) {
 	return c.enableExitNode.CallUnary(ctx, req)
@@ -686,6 +931,16 @@ func (c *ionscaleServiceClient) DisableExitNode(ctx context.Context, req *connec
 	return c.disableExitNode.CallUnary(ctx, req)
 }
 
+// ApproveSSHRequest calls ionscale.v1.IonscaleService.ApproveSSHRequest.
+func (c *ionscaleServiceClient) ApproveSSHRequest(ctx context.Context, req *connect_go.Request[v1.ApproveSSHRequestRequest]) (*connect_go.Response[v1.ApproveSSHRequestResponse], error) {
+	return c.approveSSHRequest.CallUnary(ctx, req)
+}
+
+// ListSSHSessionEvents calls ionscale.v1.IonscaleService.ListSSHSessionEvents.
+func (c *ionscaleServiceClient) ListSSHSessionEvents(ctx context.Context, req *connect_go.Request[v1.ListSSHSessionEventsRequest]) (*connect_go.Response[v1.ListSSHSessionEventsResponse], error) {
+	return c.listSSHSessionEvents.CallUnary(ctx, req)
+}
+
 // IonscaleServiceHandler is an implementation of the ionscale.v1.IonscaleService service.
 type IonscaleServiceHandler interface {
 	GetVersion(context.Context, *connect_go.Request[v1.GetVersionRequest]) (*connect_go.Response[v1.GetVersionResponse], error)
@@ -707,6 +962,9 @@ type IonscaleServiceHandler interface {
 	DisableSSH(context.Context, *connect_go.Request[v1.DisableSSHRequest]) (*connect_go.Response[v1.DisableSSHResponse], error)
 	EnableMachineAuthorization(context.Context, *connect_go.Request[v1.EnableMachineAuthorizationRequest]) (*connect_go.Response[v1.EnableMachineAuthorizationResponse], error)
 	DisableMachineAuthorization(context.Context, *connect_go.Request[v1.DisableMachineAuthorizationRequest]) (*connect_go.Response[v1.DisableMachineAuthorizationResponse], error)
+	GetMachineRetentionPolicy(context.Context, *connect_go.Request[v1.GetMachineRetentionPolicyRequest]) (*connect_go.Response[v1.GetMachineRetentionPolicyResponse], error)
+	SetMachineRetentionPolicy(context.Context, *connect_go.Request[v1.SetMachineRetentionPolicyRequest]) (*connect_go.Response[v1.SetMachineRetentionPolicyResponse], error)
+	PreviewMachineRetentionPolicy(context.Context, *connect_go.Request[v1.PreviewMachineRetentionPolicyRequest]) (*connect_go.Response[v1.PreviewMachineRetentionPolicyResponse], error)
 	GetDNSConfig(context.Context, *connect_go.Request[v1.GetDNSConfigRequest]) (*connect_go.Response[v1.GetDNSConfigResponse], error)
 	SetDNSConfig(context.Context, *connect_go.Request[v1.SetDNSConfigRequest]) (*connect_go.Response[v1.SetDNSConfigResponse], error)
 	GetIAMPolicy(context.Context, *connect_go.Request[v1.GetIAMPolicyRequest]) (*connect_go.Response[v1.GetIAMPolicyResponse], error)
@@ -725,11 +983,25 @@ type IonscaleServiceHandler interface {
 	ExpireMachine(context.Context, *connect_go.Request[v1.ExpireMachineRequest]) (*connect_go.Response[v1.ExpireMachineResponse], error)
 	DeleteMachine(context.Context, *connect_go.Request[v1.DeleteMachineRequest]) (*connect_go.Response[v1.DeleteMachineResponse], error)
 	SetMachineKeyExpiry(context.Context, *connect_go.Request[v1.SetMachineKeyExpiryRequest]) (*connect_go.Response[v1.SetMachineKeyExpiryResponse], error)
+	RenameMachine(context.Context, *connect_go.Request[v1.RenameMachineRequest]) (*connect_go.Response[v1.RenameMachineResponse], error)
+	MoveMachine(context.Context, *connect_go.Request[v1.MoveMachineRequest]) (*connect_go.Response[v1.MoveMachineResponse], error)
+	CreateMachineShare(context.Context, *connect_go.Request[v1.CreateMachineShareRequest]) (*connect_go.Response[v1.CreateMachineShareResponse], error)
+	ListMachineShares(context.Context, *connect_go.Request[v1.ListMachineSharesRequest]) (*connect_go.Response[v1.ListMachineSharesResponse], error)
+	AcceptMachineShare(context.Context, *connect_go.Request[v1.AcceptMachineShareRequest]) (*connect_go.Response[v1.AcceptMachineShareResponse], error)
+	DeleteMachineShare(context.Context, *connect_go.Request[v1.DeleteMachineShareRequest]) (*connect_go.Response[v1.DeleteMachineShareResponse], error)
+	SetMachineAliases(context.Context, *connect_go.Request[v1.SetMachineAliasesRequest]) (*connect_go.Response[v1.SetMachineAliasesResponse], error)
+	SetMachineTags(context.Context, *connect_go.Request[v1.SetMachineTagsRequest]) (*connect_go.Response[v1.SetMachineTagsResponse], error)
 	GetMachineRoutes(context.Context, *connect_go.Request[v1.GetMachineRoutesRequest]) (*connect_go.Response[v1.GetMachineRoutesResponse], error)
 	EnableMachineRoutes(context.Context, *connect_go.Request[v1.EnableMachineRoutesRequest]) (*connect_go.Response[v1.EnableMachineRoutesResponse], error)
 	DisableMachineRoutes(context.Context, *connect_go.Request[v1.DisableMachineRoutesRequest]) (*connect_go.Response[v1.DisableMachineRoutesResponse], error)
+	BulkExpireMachines(context.Context, *connect_go.Request[v1.BulkExpireMachinesRequest]) (*connect_go.Response[v1.BulkExpireMachinesResponse], error)
+	BulkDeleteMachines(context.Context, *connect_go.Request[v1.BulkDeleteMachinesRequest]) (*connect_go.Response[v1.BulkDeleteMachinesResponse], error)
+	BulkAuthorizeMachines(context.Context, *connect_go.Request[v1.BulkAuthorizeMachinesRequest]) (*connect_go.Response[v1.BulkAuthorizeMachinesResponse], error)
+	BulkEnableMachineRoutes(context.Context, *connect_go.Request[v1.BulkEnableMachineRoutesRequest]) (*connect_go.Response[v1.BulkEnableMachineRoutesResponse], error)
 	EnableExitNode(context.Context, *connect_go.Request[v1.EnableExitNodeRequest]) (*connect_go.Response[v1.EnableExitNodeResponse], error)
 	DisableExitNode(context.Context, *connect_go.Request[v1.DisableExitNodeRequest]) (*connect_go.Response[v1.DisableExitNodeResponse], error)
+	ApproveSSHRequest(context.Context, *connect_go.Request[v1.ApproveSSHRequestRequest]) (*connect_go.Response[v1.ApproveSSHRequestResponse], error)
+	ListSSHSessionEvents(context.Context, *connect_go.Request[v1.ListSSHSessionEventsRequest]) (*connect_go.Response[v1.ListSSHSessionEventsResponse], error)
 }
 
 // NewIonscaleServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -833,6 +1105,21 @@ func NewIonscaleServiceHandler(svc IonscaleServiceHandler, opts ...connect_go.Ha
 		svc.DisableMachineAuthorization,
 		opts...,
 	)
+	ionscaleServiceGetMachineRetentionPolicyHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceGetMachineRetentionPolicyProcedure,
+		svc.GetMachineRetentionPolicy,
+		opts...,
+	)
+	ionscaleServiceSetMachineRetentionPolicyHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceSetMachineRetentionPolicyProcedure,
+		svc.SetMachineRetentionPolicy,
+		opts...,
+	)
+	ionscaleServicePreviewMachineRetentionPolicyHandler := connect_go.NewUnaryHandler(
+		IonscaleServicePreviewMachineRetentionPolicyProcedure,
+		svc.PreviewMachineRetentionPolicy,
+		opts...,
+	)
 	ionscaleServiceGetDNSConfigHandler := connect_go.NewUnaryHandler(
 		IonscaleServiceGetDNSConfigProcedure,
 		svc.GetDNSConfig,
@@ -923,6 +1210,46 @@ func NewIonscaleServiceHandler(svc IonscaleServiceHandler, opts ...connect_go.Ha
 		svc.SetMachineKeyExpiry,
 		opts...,
 	)
+	ionscaleServiceRenameMachineHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceRenameMachineProcedure,
+		svc.RenameMachine,
+		opts...,
+	)
+	ionscaleServiceMoveMachineHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceMoveMachineProcedure,
+		svc.MoveMachine,
+		opts...,
+	)
+	ionscaleServiceCreateMachineShareHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceCreateMachineShareProcedure,
+		svc.CreateMachineShare,
+		opts...,
+	)
+	ionscaleServiceListMachineSharesHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceListMachineSharesProcedure,
+		svc.ListMachineShares,
+		opts...,
+	)
+	ionscaleServiceAcceptMachineShareHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceAcceptMachineShareProcedure,
+		svc.AcceptMachineShare,
+		opts...,
+	)
+	ionscaleServiceDeleteMachineShareHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceDeleteMachineShareProcedure,
+		svc.DeleteMachineShare,
+		opts...,
+	)
+	ionscaleServiceSetMachineAliasesHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceSetMachineAliasesProcedure,
+		svc.SetMachineAliases,
+		opts...,
+	)
+	ionscaleServiceSetMachineTagsHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceSetMachineTagsProcedure,
+		svc.SetMachineTags,
+		opts...,
+	)
 	ionscaleServiceGetMachineRoutesHandler := connect_go.NewUnaryHandler(
 		IonscaleServiceGetMachineRoutesProcedure,
 		svc.GetMachineRoutes,
@@ -938,6 +1265,26 @@ func NewIonscaleServiceHandler(svc IonscaleServiceHandler, opts ...connect_go.Ha
 		svc.DisableMachineRoutes,
 		opts...,
 	)
+	ionscaleServiceBulkExpireMachinesHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceBulkExpireMachinesProcedure,
+		svc.BulkExpireMachines,
+		opts...,
+	)
+	ionscaleServiceBulkDeleteMachinesHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceBulkDeleteMachinesProcedure,
+		svc.BulkDeleteMachines,
+		opts...,
+	)
+	ionscaleServiceBulkAuthorizeMachinesHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceBulkAuthorizeMachinesProcedure,
+		svc.BulkAuthorizeMachines,
+		opts...,
+	)
+	ionscaleServiceBulkEnableMachineRoutesHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceBulkEnableMachineRoutesProcedure,
+		svc.BulkEnableMachineRoutes,
+		opts...,
+	)
 	ionscaleServiceEnableExitNodeHandler := connect_go.NewUnaryHandler(
 		IonscaleServiceEnableExitNodeProcedure,
 		svc.EnableExitNode,
@@ -948,6 +1295,16 @@ func NewIonscaleServiceHandler(svc IonscaleServiceHandler, opts ...connect_go.Ha
 		svc.DisableExitNode,
 		opts...,
 	)
+	ionscaleServiceApproveSSHRequestHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceApproveSSHRequestProcedure,
+		svc.ApproveSSHRequest,
+		opts...,
+	)
+	ionscaleServiceListSSHSessionEventsHandler := connect_go.NewUnaryHandler(
+		IonscaleServiceListSSHSessionEventsProcedure,
+		svc.ListSSHSessionEvents,
+		opts...,
+	)
 	return "/ionscale.v1.IonscaleService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case IonscaleServiceGetVersionProcedure:
@@ -988,6 +1345,12 @@ func NewIonscaleServiceHandler(svc IonscaleServiceHandler, opts ...connect_go.Ha
 			ionscaleServiceEnableMachineAuthorizationHandler.ServeHTTP(w, r)
 		case IonscaleServiceDisableMachineAuthorizationProcedure:
 			ionscaleServiceDisableMachineAuthorizationHandler.ServeHTTP(w, r)
+		case IonscaleServiceGetMachineRetentionPolicyProcedure:
+			ionscaleServiceGetMachineRetentionPolicyHandler.ServeHTTP(w, r)
+		case IonscaleServiceSetMachineRetentionPolicyProcedure:
+			ionscaleServiceSetMachineRetentionPolicyHandler.ServeHTTP(w, r)
+		case IonscaleServicePreviewMachineRetentionPolicyProcedure:
+			ionscaleServicePreviewMachineRetentionPolicyHandler.ServeHTTP(w, r)
 		case IonscaleServiceGetDNSConfigProcedure:
 			ionscaleServiceGetDNSConfigHandler.ServeHTTP(w, r)
 		case IonscaleServiceSetDNSConfigProcedure:
@@ -1024,16 +1387,44 @@ func NewIonscaleServiceHandler(svc IonscaleServiceHandler, opts ...connect_go.Ha
 			ionscaleServiceDeleteMachineHandler.ServeHTTP(w, r)
 		case IonscaleServiceSetMachineKeyExpiryProcedure:
 			ionscaleServiceSetMachineKeyExpiryHandler.ServeHTTP(w, r)
+		case IonscaleServiceRenameMachineProcedure:
+			ionscaleServiceRenameMachineHandler.ServeHTTP(w, r)
+		case IonscaleServiceMoveMachineProcedure:
+			ionscaleServiceMoveMachineHandler.ServeHTTP(w, r)
+		case IonscaleServiceCreateMachineShareProcedure:
+			ionscaleServiceCreateMachineShareHandler.ServeHTTP(w, r)
+		case IonscaleServiceListMachineSharesProcedure:
+			ionscaleServiceListMachineSharesHandler.ServeHTTP(w, r)
+		case IonscaleServiceAcceptMachineShareProcedure:
+			ionscaleServiceAcceptMachineShareHandler.ServeHTTP(w, r)
+		case IonscaleServiceDeleteMachineShareProcedure:
+			ionscaleServiceDeleteMachineShareHandler.ServeHTTP(w, r)
+		case IonscaleServiceSetMachineAliasesProcedure:
+			ionscaleServiceSetMachineAliasesHandler.ServeHTTP(w, r)
+		case IonscaleServiceSetMachineTagsProcedure:
+			ionscaleServiceSetMachineTagsHandler.ServeHTTP(w, r)
 		case IonscaleServiceGetMachineRoutesProcedure:
 			ionscaleServiceGetMachineRoutesHandler.ServeHTTP(w, r)
 		case IonscaleServiceEnableMachineRoutesProcedure:
 			ionscaleServiceEnableMachineRoutesHandler.ServeHTTP(w, r)
 		case IonscaleServiceDisableMachineRoutesProcedure:
 			ionscaleServiceDisableMachineRoutesHandler.ServeHTTP(w, r)
+		case IonscaleServiceBulkExpireMachinesProcedure:
+			ionscaleServiceBulkExpireMachinesHandler.ServeHTTP(w, r)
+		case IonscaleServiceBulkDeleteMachinesProcedure:
+			ionscaleServiceBulkDeleteMachinesHandler.ServeHTTP(w, r)
+		case IonscaleServiceBulkAuthorizeMachinesProcedure:
+			ionscaleServiceBulkAuthorizeMachinesHandler.ServeHTTP(w, r)
+		case IonscaleServiceBulkEnableMachineRoutesProcedure:
+			ionscaleServiceBulkEnableMachineRoutesHandler.ServeHTTP(w, r)
 		case IonscaleServiceEnableExitNodeProcedure:
 			ionscaleServiceEnableExitNodeHandler.ServeHTTP(w, r)
 		case IonscaleServiceDisableExitNodeProcedure:
 			ionscaleServiceDisableExitNodeHandler.ServeHTTP(w, r)
+		case IonscaleServiceApproveSSHRequestProcedure:
+			ionscaleServiceApproveSSHRequestHandler.ServeHTTP(w, r)
+		case IonscaleServiceListSSHSessionEventsProcedure:
+			ionscaleServiceListSSHSessionEventsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -1119,6 +1510,18 @@ func (UnimplementedIonscaleServiceHandler) DisableMachineAuthorization(context.C
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.DisableMachineAuthorization is not implemented"))
 }
 
+func (UnimplementedIonscaleServiceHandler) GetMachineRetentionPolicy(context.Context, *connect_go.Request[v1.GetMachineRetentionPolicyRequest]) (*connect_go.Response[v1.GetMachineRetentionPolicyResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.GetMachineRetentionPolicy is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) SetMachineRetentionPolicy(context.Context, *connect_go.Request[v1.SetMachineRetentionPolicyRequest]) (*connect_go.Response[v1.SetMachineRetentionPolicyResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.SetMachineRetentionPolicy is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) PreviewMachineRetentionPolicy(context.Context, *connect_go.Request[v1.PreviewMachineRetentionPolicyRequest]) (*connect_go.Response[v1.PreviewMachineRetentionPolicyResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.PreviewMachineRetentionPolicy is not implemented"))
+}
+
 func (UnimplementedIonscaleServiceHandler) GetDNSConfig(context.Context, *connect_go.Request[v1.GetDNSConfigRequest]) (*connect_go.Response[v1.GetDNSConfigResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.GetDNSConfig is not implemented"))
 }
@@ -1191,6 +1594,38 @@ func (UnimplementedIonscaleServiceHandler) SetMachineKeyExpiry(context.Context,
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.SetMachineKeyExpiry is not implemented"))
 }
 
+func (UnimplementedIonscaleServiceHandler) RenameMachine(context.Context, *connect_go.Request[v1.RenameMachineRequest]) (*connect_go.Response[v1.RenameMachineResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.RenameMachine is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) MoveMachine(context.Context, *connect_go.Request[v1.MoveMachineRequest]) (*connect_go.Response[v1.MoveMachineResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.MoveMachine is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) CreateMachineShare(context.Context, *connect_go.Request[v1.CreateMachineShareRequest]) (*connect_go.Response[v1.CreateMachineShareResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.CreateMachineShare is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) ListMachineShares(context.Context, *connect_go.Request[v1.ListMachineSharesRequest]) (*connect_go.Response[v1.ListMachineSharesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.ListMachineShares is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) AcceptMachineShare(context.Context, *connect_go.Request[v1.AcceptMachineShareRequest]) (*connect_go.Response[v1.AcceptMachineShareResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.AcceptMachineShare is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) DeleteMachineShare(context.Context, *connect_go.Request[v1.DeleteMachineShareRequest]) (*connect_go.Response[v1.DeleteMachineShareResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.DeleteMachineShare is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) SetMachineAliases(context.Context, *connect_go.Request[v1.SetMachineAliasesRequest]) (*connect_go.Response[v1.SetMachineAliasesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.SetMachineAliases is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) SetMachineTags(context.Context, *connect_go.Request[v1.SetMachineTagsRequest]) (*connect_go.Response[v1.SetMachineTagsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.SetMachineTags is not implemented"))
+}
+
 func (UnimplementedIonscaleServiceHandler) GetMachineRoutes(context.Context, *connect_go.Request[v1.GetMachineRoutesRequest]) (*connect_go.Response[v1.GetMachineRoutesResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.GetMachineRoutes is not implemented"))
 }
@@ -1203,6 +1638,22 @@ func (UnimplementedIonscaleServiceHandler) DisableMachineRoutes(context.Context,
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.DisableMachineRoutes is not implemented"))
 }
 
+func (UnimplementedIonscaleServiceHandler) BulkExpireMachines(context.Context, *connect_go.Request[v1.BulkExpireMachinesRequest]) (*connect_go.Response[v1.BulkExpireMachinesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.BulkExpireMachines is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) BulkDeleteMachines(context.Context, *connect_go.Request[v1.BulkDeleteMachinesRequest]) (*connect_go.Response[v1.BulkDeleteMachinesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.BulkDeleteMachines is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) BulkAuthorizeMachines(context.Context, *connect_go.Request[v1.BulkAuthorizeMachinesRequest]) (*connect_go.Response[v1.BulkAuthorizeMachinesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.BulkAuthorizeMachines is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) BulkEnableMachineRoutes(context.Context, *connect_go.Request[v1.BulkEnableMachineRoutesRequest]) (*connect_go.Response[v1.BulkEnableMachineRoutesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.BulkEnableMachineRoutes is not implemented"))
+}
+
 func (UnimplementedIonscaleServiceHandler) EnableExitNode(context.Context, *connect_go.Request[v1.EnableExitNodeRequest]) (*connect_go.Response[v1.EnableExitNodeResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.EnableExitNode is not implemented"))
 }
@@ -1210,3 +1661,11 @@ func (UnimplementedIonscaleServiceHandler) EnableExitNode(context.Context, *conn
 func (UnimplementedIonscaleServiceHandler) DisableExitNode(context.Context, *connect_go.Request[v1.DisableExitNodeRequest]) (*connect_go.Response[v1.DisableExitNodeResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.DisableExitNode is not implemented"))
 }
+
+func (UnimplementedIonscaleServiceHandler) ApproveSSHRequest(context.Context, *connect_go.Request[v1.ApproveSSHRequestRequest]) (*connect_go.Response[v1.ApproveSSHRequestResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.ApproveSSHRequest is not implemented"))
+}
+
+func (UnimplementedIonscaleServiceHandler) ListSSHSessionEvents(context.Context, *connect_go.Request[v1.ListSSHSessionEventsRequest]) (*connect_go.Response[v1.ListSSHSessionEventsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("ionscale.v1.IonscaleService.ListSSHSessionEvents is not implemented"))
+}
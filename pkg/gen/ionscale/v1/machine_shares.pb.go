@@ -0,0 +1,809 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: ionscale/v1/machine_shares.proto
+
+package ionscalev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MachineShare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Machine          *Ref                   `protobuf:"bytes,2,opt,name=machine,proto3" json:"machine,omitempty"`
+	Tailnet          *Ref                   `protobuf:"bytes,3,opt,name=tailnet,proto3" json:"tailnet,omitempty"`
+	InviteUrl        string                 `protobuf:"bytes,4,opt,name=invite_url,json=inviteUrl,proto3" json:"invite_url,omitempty"`
+	InviteCode       string                 `protobuf:"bytes,5,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	InviteLoginName  string                 `protobuf:"bytes,6,opt,name=invite_login_name,json=inviteLoginName,proto3" json:"invite_login_name,omitempty"`
+	InviteTailnetId  uint64                 `protobuf:"varint,7,opt,name=invite_tailnet_id,json=inviteTailnetId,proto3" json:"invite_tailnet_id,omitempty"`
+	RecipientTailnet *Ref                   `protobuf:"bytes,8,opt,name=recipient_tailnet,json=recipientTailnet,proto3,oneof" json:"recipient_tailnet,omitempty"`
+	RecipientUser    *Ref                   `protobuf:"bytes,9,opt,name=recipient_user,json=recipientUser,proto3,oneof" json:"recipient_user,omitempty"`
+	AcceptedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=accepted_at,json=acceptedAt,proto3,oneof" json:"accepted_at,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *MachineShare) Reset() {
+	*x = MachineShare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineShare) ProtoMessage() {}
+
+func (x *MachineShare) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineShare.ProtoReflect.Descriptor instead.
+func (*MachineShare) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MachineShare) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MachineShare) GetMachine() *Ref {
+	if x != nil {
+		return x.Machine
+	}
+	return nil
+}
+
+func (x *MachineShare) GetTailnet() *Ref {
+	if x != nil {
+		return x.Tailnet
+	}
+	return nil
+}
+
+func (x *MachineShare) GetInviteUrl() string {
+	if x != nil {
+		return x.InviteUrl
+	}
+	return ""
+}
+
+func (x *MachineShare) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *MachineShare) GetInviteLoginName() string {
+	if x != nil {
+		return x.InviteLoginName
+	}
+	return ""
+}
+
+func (x *MachineShare) GetInviteTailnetId() uint64 {
+	if x != nil {
+		return x.InviteTailnetId
+	}
+	return 0
+}
+
+func (x *MachineShare) GetRecipientTailnet() *Ref {
+	if x != nil {
+		return x.RecipientTailnet
+	}
+	return nil
+}
+
+func (x *MachineShare) GetRecipientUser() *Ref {
+	if x != nil {
+		return x.RecipientUser
+	}
+	return nil
+}
+
+func (x *MachineShare) GetAcceptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return nil
+}
+
+func (x *MachineShare) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateMachineShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId uint64 `protobuf:"varint,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	LoginName string `protobuf:"bytes,2,opt,name=login_name,json=loginName,proto3" json:"login_name,omitempty"`
+	TailnetId uint64 `protobuf:"varint,3,opt,name=tailnet_id,json=tailnetId,proto3" json:"tailnet_id,omitempty"`
+}
+
+func (x *CreateMachineShareRequest) Reset() {
+	*x = CreateMachineShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateMachineShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMachineShareRequest) ProtoMessage() {}
+
+func (x *CreateMachineShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMachineShareRequest.ProtoReflect.Descriptor instead.
+func (*CreateMachineShareRequest) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateMachineShareRequest) GetMachineId() uint64 {
+	if x != nil {
+		return x.MachineId
+	}
+	return 0
+}
+
+func (x *CreateMachineShareRequest) GetLoginName() string {
+	if x != nil {
+		return x.LoginName
+	}
+	return ""
+}
+
+func (x *CreateMachineShareRequest) GetTailnetId() uint64 {
+	if x != nil {
+		return x.TailnetId
+	}
+	return 0
+}
+
+type CreateMachineShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Share *MachineShare `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+}
+
+func (x *CreateMachineShareResponse) Reset() {
+	*x = CreateMachineShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateMachineShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMachineShareResponse) ProtoMessage() {}
+
+func (x *CreateMachineShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMachineShareResponse.ProtoReflect.Descriptor instead.
+func (*CreateMachineShareResponse) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateMachineShareResponse) GetShare() *MachineShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type ListMachineSharesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId uint64 `protobuf:"varint,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	TailnetId uint64 `protobuf:"varint,2,opt,name=tailnet_id,json=tailnetId,proto3" json:"tailnet_id,omitempty"`
+}
+
+func (x *ListMachineSharesRequest) Reset() {
+	*x = ListMachineSharesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMachineSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMachineSharesRequest) ProtoMessage() {}
+
+func (x *ListMachineSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMachineSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListMachineSharesRequest) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListMachineSharesRequest) GetMachineId() uint64 {
+	if x != nil {
+		return x.MachineId
+	}
+	return 0
+}
+
+func (x *ListMachineSharesRequest) GetTailnetId() uint64 {
+	if x != nil {
+		return x.TailnetId
+	}
+	return 0
+}
+
+type ListMachineSharesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shares []*MachineShare `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+}
+
+func (x *ListMachineSharesResponse) Reset() {
+	*x = ListMachineSharesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMachineSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMachineSharesResponse) ProtoMessage() {}
+
+func (x *ListMachineSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMachineSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListMachineSharesResponse) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMachineSharesResponse) GetShares() []*MachineShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type AcceptMachineShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *AcceptMachineShareRequest) Reset() {
+	*x = AcceptMachineShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcceptMachineShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptMachineShareRequest) ProtoMessage() {}
+
+func (x *AcceptMachineShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptMachineShareRequest.ProtoReflect.Descriptor instead.
+func (*AcceptMachineShareRequest) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AcceptMachineShareRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type AcceptMachineShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Share *MachineShare `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+}
+
+func (x *AcceptMachineShareResponse) Reset() {
+	*x = AcceptMachineShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcceptMachineShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptMachineShareResponse) ProtoMessage() {}
+
+func (x *AcceptMachineShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptMachineShareResponse.ProtoReflect.Descriptor instead.
+func (*AcceptMachineShareResponse) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AcceptMachineShareResponse) GetShare() *MachineShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type DeleteMachineShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShareId uint64 `protobuf:"varint,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+}
+
+func (x *DeleteMachineShareRequest) Reset() {
+	*x = DeleteMachineShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteMachineShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMachineShareRequest) ProtoMessage() {}
+
+func (x *DeleteMachineShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMachineShareRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMachineShareRequest) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteMachineShareRequest) GetShareId() uint64 {
+	if x != nil {
+		return x.ShareId
+	}
+	return 0
+}
+
+type DeleteMachineShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteMachineShareResponse) Reset() {
+	*x = DeleteMachineShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_machine_shares_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteMachineShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMachineShareResponse) ProtoMessage() {}
+
+func (x *DeleteMachineShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_machine_shares_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMachineShareResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMachineShareResponse) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_machine_shares_proto_rawDescGZIP(), []int{8}
+}
+
+var File_ionscale_v1_machine_shares_proto protoreflect.FileDescriptor
+
+var file_ionscale_v1_machine_shares_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0b, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x15, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65,
+	0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc6, 0x04, 0x0a, 0x0c, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2a, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6f, 0x6e, 0x73,
+	0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x52, 0x07, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x52, 0x07, 0x74, 0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x55, 0x72, 0x6c, 0x12,
+	0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x69, 0x6e,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x76,
+	0x69, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11,
+	0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x54,
+	0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x49, 0x64, 0x12, 0x42, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x66, 0x48, 0x00, 0x52, 0x10, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x54, 0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x0e,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x48, 0x01, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x40, 0x0a, 0x0b, 0x61, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x02, 0x52, 0x0a, 0x61,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x41, 0x74, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x42, 0x11, 0x0a,
+	0x0f, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72,
+	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x22, 0x78, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x74, 0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x1a, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x22, 0x58, 0x0a, 0x18, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x69, 0x6c, 0x6e, 0x65, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x61, 0x69, 0x6c, 0x6e, 0x65,
+	0x74, 0x49, 0x64, 0x22, 0x4e, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x31, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x06, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x73, 0x22, 0x2f, 0x0a, 0x19, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x22, 0x4d, 0x0a, 0x1a, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x4d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x05, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x22, 0x36, 0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x73, 0x68, 0x61, 0x72, 0x65, 0x49, 0x64, 0x22, 0x1c, 0x0a, 0x1a, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x73, 0x69, 0x65, 0x62, 0x65, 0x6e, 0x73,
+	0x2f, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65,
+	0x6e, 0x2f, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x69, 0x6f,
+	0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ionscale_v1_machine_shares_proto_rawDescOnce sync.Once
+	file_ionscale_v1_machine_shares_proto_rawDescData = file_ionscale_v1_machine_shares_proto_rawDesc
+)
+
+func file_ionscale_v1_machine_shares_proto_rawDescGZIP() []byte {
+	file_ionscale_v1_machine_shares_proto_rawDescOnce.Do(func() {
+		file_ionscale_v1_machine_shares_proto_rawDescData = protoimpl.X.CompressGZIP(file_ionscale_v1_machine_shares_proto_rawDescData)
+	})
+	return file_ionscale_v1_machine_shares_proto_rawDescData
+}
+
+var file_ionscale_v1_machine_shares_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_ionscale_v1_machine_shares_proto_goTypes = []any{
+	(*MachineShare)(nil),               // 0: ionscale.v1.MachineShare
+	(*CreateMachineShareRequest)(nil),  // 1: ionscale.v1.CreateMachineShareRequest
+	(*CreateMachineShareResponse)(nil), // 2: ionscale.v1.CreateMachineShareResponse
+	(*ListMachineSharesRequest)(nil),   // 3: ionscale.v1.ListMachineSharesRequest
+	(*ListMachineSharesResponse)(nil),  // 4: ionscale.v1.ListMachineSharesResponse
+	(*AcceptMachineShareRequest)(nil),  // 5: ionscale.v1.AcceptMachineShareRequest
+	(*AcceptMachineShareResponse)(nil), // 6: ionscale.v1.AcceptMachineShareResponse
+	(*DeleteMachineShareRequest)(nil),  // 7: ionscale.v1.DeleteMachineShareRequest
+	(*DeleteMachineShareResponse)(nil), // 8: ionscale.v1.DeleteMachineShareResponse
+	(*Ref)(nil),                        // 9: ionscale.v1.Ref
+	(*timestamppb.Timestamp)(nil),      // 10: google.protobuf.Timestamp
+}
+var file_ionscale_v1_machine_shares_proto_depIdxs = []int32{
+	9,  // 0: ionscale.v1.MachineShare.machine:type_name -> ionscale.v1.Ref
+	9,  // 1: ionscale.v1.MachineShare.tailnet:type_name -> ionscale.v1.Ref
+	9,  // 2: ionscale.v1.MachineShare.recipient_tailnet:type_name -> ionscale.v1.Ref
+	9,  // 3: ionscale.v1.MachineShare.recipient_user:type_name -> ionscale.v1.Ref
+	10, // 4: ionscale.v1.MachineShare.accepted_at:type_name -> google.protobuf.Timestamp
+	10, // 5: ionscale.v1.MachineShare.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: ionscale.v1.CreateMachineShareResponse.share:type_name -> ionscale.v1.MachineShare
+	0,  // 7: ionscale.v1.ListMachineSharesResponse.shares:type_name -> ionscale.v1.MachineShare
+	0,  // 8: ionscale.v1.AcceptMachineShareResponse.share:type_name -> ionscale.v1.MachineShare
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_ionscale_v1_machine_shares_proto_init() }
+func file_ionscale_v1_machine_shares_proto_init() {
+	if File_ionscale_v1_machine_shares_proto != nil {
+		return
+	}
+	file_ionscale_v1_ref_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_ionscale_v1_machine_shares_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*MachineShare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_machine_shares_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateMachineShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_machine_shares_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateMachineShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_machine_shares_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListMachineSharesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_machine_shares_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ListMachineSharesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_machine_shares_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*AcceptMachineShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_machine_shares_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*AcceptMachineShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_machine_shares_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteMachineShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_machine_shares_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteMachineShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_ionscale_v1_machine_shares_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ionscale_v1_machine_shares_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ionscale_v1_machine_shares_proto_goTypes,
+		DependencyIndexes: file_ionscale_v1_machine_shares_proto_depIdxs,
+		MessageInfos:      file_ionscale_v1_machine_shares_proto_msgTypes,
+	}.Build()
+	File_ionscale_v1_machine_shares_proto = out.File
+	file_ionscale_v1_machine_shares_proto_rawDesc = nil
+	file_ionscale_v1_machine_shares_proto_goTypes = nil
+	file_ionscale_v1_machine_shares_proto_depIdxs = nil
+}
@@ -0,0 +1,552 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: ionscale/v1/ssh.proto
+
+package ionscalev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ApproveSSHRequestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Reject bool   `protobuf:"varint,2,opt,name=reject,proto3" json:"reject,omitempty"`
+}
+
+func (x *ApproveSSHRequestRequest) Reset() {
+	*x = ApproveSSHRequestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_ssh_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveSSHRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveSSHRequestRequest) ProtoMessage() {}
+
+func (x *ApproveSSHRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_ssh_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveSSHRequestRequest.ProtoReflect.Descriptor instead.
+func (*ApproveSSHRequestRequest) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_ssh_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApproveSSHRequestRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ApproveSSHRequestRequest) GetReject() bool {
+	if x != nil {
+		return x.Reject
+	}
+	return false
+}
+
+type ApproveSSHRequestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ApproveSSHRequestResponse) Reset() {
+	*x = ApproveSSHRequestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_ssh_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveSSHRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveSSHRequestResponse) ProtoMessage() {}
+
+func (x *ApproveSSHRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_ssh_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveSSHRequestResponse.ProtoReflect.Descriptor instead.
+func (*ApproveSSHRequestResponse) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_ssh_proto_rawDescGZIP(), []int{1}
+}
+
+type ListSSHSessionEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TailnetId uint64                 `protobuf:"varint,1,opt,name=tailnet_id,json=tailnetId,proto3" json:"tailnet_id,omitempty"`
+	MachineId uint64                 `protobuf:"varint,2,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Since     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3,oneof" json:"since,omitempty"`
+	Limit     uint32                 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListSSHSessionEventsRequest) Reset() {
+	*x = ListSSHSessionEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_ssh_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSSHSessionEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSSHSessionEventsRequest) ProtoMessage() {}
+
+func (x *ListSSHSessionEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_ssh_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSSHSessionEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListSSHSessionEventsRequest) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_ssh_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSSHSessionEventsRequest) GetTailnetId() uint64 {
+	if x != nil {
+		return x.TailnetId
+	}
+	return 0
+}
+
+func (x *ListSSHSessionEventsRequest) GetMachineId() uint64 {
+	if x != nil {
+		return x.MachineId
+	}
+	return 0
+}
+
+func (x *ListSSHSessionEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListSSHSessionEventsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSSHSessionEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*SSHSessionEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *ListSSHSessionEventsResponse) Reset() {
+	*x = ListSSHSessionEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_ssh_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSSHSessionEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSSHSessionEventsResponse) ProtoMessage() {}
+
+func (x *ListSSHSessionEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_ssh_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSSHSessionEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSSHSessionEventsResponse) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_ssh_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListSSHSessionEventsResponse) GetEvents() []*SSHSessionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type SSHSessionEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ConnectionId   string                 `protobuf:"bytes,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	SrcMachineId   uint64                 `protobuf:"varint,4,opt,name=src_machine_id,json=srcMachineId,proto3" json:"src_machine_id,omitempty"`
+	SrcMachineName string                 `protobuf:"bytes,5,opt,name=src_machine_name,json=srcMachineName,proto3" json:"src_machine_name,omitempty"`
+	DstMachineId   uint64                 `protobuf:"varint,6,opt,name=dst_machine_id,json=dstMachineId,proto3" json:"dst_machine_id,omitempty"`
+	DstMachineName string                 `protobuf:"bytes,7,opt,name=dst_machine_name,json=dstMachineName,proto3" json:"dst_machine_name,omitempty"`
+	SshUser        string                 `protobuf:"bytes,8,opt,name=ssh_user,json=sshUser,proto3" json:"ssh_user,omitempty"`
+	LocalUser      string                 `protobuf:"bytes,9,opt,name=local_user,json=localUser,proto3" json:"local_user,omitempty"`
+	Decision       string                 `protobuf:"bytes,10,opt,name=decision,proto3" json:"decision,omitempty"`
+	Recording      string                 `protobuf:"bytes,11,opt,name=recording,proto3" json:"recording,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *SSHSessionEvent) Reset() {
+	*x = SSHSessionEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ionscale_v1_ssh_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SSHSessionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SSHSessionEvent) ProtoMessage() {}
+
+func (x *SSHSessionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ionscale_v1_ssh_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SSHSessionEvent.ProtoReflect.Descriptor instead.
+func (*SSHSessionEvent) Descriptor() ([]byte, []int) {
+	return file_ionscale_v1_ssh_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SSHSessionEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SSHSessionEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SSHSessionEvent) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *SSHSessionEvent) GetSrcMachineId() uint64 {
+	if x != nil {
+		return x.SrcMachineId
+	}
+	return 0
+}
+
+func (x *SSHSessionEvent) GetSrcMachineName() string {
+	if x != nil {
+		return x.SrcMachineName
+	}
+	return ""
+}
+
+func (x *SSHSessionEvent) GetDstMachineId() uint64 {
+	if x != nil {
+		return x.DstMachineId
+	}
+	return 0
+}
+
+func (x *SSHSessionEvent) GetDstMachineName() string {
+	if x != nil {
+		return x.DstMachineName
+	}
+	return ""
+}
+
+func (x *SSHSessionEvent) GetSshUser() string {
+	if x != nil {
+		return x.SshUser
+	}
+	return ""
+}
+
+func (x *SSHSessionEvent) GetLocalUser() string {
+	if x != nil {
+		return x.LocalUser
+	}
+	return ""
+}
+
+func (x *SSHSessionEvent) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *SSHSessionEvent) GetRecording() string {
+	if x != nil {
+		return x.Recording
+	}
+	return ""
+}
+
+func (x *SSHSessionEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_ionscale_v1_ssh_proto protoreflect.FileDescriptor
+
+var file_ionscale_v1_ssh_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x73,
+	0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x44, 0x0a, 0x18, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x53, 0x53, 0x48, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x1b, 0x0a, 0x19, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x53, 0x48, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x53, 0x48, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x69, 0x6c,
+	0x6e, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x61,
+	0x69, 0x6c, 0x6e, 0x65, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x48, 0x00, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x54, 0x0a,
+	0x1c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x53, 0x48, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x53, 0x48, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0xa9, 0x03, 0x0a, 0x0f, 0x53, 0x53, 0x48, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x24, 0x0a, 0x0e, 0x73, 0x72, 0x63, 0x5f, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x72, 0x63, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x72, 0x63, 0x5f, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x73, 0x72, 0x63, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x24, 0x0a, 0x0e, 0x64, 0x73, 0x74, 0x5f, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x73, 0x74, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x73, 0x74, 0x5f, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x64, 0x73, 0x74, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x73, 0x73, 0x68, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x73, 0x68, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x42,
+	0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x73,
+	0x69, 0x65, 0x62, 0x65, 0x6e, 0x73, 0x2f, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65,
+	0x2f, 0x76, 0x31, 0x3b, 0x69, 0x6f, 0x6e, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ionscale_v1_ssh_proto_rawDescOnce sync.Once
+	file_ionscale_v1_ssh_proto_rawDescData = file_ionscale_v1_ssh_proto_rawDesc
+)
+
+func file_ionscale_v1_ssh_proto_rawDescGZIP() []byte {
+	file_ionscale_v1_ssh_proto_rawDescOnce.Do(func() {
+		file_ionscale_v1_ssh_proto_rawDescData = protoimpl.X.CompressGZIP(file_ionscale_v1_ssh_proto_rawDescData)
+	})
+	return file_ionscale_v1_ssh_proto_rawDescData
+}
+
+var file_ionscale_v1_ssh_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_ionscale_v1_ssh_proto_goTypes = []any{
+	(*ApproveSSHRequestRequest)(nil),     // 0: ionscale.v1.ApproveSSHRequestRequest
+	(*ApproveSSHRequestResponse)(nil),    // 1: ionscale.v1.ApproveSSHRequestResponse
+	(*ListSSHSessionEventsRequest)(nil),  // 2: ionscale.v1.ListSSHSessionEventsRequest
+	(*ListSSHSessionEventsResponse)(nil), // 3: ionscale.v1.ListSSHSessionEventsResponse
+	(*SSHSessionEvent)(nil),              // 4: ionscale.v1.SSHSessionEvent
+	(*timestamppb.Timestamp)(nil),        // 5: google.protobuf.Timestamp
+}
+var file_ionscale_v1_ssh_proto_depIdxs = []int32{
+	5, // 0: ionscale.v1.ListSSHSessionEventsRequest.since:type_name -> google.protobuf.Timestamp
+	4, // 1: ionscale.v1.ListSSHSessionEventsResponse.events:type_name -> ionscale.v1.SSHSessionEvent
+	5, // 2: ionscale.v1.SSHSessionEvent.created_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_ionscale_v1_ssh_proto_init() }
+func file_ionscale_v1_ssh_proto_init() {
+	if File_ionscale_v1_ssh_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ionscale_v1_ssh_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ApproveSSHRequestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_ssh_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ApproveSSHRequestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_ssh_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSSHSessionEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_ssh_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListSSHSessionEventsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ionscale_v1_ssh_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SSHSessionEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_ionscale_v1_ssh_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ionscale_v1_ssh_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ionscale_v1_ssh_proto_goTypes,
+		DependencyIndexes: file_ionscale_v1_ssh_proto_depIdxs,
+		MessageInfos:      file_ionscale_v1_ssh_proto_msgTypes,
+	}.Build()
+	File_ionscale_v1_ssh_proto = out.File
+	file_ionscale_v1_ssh_proto_rawDesc = nil
+	file_ionscale_v1_ssh_proto_goTypes = nil
+	file_ionscale_v1_ssh_proto_depIdxs = nil
+}
@@ -0,0 +1,179 @@
+package ssh
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"html/template"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// apiAuth accepts the API key as a bearer token, or as the password of HTTP basic authentication
+// so that browsers can be used to search and play recordings.
+func apiAuth(apiKey string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var token string
+
+			if _, password, ok := c.Request().BasicAuth(); ok {
+				token = password
+			} else if t, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer "); ok {
+				token = t
+			}
+
+			if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="ionscale recorder"`)
+				return echo.NewHTTPError(http.StatusUnauthorized)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func registerAPI(mux *echo.Echo, apiKey string, index *Index, storage Storage) {
+	g := mux.Group("", apiAuth(apiKey))
+
+	registerAssets(g)
+
+	g.GET("/api/recordings", func(c echo.Context) error {
+		filter, err := parseRecordingFilter(c)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return c.JSON(http.StatusOK, index.List(filter))
+	})
+
+	g.GET("/api/recordings/:id", func(c echo.Context) error {
+		r, ok := index.Get(c.Param("id"))
+		if !ok {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+		return c.JSON(http.StatusOK, r)
+	})
+
+	g.GET("/api/recordings/:id/cast", func(c echo.Context) error {
+		r, ok := index.Get(c.Param("id"))
+		if !ok {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+
+		content, err := storage.Open(c.Request().Context(), r.Name)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+		defer content.Close()
+
+		filename := fmt.Sprintf("%s-%s", r.Header.SrcNodeID, path.Base(r.Name))
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", filename))
+		c.Response().Header().Set(echo.HeaderContentType, "application/x-asciicast")
+		c.Response().WriteHeader(http.StatusOK)
+
+		_, err = io.Copy(c.Response(), content)
+		return err
+	})
+
+	g.GET("/", func(c echo.Context) error {
+		filter, err := parseRecordingFilter(c)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+		return listTemplate.Execute(c.Response(), map[string]interface{}{
+			"Filter":     c.QueryParams(),
+			"Recordings": index.List(filter),
+		})
+	})
+
+	g.GET("/recordings/:id", func(c echo.Context) error {
+		r, ok := index.Get(c.Param("id"))
+		if !ok {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+		c.Response().Header().Set(echo.HeaderContentSecurityPolicy, contentSecurityPolicy)
+		return playTemplate.Execute(c.Response(), r)
+	})
+}
+
+func parseRecordingFilter(c echo.Context) (RecordingFilter, error) {
+	filter := RecordingFilter{
+		SrcNode: c.QueryParam("src_node"),
+		DstNode: c.QueryParam("dst_node"),
+		SSHUser: c.QueryParam("ssh_user"),
+	}
+
+	for name, t := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := c.QueryParam(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return filter, fmt.Errorf("invalid value for %s, expected RFC3339 timestamp", name)
+			}
+			*t = parsed
+		}
+	}
+
+	return filter, nil
+}
+
+var listTemplate = template.Must(template.New("list").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>SSH Recordings</title>
+  <style>
+    body { font-family: sans-serif; margin: 2em; }
+    table { border-collapse: collapse; width: 100%; }
+    th, td { text-align: left; padding: 0.4em; border-bottom: 1px solid #ddd; }
+  </style>
+</head>
+<body>
+  <h1>SSH Recordings</h1>
+  <form method="get">
+    <input name="src_node" placeholder="Source node" value="{{ .Filter.Get "src_node" }}">
+    <input name="dst_node" placeholder="Destination node" value="{{ .Filter.Get "dst_node" }}">
+    <input name="ssh_user" placeholder="SSH user" value="{{ .Filter.Get "ssh_user" }}">
+    <input name="since" placeholder="Since (RFC3339)" value="{{ .Filter.Get "since" }}">
+    <input name="until" placeholder="Until (RFC3339)" value="{{ .Filter.Get "until" }}">
+    <button type="submit">Search</button>
+  </form>
+  <table>
+    <tr><th>Started</th><th>Source</th><th>Destination</th><th>SSH user</th><th>Local user</th><th>Command</th><th></th></tr>
+    {{ range .Recordings }}
+    <tr>
+      <td>{{ .StartedAt.Format "2006-01-02 15:04:05 MST" }}</td>
+      <td>{{ .Header.SrcNode }}</td>
+      <td>{{ .DstNode }}</td>
+      <td>{{ .Header.SSHUser }}</td>
+      <td>{{ .Header.LocalUser }}</td>
+      <td>{{ .Header.Command }}</td>
+      <td><a href="/recordings/{{ .ID }}">play</a> | <a href="/api/recordings/{{ .ID }}/cast" download>download</a></td>
+    </tr>
+    {{ end }}
+  </table>
+</body>
+</html>
+`))
+
+var playTemplate = template.Must(template.New("play").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>SSH Recording {{ .ID }}</title>
+  <link rel="stylesheet" type="text/css" href="/assets/player.css">
+</head>
+<body>
+  <p><a href="/">&larr; all recordings</a></p>
+  <h1>{{ .Header.SSHUser }}@{{ .DstNode }}</h1>
+  <p>From {{ .Header.SrcNode }} at {{ .StartedAt.Format "2006-01-02 15:04:05 MST" }}</p>
+  <div id="player" data-cast="/api/recordings/{{ .ID }}/cast"></div>
+  <script src="/assets/player.js"></script>
+</body>
+</html>
+`))
@@ -0,0 +1,48 @@
+package ssh
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAPI_PlayerUsesEmbeddedAssets(t *testing.T) {
+	idx, err := OpenIndex(path.Join(t.TempDir(), "recordings.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, idx.Save(Recording{ID: "a", Name: "n1/a.cast", StartedAt: time.Now().UTC(), DstNode: "server.example.ts.net"}))
+
+	mux := echo.New()
+	registerAPI(mux, "secret", idx, nil)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.SetBasicAuth("", "secret")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	play := get("/recordings/a")
+	require.Equal(t, http.StatusOK, play.Code)
+	assert.Equal(t, contentSecurityPolicy, play.Header().Get(echo.HeaderContentSecurityPolicy))
+	assert.NotContains(t, play.Body.String(), "https://")
+	assert.Contains(t, play.Body.String(), `data-cast="/api/recordings/a/cast"`)
+
+	// every asset referenced by the page is served from the embedded files
+	assets := regexp.MustCompile(`(?:src|href)="(/assets/[^"]+)"`).FindAllStringSubmatch(play.Body.String(), -1)
+	require.Len(t, assets, 2)
+
+	for _, a := range assets {
+		asset := get(a[1])
+		require.Equal(t, http.StatusOK, asset.Code, a[1])
+		assert.NotEmpty(t, asset.Body.String(), a[1])
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("/assets/missing.js").Code)
+}
@@ -0,0 +1,21 @@
+package ssh
+
+import (
+	"embed"
+	"github.com/labstack/echo/v4"
+	"io/fs"
+	"net/http"
+)
+
+// assets holds the recording player, every file is listed so that a missing asset fails the build.
+//
+//go:embed assets/player.css assets/player.js
+var assets embed.FS
+
+// contentSecurityPolicy only allows the embedded assets and the recordings of the recorder itself.
+const contentSecurityPolicy = "default-src 'self'; object-src 'none'; base-uri 'none'"
+
+func registerAssets(g *echo.Group) {
+	sub, _ := fs.Sub(assets, "assets")
+	g.GET("/assets/*", echo.WrapHandler(http.StripPrefix("/assets/", http.FileServer(http.FS(sub)))))
+}
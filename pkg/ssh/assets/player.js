@@ -0,0 +1,668 @@
+// Plays asciicast v2 recordings with a minimal terminal emulator, so that the recorder
+// doesn't depend on third party scripts. It supports the control sequences commonly used
+// by shells and full screen programs: cursor movement, erasing, scrolling regions,
+// colors and the alternate screen.
+(function () {
+  'use strict';
+
+  const PALETTE = [
+    '#000000', '#cd0000', '#00cd00', '#cdcd00', '#0000ee', '#cd00cd', '#00cdcd', '#e5e5e5',
+    '#7f7f7f', '#ff0000', '#00ff00', '#ffff00', '#5c5cff', '#ff00ff', '#00ffff', '#ffffff',
+  ];
+
+  const DEFAULT_STYLE = Object.freeze({fg: null, bg: null, bold: false, italic: false, underline: false, inverse: false});
+  const BLANK = Object.freeze({ch: ' ', style: DEFAULT_STYLE});
+
+  function rgb(r, g, b) {
+    return 'rgb(' + r + ',' + g + ',' + b + ')';
+  }
+
+  function color256(n) {
+    if (n < 16) {
+      return PALETTE[n];
+    }
+    if (n < 232) {
+      const levels = [0, 95, 135, 175, 215, 255];
+      n -= 16;
+      return rgb(levels[Math.floor(n / 36)], levels[Math.floor(n / 6) % 6], levels[n % 6]);
+    }
+    const v = 8 + (n - 232) * 10;
+    return rgb(v, v, v);
+  }
+
+  function clamp(v, min, max) {
+    return Math.min(Math.max(v, min), max);
+  }
+
+  class Terminal {
+    constructor(cols, rows) {
+      this.cols = cols;
+      this.rows = rows;
+      this.reset();
+    }
+
+    reset() {
+      this.lines = this.blankScreen();
+      this.primary = null;
+      this.x = 0;
+      this.y = 0;
+      this.wrapPending = false;
+      this.style = DEFAULT_STYLE;
+      this.saved = null;
+      this.top = 0;
+      this.bottom = this.rows - 1;
+      this.cursorVisible = true;
+      this.state = 'normal';
+      this.params = '';
+    }
+
+    blankLine() {
+      return new Array(this.cols).fill(BLANK);
+    }
+
+    blankScreen() {
+      const lines = [];
+      for (let i = 0; i < this.rows; i++) {
+        lines.push(this.blankLine());
+      }
+      return lines;
+    }
+
+    feed(data) {
+      for (const c of data) {
+        switch (this.state) {
+          case 'esc':
+            this.escape(c);
+            break;
+          case 'csi':
+            if (c >= '0' && c <= '?') {
+              this.params += c;
+            } else if (c < ' ') {
+              this.control(c);
+            } else if (c > '/') {
+              this.state = 'normal';
+              this.csi(c);
+            }
+            break;
+          case 'string':
+            // OSC, DCS and similar strings are terminated by BEL or ST, their content is ignored
+            if (c === '\x07') {
+              this.state = 'normal';
+            } else if (c === '\x1b') {
+              this.state = 'esc';
+            }
+            break;
+          case 'charset':
+            this.state = 'normal';
+            break;
+          default:
+            if (c === '\x1b') {
+              this.state = 'esc';
+            } else if (c < ' ' || c === '\x7f') {
+              this.control(c);
+            } else {
+              this.print(c);
+            }
+        }
+      }
+    }
+
+    control(c) {
+      switch (c) {
+        case '\r':
+          this.x = 0;
+          this.wrapPending = false;
+          break;
+        case '\n':
+        case '\x0b':
+        case '\x0c':
+          this.lineFeed();
+          break;
+        case '\b':
+          this.x = Math.max(0, this.x - 1);
+          this.wrapPending = false;
+          break;
+        case '\t':
+          this.x = Math.min(this.cols - 1, (Math.floor(this.x / 8) + 1) * 8);
+          break;
+      }
+    }
+
+    escape(c) {
+      this.state = 'normal';
+      switch (c) {
+        case '[':
+          this.state = 'csi';
+          this.params = '';
+          break;
+        case ']':
+        case 'P':
+        case 'X':
+        case '^':
+        case '_':
+          this.state = 'string';
+          break;
+        case '(':
+        case ')':
+        case '*':
+        case '+':
+          this.state = 'charset';
+          break;
+        case '7':
+          this.saveCursor();
+          break;
+        case '8':
+          this.restoreCursor();
+          break;
+        case 'D':
+          this.lineFeed();
+          break;
+        case 'E':
+          this.x = 0;
+          this.lineFeed();
+          break;
+        case 'M':
+          this.reverseIndex();
+          break;
+        case 'c':
+          this.reset();
+          break;
+      }
+    }
+
+    print(c) {
+      if (this.wrapPending) {
+        this.x = 0;
+        this.lineFeed();
+      }
+
+      this.lines[this.y][this.x] = {ch: c, style: this.style};
+
+      if (this.x === this.cols - 1) {
+        this.wrapPending = true;
+      } else {
+        this.x++;
+      }
+    }
+
+    lineFeed() {
+      this.wrapPending = false;
+      if (this.y === this.bottom) {
+        this.scrollUp(1);
+      } else if (this.y < this.rows - 1) {
+        this.y++;
+      }
+    }
+
+    reverseIndex() {
+      this.wrapPending = false;
+      if (this.y === this.top) {
+        this.scrollDown(1);
+      } else if (this.y > 0) {
+        this.y--;
+      }
+    }
+
+    scrollUp(n) {
+      for (let i = 0; i < n; i++) {
+        this.lines.splice(this.top, 1);
+        this.lines.splice(this.bottom, 0, this.blankLine());
+      }
+    }
+
+    scrollDown(n) {
+      for (let i = 0; i < n; i++) {
+        this.lines.splice(this.bottom, 1);
+        this.lines.splice(this.top, 0, this.blankLine());
+      }
+    }
+
+    saveCursor() {
+      this.saved = {x: this.x, y: this.y, style: this.style};
+    }
+
+    restoreCursor() {
+      if (this.saved) {
+        this.x = this.saved.x;
+        this.y = this.saved.y;
+        this.style = this.saved.style;
+      }
+      this.wrapPending = false;
+    }
+
+    erase(line, from, to) {
+      for (let i = from; i < to; i++) {
+        line[i] = BLANK;
+      }
+    }
+
+    csi(final) {
+      const isPrivate = this.params.startsWith('?');
+      const params = this.params.replace(/[?<=>]/g, '').split(';').map((v) => parseInt(v, 10));
+      const param = (i, def) => (isNaN(params[i]) || params[i] === 0 ? def : params[i]);
+      const n = param(0, 1);
+      const line = this.lines[this.y];
+
+      if (final !== 'm') {
+        this.wrapPending = false;
+      }
+
+      switch (final) {
+        case 'A':
+          this.y = clamp(this.y - n, 0, this.rows - 1);
+          break;
+        case 'B':
+        case 'e':
+          this.y = clamp(this.y + n, 0, this.rows - 1);
+          break;
+        case 'C':
+        case 'a':
+          this.x = clamp(this.x + n, 0, this.cols - 1);
+          break;
+        case 'D':
+          this.x = clamp(this.x - n, 0, this.cols - 1);
+          break;
+        case 'E':
+          this.y = clamp(this.y + n, 0, this.rows - 1);
+          this.x = 0;
+          break;
+        case 'F':
+          this.y = clamp(this.y - n, 0, this.rows - 1);
+          this.x = 0;
+          break;
+        case 'G':
+        case '`':
+          this.x = clamp(n - 1, 0, this.cols - 1);
+          break;
+        case 'd':
+          this.y = clamp(n - 1, 0, this.rows - 1);
+          break;
+        case 'H':
+        case 'f':
+          this.y = clamp(param(0, 1) - 1, 0, this.rows - 1);
+          this.x = clamp(param(1, 1) - 1, 0, this.cols - 1);
+          break;
+        case 'J':
+          switch (params[0] || 0) {
+            case 0:
+              this.erase(line, this.x, this.cols);
+              for (let i = this.y + 1; i < this.rows; i++) {
+                this.lines[i] = this.blankLine();
+              }
+              break;
+            case 1:
+              this.erase(line, 0, this.x + 1);
+              for (let i = 0; i < this.y; i++) {
+                this.lines[i] = this.blankLine();
+              }
+              break;
+            default:
+              this.lines = this.blankScreen();
+          }
+          break;
+        case 'K':
+          switch (params[0] || 0) {
+            case 0:
+              this.erase(line, this.x, this.cols);
+              break;
+            case 1:
+              this.erase(line, 0, this.x + 1);
+              break;
+            default:
+              this.erase(line, 0, this.cols);
+          }
+          break;
+        case 'L':
+          if (this.y >= this.top && this.y <= this.bottom) {
+            for (let i = 0; i < n; i++) {
+              this.lines.splice(this.bottom, 1);
+              this.lines.splice(this.y, 0, this.blankLine());
+            }
+          }
+          break;
+        case 'M':
+          if (this.y >= this.top && this.y <= this.bottom) {
+            for (let i = 0; i < n; i++) {
+              this.lines.splice(this.y, 1);
+              this.lines.splice(this.bottom, 0, this.blankLine());
+            }
+          }
+          break;
+        case 'P':
+          line.splice(this.x, n);
+          while (line.length < this.cols) {
+            line.push(BLANK);
+          }
+          break;
+        case '@':
+          line.splice(this.x, 0, ...new Array(n).fill(BLANK));
+          line.length = this.cols;
+          break;
+        case 'X':
+          this.erase(line, this.x, Math.min(this.cols, this.x + n));
+          break;
+        case 'S':
+          this.scrollUp(n);
+          break;
+        case 'T':
+          this.scrollDown(n);
+          break;
+        case 'r':
+          this.top = clamp(param(0, 1) - 1, 0, this.rows - 1);
+          this.bottom = clamp(param(1, this.rows) - 1, this.top, this.rows - 1);
+          this.x = 0;
+          this.y = 0;
+          break;
+        case 's':
+          this.saveCursor();
+          break;
+        case 'u':
+          this.restoreCursor();
+          break;
+        case 'h':
+        case 'l':
+          if (isPrivate) {
+            this.mode(params, final === 'h');
+          }
+          break;
+        case 'm':
+          this.sgr(params);
+          break;
+      }
+    }
+
+    mode(params, enabled) {
+      for (const p of params) {
+        switch (p) {
+          case 25:
+            this.cursorVisible = enabled;
+            break;
+          case 47:
+          case 1047:
+          case 1049:
+            if (enabled && !this.primary) {
+              if (p === 1049) {
+                this.saveCursor();
+              }
+              this.primary = this.lines;
+              this.lines = this.blankScreen();
+            } else if (!enabled && this.primary) {
+              this.lines = this.primary;
+              this.primary = null;
+              if (p === 1049) {
+                this.restoreCursor();
+              }
+            }
+            break;
+        }
+      }
+    }
+
+    sgr(params) {
+      const style = Object.assign({}, this.style);
+
+      for (let i = 0; i < params.length; i++) {
+        const p = isNaN(params[i]) ? 0 : params[i];
+
+        if (p === 0) {
+          Object.assign(style, DEFAULT_STYLE);
+        } else if (p === 1) {
+          style.bold = true;
+        } else if (p === 3) {
+          style.italic = true;
+        } else if (p === 4) {
+          style.underline = true;
+        } else if (p === 7) {
+          style.inverse = true;
+        } else if (p === 22) {
+          style.bold = false;
+        } else if (p === 23) {
+          style.italic = false;
+        } else if (p === 24) {
+          style.underline = false;
+        } else if (p === 27) {
+          style.inverse = false;
+        } else if (p >= 30 && p <= 37) {
+          style.fg = PALETTE[p - 30];
+        } else if (p === 39) {
+          style.fg = null;
+        } else if (p >= 40 && p <= 47) {
+          style.bg = PALETTE[p - 40];
+        } else if (p === 49) {
+          style.bg = null;
+        } else if (p >= 90 && p <= 97) {
+          style.fg = PALETTE[p - 90 + 8];
+        } else if (p >= 100 && p <= 107) {
+          style.bg = PALETTE[p - 100 + 8];
+        } else if (p === 38 || p === 48) {
+          let color = null;
+          if (params[i + 1] === 5) {
+            color = color256(clamp(params[i + 2] || 0, 0, 255));
+            i += 2;
+          } else if (params[i + 1] === 2) {
+            color = rgb(params[i + 2] || 0, params[i + 3] || 0, params[i + 4] || 0);
+            i += 4;
+          }
+          if (p === 38) {
+            style.fg = color;
+          } else {
+            style.bg = color;
+          }
+        }
+      }
+
+      this.style = Object.freeze(style);
+    }
+
+    render(container) {
+      const fragment = document.createDocumentFragment();
+
+      this.lines.forEach((line, y) => {
+        const row = document.createElement('div');
+        row.className = 'line';
+
+        let span = null;
+        let current = null;
+
+        line.forEach((cell, x) => {
+          const cursor = this.cursorVisible && x === this.x && y === this.y;
+          if (span === null || cell.style !== current || cursor || span.classList.contains('cursor')) {
+            span = this.span(cell.style, cursor);
+            current = cell.style;
+            row.appendChild(span);
+          }
+          span.textContent += cell.ch;
+        });
+
+        fragment.appendChild(row);
+      });
+
+      container.replaceChildren(fragment);
+    }
+
+    span(style, cursor) {
+      const span = document.createElement('span');
+      let fg = style.fg;
+      let bg = style.bg;
+
+      if (style.inverse !== cursor) {
+        fg = style.bg || 'var(--player-bg)';
+        bg = style.fg || 'var(--player-fg)';
+      }
+
+      if (fg) {
+        span.style.color = fg;
+      }
+      if (bg) {
+        span.style.backgroundColor = bg;
+      }
+      if (style.bold) {
+        span.style.fontWeight = 'bold';
+      }
+      if (style.italic) {
+        span.style.fontStyle = 'italic';
+      }
+      if (style.underline) {
+        span.style.textDecoration = 'underline';
+      }
+      if (cursor) {
+        span.classList.add('cursor');
+      }
+
+      return span;
+    }
+  }
+
+  function parseCast(text) {
+    const lines = text.split('\n').filter((l) => l.trim() !== '');
+    const header = JSON.parse(lines[0]);
+    const idleLimit = header.idle_time_limit || 0;
+
+    const events = [];
+    let previous = 0;
+    let shift = 0;
+
+    for (const l of lines.slice(1)) {
+      const [time, type, data] = JSON.parse(l);
+      if (type !== 'o') {
+        continue;
+      }
+
+      // long pauses are shortened when the recording defines an idle time limit
+      if (idleLimit > 0 && time - previous > idleLimit) {
+        shift += time - previous - idleLimit;
+      }
+      previous = time;
+
+      events.push({time: time - shift, data: data});
+    }
+
+    return {header: header, events: events};
+  }
+
+  function formatTime(seconds) {
+    const s = Math.floor(seconds);
+    return Math.floor(s / 60) + ':' + String(s % 60).padStart(2, '0');
+  }
+
+  class Player {
+    constructor(el, cast) {
+      this.events = cast.events;
+      this.duration = this.events.length ? this.events[this.events.length - 1].time : 0;
+      this.terminal = new Terminal(cast.header.width || 80, cast.header.height || 24);
+      this.index = 0;
+      this.position = 0;
+      this.speed = 1;
+      this.frame = null;
+
+      this.screen = document.createElement('pre');
+      this.screen.className = 'player-screen';
+
+      const controls = document.createElement('div');
+      controls.className = 'player-controls';
+
+      this.button = document.createElement('button');
+      this.button.type = 'button';
+      this.button.addEventListener('click', () => (this.frame ? this.pause() : this.play()));
+
+      this.progress = document.createElement('input');
+      this.progress.type = 'range';
+      this.progress.min = '0';
+      this.progress.max = String(this.duration);
+      this.progress.step = '0.1';
+      this.progress.addEventListener('input', () => this.seek(parseFloat(this.progress.value)));
+
+      this.clock = document.createElement('span');
+
+      const speed = document.createElement('select');
+      for (const s of [0.5, 1, 2, 4, 8]) {
+        const option = new Option(s + 'x', String(s), s === 1, s === 1);
+        speed.appendChild(option);
+      }
+      speed.addEventListener('change', () => {
+        const playing = this.frame !== null;
+        this.pause();
+        this.speed = parseFloat(speed.value);
+        if (playing) {
+          this.play();
+        }
+      });
+
+      controls.append(this.button, this.progress, this.clock, speed);
+      el.append(this.screen, controls);
+
+      this.update();
+    }
+
+    play() {
+      if (this.position >= this.duration) {
+        this.seek(0);
+      }
+      this.startedAt = performance.now() - (this.position * 1000) / this.speed;
+      this.frame = requestAnimationFrame(() => this.tick());
+      this.update();
+    }
+
+    pause() {
+      if (this.frame !== null) {
+        cancelAnimationFrame(this.frame);
+        this.frame = null;
+      }
+      this.update();
+    }
+
+    tick() {
+      this.advance(((performance.now() - this.startedAt) * this.speed) / 1000);
+
+      if (this.position >= this.duration) {
+        this.frame = null;
+      } else {
+        this.frame = requestAnimationFrame(() => this.tick());
+      }
+      this.update();
+    }
+
+    seek(position) {
+      if (position < this.position) {
+        this.terminal.reset();
+        this.index = 0;
+      }
+      this.advance(position);
+      if (this.frame !== null) {
+        this.startedAt = performance.now() - (this.position * 1000) / this.speed;
+      }
+      this.update();
+    }
+
+    advance(position) {
+      this.position = Math.min(position, this.duration);
+      while (this.index < this.events.length && this.events[this.index].time <= this.position) {
+        this.terminal.feed(this.events[this.index].data);
+        this.index++;
+      }
+    }
+
+    update() {
+      this.terminal.render(this.screen);
+      this.button.textContent = this.frame !== null ? 'Pause' : 'Play';
+      this.progress.value = String(this.position);
+      this.clock.textContent = formatTime(this.position) + ' / ' + formatTime(this.duration);
+    }
+  }
+
+  document.addEventListener('DOMContentLoaded', function () {
+    const el = document.getElementById('player');
+
+    fetch(el.dataset.cast, {credentials: 'same-origin'})
+      .then((response) => {
+        if (!response.ok) {
+          throw new Error('unable to load recording: ' + response.status);
+        }
+        return response.text();
+      })
+      .then((text) => new Player(el, parseCast(text)))
+      .catch((err) => {
+        el.textContent = err.message;
+      });
+  });
+})();
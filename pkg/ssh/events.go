@@ -0,0 +1,109 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"tailscale.com/client/tailscale"
+	"tailscale.com/tailcfg"
+	"time"
+)
+
+const (
+	// EventsPath is the endpoint of the control server receiving the session events of the recorders.
+	EventsPath = "/ssh/events"
+	// EventsAudience is the audience of the ID token a recorder uses to authenticate against the events endpoint.
+	EventsAudience = "ionscale-recorder"
+
+	SessionStarted = "start"
+	SessionEnded   = "end"
+)
+
+// SessionEvent is sent by a recorder to the control server when a recorded session starts or ends.
+type SessionEvent struct {
+	Type         string               `json:"type"`
+	ConnectionID string               `json:"connectionID"`
+	SrcNode      string               `json:"srcNode"`
+	SrcNodeID    tailcfg.StableNodeID `json:"srcNodeID"`
+	DstNode      string               `json:"dstNode"`
+	DstNodeID    tailcfg.StableNodeID `json:"dstNodeID"`
+	SSHUser      string               `json:"sshUser"`
+	LocalUser    string               `json:"localUser"`
+	Recording    string               `json:"recording"`
+	Timestamp    time.Time            `json:"timestamp"`
+}
+
+func newSessionEvent(eventType string, recording Recording, location string, timestamp time.Time) SessionEvent {
+	return SessionEvent{
+		Type:         eventType,
+		ConnectionID: recording.Header.ConnectionID,
+		SrcNode:      recording.Header.SrcNode,
+		SrcNodeID:    recording.Header.SrcNodeID,
+		DstNode:      recording.DstNode,
+		DstNodeID:    recording.DstNodeID,
+		SSHUser:      recording.Header.SSHUser,
+		LocalUser:    recording.Header.LocalUser,
+		Recording:    location,
+		Timestamp:    timestamp,
+	}
+}
+
+type eventReporter struct {
+	url string
+	lc  *tailscale.LocalClient
+}
+
+func newEventReporter(loginServer string, lc *tailscale.LocalClient) *eventReporter {
+	return &eventReporter{
+		url: strings.TrimSuffix(loginServer, "/") + EventsPath,
+		lc:  lc,
+	}
+}
+
+// ReportAsync sends the event in the background, a failing control server never breaks a recording.
+func (r *eventReporter) ReportAsync(event SessionEvent) {
+	go func() {
+		if err := r.Report(context.Background(), event); err != nil {
+			zap.L().Error("error reporting ssh session event", zap.Error(err))
+		}
+	}()
+}
+
+// Report sends the event to the control server, authenticated with an ID token of the recorder node.
+func (r *eventReporter) Report(ctx context.Context, event SessionEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	token, err := r.lc.IDToken(ctx, EventsAudience)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token.IDToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d reporting session event", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package ssh
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"tailscale.com/tailcfg"
+	"time"
+)
+
+// Recording is an entry in the index of the recorder, describing a single SSH session.
+type Recording struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	StartedAt time.Time            `json:"startedAt"`
+	EndedAt   *time.Time           `json:"endedAt,omitempty"`
+	Size      int64                `json:"size"`
+	DstNode   string               `json:"dstNode"`
+	DstNodeID tailcfg.StableNodeID `json:"dstNodeID"`
+	Header    CastHeader           `json:"header"`
+	Deleted   bool                 `json:"deleted,omitempty"`
+}
+
+type RecordingFilter struct {
+	SrcNode string
+	DstNode string
+	SSHUser string
+	Since   time.Time
+	Until   time.Time
+}
+
+func (f RecordingFilter) matches(r *Recording) bool {
+	if f.SrcNode != "" && !matchesNode(f.SrcNode, r.Header.SrcNode, string(r.Header.SrcNodeID)) {
+		return false
+	}
+	if f.DstNode != "" && !matchesNode(f.DstNode, r.DstNode, string(r.DstNodeID)) {
+		return false
+	}
+	if f.SSHUser != "" && f.SSHUser != r.Header.SSHUser && f.SSHUser != r.Header.LocalUser {
+		return false
+	}
+	if !f.Since.IsZero() && r.StartedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && r.StartedAt.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// matchesNode accepts the stable node id, the full MagicDNS name or only the hostname of a node.
+func matchesNode(value, fqdn, id string) bool {
+	fqdn = strings.TrimSuffix(fqdn, ".")
+	hostname, _, _ := strings.Cut(fqdn, ".")
+	return value == id || strings.EqualFold(value, fqdn) || strings.EqualFold(value, hostname)
+}
+
+// Index keeps track of all recordings, persisted as an append-only file of JSON lines,
+// where a later line for the same recording replaces the previous one.
+type Index struct {
+	sync.Mutex
+	path       string
+	recordings map[string]*Recording
+}
+
+func OpenIndex(path string) (*Index, error) {
+	idx := &Index{path: path, recordings: map[string]*Recording{}}
+
+	f, err := os.Open(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if f != nil {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var r Recording
+			if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+				continue
+			}
+			if r.Deleted {
+				delete(idx.recordings, r.ID)
+			} else {
+				idx.recordings[r.ID] = &r
+			}
+		}
+		_ = f.Close()
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// compact the index, dropping replaced and deleted entries
+	if err := idx.rewrite(); err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+func (i *Index) Save(r Recording) error {
+	i.Lock()
+	defer i.Unlock()
+
+	if err := i.append(r); err != nil {
+		return err
+	}
+
+	i.recordings[r.ID] = &r
+
+	return nil
+}
+
+func (i *Index) RemoveByName(name string) error {
+	i.Lock()
+	defer i.Unlock()
+
+	for id, r := range i.recordings {
+		if r.Name == name {
+			if err := i.append(Recording{ID: id, Deleted: true}); err != nil {
+				return err
+			}
+			delete(i.recordings, id)
+		}
+	}
+
+	return nil
+}
+
+func (i *Index) Get(id string) (Recording, bool) {
+	i.Lock()
+	defer i.Unlock()
+
+	r, ok := i.recordings[id]
+	if !ok {
+		return Recording{}, false
+	}
+	return *r, true
+}
+
+// List returns the recordings matching the filter, most recent first.
+func (i *Index) List(filter RecordingFilter) []Recording {
+	i.Lock()
+	defer i.Unlock()
+
+	var result = []Recording{}
+	for _, r := range i.recordings {
+		if filter.matches(r) {
+			result = append(result, *r)
+		}
+	}
+
+	sort.Slice(result, func(a, b int) bool { return result[a].StartedAt.After(result[b].StartedAt) })
+
+	return result
+}
+
+func (i *Index) append(r Recording) error {
+	f, err := os.OpenFile(i.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(r)
+}
+
+func (i *Index) rewrite() error {
+	tmp := i.path + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, r := range i.recordings {
+		if err := enc.Encode(r); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, i.path)
+}
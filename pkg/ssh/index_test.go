@@ -0,0 +1,51 @@
+package ssh
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestIndex_ListAndFilter(t *testing.T) {
+	p := path.Join(t.TempDir(), "recordings.jsonl")
+	idx, err := OpenIndex(p)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	require.NoError(t, idx.Save(Recording{ID: "a", Name: "n1/a.cast", StartedAt: now.Add(-time.Hour), DstNode: "server.example.ts.net", Header: CastHeader{SrcNode: "laptop.example.ts.net.", SSHUser: "root"}}))
+	require.NoError(t, idx.Save(Recording{ID: "b", Name: "n1/b.cast", StartedAt: now, DstNode: "db.example.ts.net", Header: CastHeader{SrcNode: "laptop.example.ts.net.", SSHUser: "admin"}}))
+
+	all := idx.List(RecordingFilter{})
+	require.Len(t, all, 2)
+	assert.Equal(t, "b", all[0].ID)
+
+	assert.Len(t, idx.List(RecordingFilter{SrcNode: "laptop"}), 2)
+	assert.Len(t, idx.List(RecordingFilter{DstNode: "db"}), 1)
+	assert.Len(t, idx.List(RecordingFilter{SSHUser: "root"}), 1)
+	assert.Len(t, idx.List(RecordingFilter{Since: now.Add(-time.Minute)}), 1)
+}
+
+func TestIndex_ReopenAppliesUpdatesAndRemovals(t *testing.T) {
+	p := path.Join(t.TempDir(), "recordings.jsonl")
+	idx, err := OpenIndex(p)
+	require.NoError(t, err)
+
+	endedAt := time.Now().UTC()
+	require.NoError(t, idx.Save(Recording{ID: "a", Name: "n1/a.cast"}))
+	require.NoError(t, idx.Save(Recording{ID: "a", Name: "n1/a.cast", EndedAt: &endedAt, Size: 42}))
+	require.NoError(t, idx.Save(Recording{ID: "b", Name: "n1/b.cast"}))
+	require.NoError(t, idx.RemoveByName("n1/b.cast"))
+
+	reopened, err := OpenIndex(p)
+	require.NoError(t, err)
+
+	r, ok := reopened.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, int64(42), r.Size)
+	assert.NotNil(t, r.EndedAt)
+
+	_, ok = reopened.Get("b")
+	assert.False(t, ok)
+}
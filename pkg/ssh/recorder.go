@@ -3,8 +3,11 @@ package ssh
 import (
 	"bufio"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"golang.org/x/sync/errgroup"
 	"io"
@@ -12,28 +15,58 @@ import (
 	"os"
 	"os/signal"
 	"path"
+	"strings"
 	"syscall"
+	"tailscale.com/client/tailscale"
+	"tailscale.com/hostinfo"
 	"tailscale.com/tailcfg"
 	"tailscale.com/tsnet"
 	"time"
 )
 
+// recorderApp is the app advertised in the host info, so the control server
+// recognizes the node as a session recorder listening on the advertised port.
+const recorderApp = "ionscale-recorder"
+
 type RecorderConfig struct {
 	LoginServer string
 	StateDir    string
 	Dir         string
 	AuthKey     string
 	Hostname    string
+	Port        uint16
+
+	Storage          string
+	RetentionMaxAge  time.Duration
+	RetentionMaxSize uint64
+	S3               S3Config
+
+	ApiKey string
 }
 
+// CastHeader is the asciicast header sent by Tailscale SSH at the start of a recording.
 type CastHeader struct {
-	Timestamp int64                `json:"timestamp"`
-	SrcNodeID tailcfg.StableNodeID `json:"srcNodeID"`
+	Version       int                  `json:"version"`
+	Width         int                  `json:"width"`
+	Height        int                  `json:"height"`
+	Timestamp     int64                `json:"timestamp"`
+	Env           map[string]string    `json:"env"`
+	Command       string               `json:"command,omitempty"`
+	SrcNode       string               `json:"srcNode"`
+	SrcNodeID     tailcfg.StableNodeID `json:"srcNodeID"`
+	SrcNodeTags   []string             `json:"srcNodeTags,omitempty"`
+	SrcNodeUserID tailcfg.UserID       `json:"srcNodeUserID,omitempty"`
+	SrcNodeUser   string               `json:"srcNodeUser,omitempty"`
+	SSHUser       string               `json:"sshUser"`
+	LocalUser     string               `json:"localUser"`
+	ConnectionID  string               `json:"connectionID"`
 }
 
 func Start(ctx context.Context, c RecorderConfig) error {
 	ctx = contextWithSigterm(ctx)
 
+	hostinfo.SetApp(fmt.Sprintf("%s:%d", recorderApp, c.Port))
+
 	s := &tsnet.Server{
 		ControlURL: c.LoginServer,
 		Dir:        c.StateDir,
@@ -41,15 +74,49 @@ func Start(ctx context.Context, c RecorderConfig) error {
 		Hostname:   c.Hostname,
 	}
 
+	index, err := OpenIndex(path.Join(c.StateDir, "recordings.jsonl"))
+	if err != nil {
+		return err
+	}
+
+	storage, err := NewStorage(c, func(name string) { _ = index.RemoveByName(name) })
+	if err != nil {
+		return err
+	}
+
 	if err := waitTSReady(ctx, s); err != nil {
 		return err
 	}
 
+	lc, err := s.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := lc.StatusWithoutPeers(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the location of a recording reported to the control server,
+	// the page to play it when the API is enabled, otherwise its name in the storage
+	host := strings.TrimSuffix(status.Self.DNSName, ".")
+	locate := func(r Recording) string {
+		if c.ApiKey == "" {
+			return r.Name
+		}
+		return fmt.Sprintf("http://%s:%d/recordings/%s", host, c.Port, r.ID)
+	}
+
 	mux := echo.New()
 	mux.HideBanner = true
-	mux.POST("/record", record(c.Dir))
+	mux.POST("/record", record(storage, index, lc, newEventReporter(c.LoginServer, lc), locate))
 
-	ln, err := s.Listen("tcp", ":80")
+	if c.ApiKey != "" {
+		registerAPI(mux, c.ApiKey, index, storage)
+	}
+
+	ln, err := s.Listen("tcp", fmt.Sprintf(":%d", c.Port))
 	if err != nil {
 		return err
 	}
@@ -68,6 +135,10 @@ func Start(ctx context.Context, c RecorderConfig) error {
 		return nil
 	})
 
+	g.Go(func() error {
+		return storage.Run(gCtx)
+	})
+
 	return g.Wait()
 }
 
@@ -100,10 +171,17 @@ func contextWithSigterm(ctx context.Context) context.Context {
 	return ctxWithCancel
 }
 
-func record(dir string) func(echo.Context) error {
+func record(storage Storage, index *Index, lc *tailscale.LocalClient, events *eventReporter, locate func(Recording) string) func(echo.Context) error {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		reader := bufio.NewReader(c.Request().Body)
 
+		// the destination of the SSH session is the node uploading the recording
+		who, err := lc.WhoIs(ctx, c.Request().RemoteAddr)
+		if err != nil {
+			return err
+		}
+
 		line, err := reader.ReadBytes('\n')
 		if err != nil {
 			return err
@@ -114,29 +192,61 @@ func record(dir string) func(echo.Context) error {
 			return err
 		}
 
-		timstamp := time.Unix(header.Timestamp, 0)
+		entry := Recording{
+			ID:        newRecordingID(),
+			Name:      recordingName(header),
+			StartedAt: time.Unix(header.Timestamp, 0).UTC(),
+			DstNode:   strings.TrimSuffix(who.Node.Name, "."),
+			DstNodeID: who.Node.StableID,
+			Header:    header,
+		}
 
-		nodeRecordingDir := path.Join(dir, string(header.SrcNodeID))
-		nodeRecordingFilePath := path.Join(nodeRecordingDir, timstamp.Format(time.RFC3339)+".cast")
+		w, err := storage.Create(ctx, entry.Name)
+		if err != nil {
+			return err
+		}
 
-		if err = os.MkdirAll(nodeRecordingDir, 0755); err != nil {
+		// index the session as soon as it starts, so it can be found while still running
+		if err := index.Save(entry); err != nil {
+			_ = w.Close()
 			return err
 		}
 
-		f, err := os.OpenFile(nodeRecordingFilePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
-		if err != nil {
+		events.ReportAsync(newSessionEvent(SessionStarted, entry, locate(entry), entry.StartedAt))
+
+		if _, err := w.Write(line); err != nil {
+			_ = w.Close()
 			return err
 		}
 
-		_, err = f.Write(line)
-		if err != nil {
+		// the recording is streamed while the session is running,
+		// and finalized even when the connection breaks halfway
+		n, copyErr := io.Copy(w, reader)
+
+		if err := w.Close(); err != nil {
 			return err
 		}
 
-		if _, err := io.Copy(f, reader); err != nil {
+		endedAt := time.Now().UTC()
+		entry.EndedAt = &endedAt
+		entry.Size = int64(len(line)) + n
+
+		if err := index.Save(entry); err != nil {
 			return err
 		}
 
+		events.ReportAsync(newSessionEvent(SessionEnded, entry, locate(entry), endedAt))
+
+		if copyErr != nil {
+			return copyErr
+		}
+
 		return c.String(200, "ok")
 	}
 }
+
+func newRecordingID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,82 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+)
+
+const retentionInterval = 1 * time.Minute
+
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+)
+
+// Storage persists SSH session recordings.
+type Storage interface {
+	// Create starts a new recording. Data written to the returned writer is persisted
+	// while the session is still running, closing the writer finalizes the recording.
+	Create(ctx context.Context, name string) (io.WriteCloser, error)
+
+	// Open returns the content of a recording.
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+
+	// Run executes the background tasks of the storage, like enforcing the retention policy
+	// or recovering recordings interrupted by a crash, until the context is cancelled.
+	Run(ctx context.Context) error
+}
+
+// NewStorage creates the storage backend, onRemove is called for every recording removed by the retention policy.
+func NewStorage(c RecorderConfig, onRemove func(name string)) (Storage, error) {
+	switch c.Storage {
+	case "", StorageLocal:
+		return newLocalStorage(c.Dir, c.RetentionMaxAge, c.RetentionMaxSize, onRemove)
+	case StorageS3:
+		return newS3Storage(c.S3, path.Join(c.StateDir, "spool"), c.RetentionMaxAge, c.RetentionMaxSize, onRemove)
+	default:
+		return nil, fmt.Errorf("unknown recording storage: %s", c.Storage)
+	}
+}
+
+type recordingFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// expiredRecordings returns the recordings older than the maximum age, and afterwards
+// the oldest recordings until the total size is below the maximum size.
+func expiredRecordings(files []recordingFile, now time.Time, maxAge time.Duration, maxSize uint64) []recordingFile {
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+
+	var expired []recordingFile
+	var kept []recordingFile
+	var totalSize uint64
+	for _, f := range files {
+		if maxAge != 0 && now.Sub(f.modTime) > maxAge {
+			expired = append(expired, f)
+			continue
+		}
+		kept = append(kept, f)
+		totalSize += uint64(f.size)
+	}
+
+	for _, f := range kept {
+		if maxSize == 0 || totalSize <= maxSize {
+			break
+		}
+		expired = append(expired, f)
+		totalSize -= uint64(f.size)
+	}
+
+	return expired
+}
+
+func recordingName(header CastHeader) string {
+	timestamp := time.Unix(header.Timestamp, 0)
+	return path.Join(string(header.SrcNodeID), timestamp.Format(time.RFC3339)+".cast")
+}
@@ -0,0 +1,149 @@
+package ssh
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type localStorage struct {
+	sync.Mutex
+	dir      string
+	maxAge   time.Duration
+	maxSize  uint64
+	active   map[string]bool
+	onRemove func(name string)
+}
+
+func newLocalStorage(dir string, maxAge time.Duration, maxSize uint64, onRemove func(name string)) (*localStorage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &localStorage{
+		dir:      filepath.Clean(dir),
+		maxAge:   maxAge,
+		maxSize:  maxSize,
+		active:   map[string]bool{},
+		onRemove: onRemove,
+	}, nil
+}
+
+func (s *localStorage) Create(_ context.Context, name string) (io.WriteCloser, error) {
+	filePath := path.Join(s.dir, name)
+
+	if err := os.MkdirAll(path.Dir(filePath), 0755); err != nil {
+		return nil, err
+	}
+
+	// every write goes straight to the file, so a crash of the recorder
+	// only loses the data that was still in flight
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Lock()
+	s.active[filePath] = true
+	s.Unlock()
+
+	return &localRecording{File: f, onClose: func() {
+		s.Lock()
+		delete(s.active, filePath)
+		s.Unlock()
+	}}, nil
+}
+
+func (s *localStorage) Open(_ context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(path.Join(s.dir, name))
+}
+
+func (s *localStorage) Run(ctx context.Context) error {
+	if s.maxAge == 0 && s.maxSize == 0 {
+		return nil
+	}
+
+	t := time.NewTicker(retentionInterval)
+	defer t.Stop()
+
+	for {
+		if err := s.applyRetention(time.Now()); err != nil {
+			zap.L().Error("unable to apply recording retention", zap.Error(err))
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// applyRetention removes the recordings expired by the retention policy, recordings in progress are never removed.
+func (s *localStorage) applyRetention(now time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	var files []recordingFile
+	err := filepath.WalkDir(s.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(p) != ".cast" || s.active[p] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		files = append(files, recordingFile{path: p, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, f := range expiredRecordings(files, now, s.maxAge, s.maxSize) {
+		if err := s.remove(f.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *localStorage) remove(filePath string) error {
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if s.onRemove != nil {
+		if name, err := filepath.Rel(s.dir, filePath); err == nil {
+			s.onRemove(filepath.ToSlash(name))
+		}
+	}
+
+	return nil
+}
+
+type localRecording struct {
+	*os.File
+	onClose func()
+}
+
+func (r *localRecording) Close() error {
+	defer r.onClose()
+	return r.File.Close()
+}
@@ -0,0 +1,69 @@
+package ssh
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestLocalStorage_RetentionByAge(t *testing.T) {
+	dir := t.TempDir()
+	s, err := newLocalStorage(dir, time.Hour, 0, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	writeRecording(t, s, "node1/old.cast", 10, now.Add(-2*time.Hour))
+	writeRecording(t, s, "node1/new.cast", 10, now.Add(-30*time.Minute))
+
+	require.NoError(t, s.applyRetention(now))
+
+	assert.NoFileExists(t, path.Join(dir, "node1/old.cast"))
+	assert.FileExists(t, path.Join(dir, "node1/new.cast"))
+}
+
+func TestLocalStorage_RetentionBySize(t *testing.T) {
+	dir := t.TempDir()
+	s, err := newLocalStorage(dir, 0, 25, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	writeRecording(t, s, "node1/a.cast", 10, now.Add(-3*time.Hour))
+	writeRecording(t, s, "node2/b.cast", 10, now.Add(-2*time.Hour))
+	writeRecording(t, s, "node1/c.cast", 10, now.Add(-1*time.Hour))
+
+	require.NoError(t, s.applyRetention(now))
+
+	assert.NoFileExists(t, path.Join(dir, "node1/a.cast"))
+	assert.FileExists(t, path.Join(dir, "node2/b.cast"))
+	assert.FileExists(t, path.Join(dir, "node1/c.cast"))
+}
+
+func TestLocalStorage_RetentionSkipsActiveRecordings(t *testing.T) {
+	dir := t.TempDir()
+	s, err := newLocalStorage(dir, time.Hour, 0, nil)
+	require.NoError(t, err)
+
+	w, err := s.Create(context.Background(), "node1/active.cast")
+	require.NoError(t, err)
+	defer w.Close()
+
+	filePath := path.Join(dir, "node1/active.cast")
+	require.NoError(t, os.Chtimes(filePath, time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour)))
+
+	require.NoError(t, s.applyRetention(time.Now()))
+
+	assert.FileExists(t, filePath)
+}
+
+func writeRecording(t *testing.T, s *localStorage, name string, size int, modTime time.Time) {
+	w, err := s.Create(context.Background(), name)
+	require.NoError(t, err)
+	_, err = w.Write(make([]byte, size))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, os.Chtimes(path.Join(s.dir, name), modTime, modTime))
+}
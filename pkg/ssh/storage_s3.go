@@ -0,0 +1,411 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/go-multierror"
+	"go.uber.org/zap"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// S3 requires all parts of a multipart upload, except the last one, to be at least 5 MiB.
+const s3PartSize = 5 * 1024 * 1024
+
+const (
+	s3MinBackoff = 1 * time.Second
+	s3MaxBackoff = 5 * time.Minute
+)
+
+type S3Config struct {
+	Bucket    string
+	Prefix    string
+	Endpoint  string
+	Region    string
+	PathStyle bool
+}
+
+// s3Storage streams recordings to an S3 compatible object storage using multipart uploads.
+// While a session is running, the recording is also written to a local spool file,
+// which is uploaded when the recorder is restarted after a crash.
+type s3Storage struct {
+	client   *s3.S3
+	bucket   string
+	prefix   string
+	spoolDir string
+	started  time.Time
+	spooled  map[string]bool
+	maxAge   time.Duration
+	maxSize  uint64
+	onRemove func(name string)
+}
+
+func newS3Storage(c S3Config, spoolDir string, maxAge time.Duration, maxSize uint64, onRemove func(name string)) (*s3Storage, error) {
+	if err := os.MkdirAll(spoolDir, 0700); err != nil {
+		return nil, err
+	}
+
+	cfg := aws.NewConfig().WithS3ForcePathStyle(c.PathStyle)
+	if c.Endpoint != "" {
+		cfg = cfg.WithEndpoint(c.Endpoint)
+	}
+	if c.Region != "" {
+		cfg = cfg.WithRegion(c.Region)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Storage{
+		client:   s3.New(sess),
+		bucket:   c.Bucket,
+		prefix:   c.Prefix,
+		spoolDir: spoolDir,
+		started:  time.Now(),
+		spooled:  map[string]bool{},
+		maxAge:   maxAge,
+		maxSize:  maxSize,
+		onRemove: onRemove,
+	}, nil
+}
+
+func (s *s3Storage) Create(ctx context.Context, name string) (io.WriteCloser, error) {
+	key := s.key(name)
+
+	spool, err := os.OpenFile(s.spoolPath(key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Recording{ctx: ctx, storage: s, key: key, spool: spool}, nil
+}
+
+func (s *s3Storage) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+// Run recovers the recordings of sessions interrupted by a previous crash of the recorder, and afterwards
+// removes the recordings expired by the retention policy until the context is cancelled. Failures are logged
+// and retried, an unavailable object storage never stops the recorder.
+func (s *s3Storage) Run(ctx context.Context) error {
+	backoff := s3MinBackoff
+	for {
+		err := s.recoverInterrupted(ctx)
+		if err == nil {
+			break
+		}
+
+		zap.L().Error("unable to recover interrupted recordings", zap.Error(err), zap.Duration("retry_in", backoff))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil
+		}
+
+		backoff = min(2*backoff, s3MaxBackoff)
+	}
+
+	if s.maxAge == 0 && s.maxSize == 0 {
+		return nil
+	}
+
+	t := time.NewTicker(retentionInterval)
+	defer t.Stop()
+
+	for {
+		if err := s.applyRetention(ctx, time.Now()); err != nil {
+			zap.L().Error("unable to apply recording retention", zap.Error(err))
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// recoverInterrupted uploads the spooled recordings as a whole, and completes the multipart uploads without
+// a spool file with the parts already uploaded, so that at least part of the recording is kept.
+// Recovered recordings are removed from the spool directory and the pending uploads, so it can be retried after a failure.
+func (s *s3Storage) recoverInterrupted(ctx context.Context) error {
+	entries, err := os.ReadDir(s.spoolDir)
+	if err != nil {
+		return err
+	}
+
+	var result *multierror.Error
+
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.ModTime().Before(s.started) {
+			continue
+		}
+
+		key, err := url.PathUnescape(e.Name())
+		if err != nil {
+			continue
+		}
+
+		s.spooled[key] = true
+
+		if err := s.uploadSpool(ctx, key); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	var uploads []*s3.MultipartUpload
+	input := &s3.ListMultipartUploadsInput{Bucket: aws.String(s.bucket), Prefix: aws.String(s.keyPrefix())}
+	err = s.client.ListMultipartUploadsPagesWithContext(ctx, input, func(output *s3.ListMultipartUploadsOutput, _ bool) bool {
+		for _, u := range output.Uploads {
+			if u.Initiated != nil && u.Initiated.Before(s.started) {
+				uploads = append(uploads, u)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return multierror.Append(result, err)
+	}
+
+	for _, u := range uploads {
+		// a spooled recording replaces the upload, even when the spool file was already uploaded by a previous attempt
+		if s.spooled[aws.StringValue(u.Key)] {
+			if err := s.abortUpload(ctx, u.Key, u.UploadId); err != nil {
+				result = multierror.Append(result, err)
+			}
+			continue
+		}
+
+		if err := s.completeAbandonedUpload(ctx, u.Key, u.UploadId); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// applyRetention removes the recordings expired by the retention policy. Recordings in progress are
+// pending multipart uploads, or not uploaded at all, so they are never removed.
+func (s *s3Storage) applyRetention(ctx context.Context, now time.Time) error {
+	var files []recordingFile
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(s.bucket), Prefix: aws.String(s.keyPrefix())}
+	err := s.client.ListObjectsV2PagesWithContext(ctx, input, func(output *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range output.Contents {
+			if strings.HasSuffix(aws.StringValue(o.Key), ".cast") {
+				files = append(files, recordingFile{path: aws.StringValue(o.Key), size: aws.Int64Value(o.Size), modTime: aws.TimeValue(o.LastModified)})
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, f := range expiredRecordings(files, now, s.maxAge, s.maxSize) {
+		_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(f.path),
+		})
+		if err != nil {
+			return err
+		}
+
+		if s.onRemove != nil {
+			s.onRemove(strings.TrimPrefix(f.path, s.keyPrefix()))
+		}
+	}
+
+	return nil
+}
+
+// key returns the object key of a recording.
+func (s *s3Storage) key(name string) string {
+	return s.keyPrefix() + strings.TrimPrefix(name, "/")
+}
+
+// keyPrefix returns the prefix shared by the object keys of all recordings, it ends with a separator
+// so that other prefixes starting with the same characters are not included.
+func (s *s3Storage) keyPrefix() string {
+	prefix := strings.Trim(s.prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
+func (s *s3Storage) spoolPath(key string) string {
+	return path.Join(s.spoolDir, url.PathEscape(key))
+}
+
+func (s *s3Storage) uploadSpool(ctx context.Context, key string) error {
+	f, err := os.Open(s.spoolPath(key))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   f,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(s.spoolPath(key))
+}
+
+func (s *s3Storage) abortUpload(ctx context.Context, key, uploadID *string) error {
+	_, err := s.client.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      key,
+		UploadId: uploadID,
+	})
+	return err
+}
+
+func (s *s3Storage) completeAbandonedUpload(ctx context.Context, key, uploadID *string) error {
+	var parts []*s3.CompletedPart
+	input := &s3.ListPartsInput{Bucket: aws.String(s.bucket), Key: key, UploadId: uploadID}
+	err := s.client.ListPartsPagesWithContext(ctx, input, func(output *s3.ListPartsOutput, _ bool) bool {
+		for _, p := range output.Parts {
+			parts = append(parts, &s3.CompletedPart{ETag: p.ETag, PartNumber: p.PartNumber})
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(parts) == 0 {
+		return s.abortUpload(ctx, key, uploadID)
+	}
+
+	_, err = s.client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             key,
+		UploadId:        uploadID,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	return err
+}
+
+type s3Recording struct {
+	ctx      context.Context
+	storage  *s3Storage
+	key      string
+	spool    *os.File
+	buf      bytes.Buffer
+	uploadID *string
+	parts    []*s3.CompletedPart
+}
+
+func (r *s3Recording) Write(p []byte) (int, error) {
+	if _, err := r.spool.Write(p); err != nil {
+		return 0, err
+	}
+
+	r.buf.Write(p)
+
+	for r.buf.Len() >= s3PartSize {
+		if err := r.uploadPart(r.buf.Next(s3PartSize)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (r *s3Recording) Close() error {
+	// use a fresh context, the recording should be finalized even when the session was interrupted
+	ctx := context.WithoutCancel(r.ctx)
+	client := r.storage.client
+
+	if err := r.spool.Close(); err != nil {
+		return err
+	}
+
+	if r.uploadID == nil {
+		_, err := client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(r.storage.bucket),
+			Key:    aws.String(r.key),
+			Body:   bytes.NewReader(r.buf.Bytes()),
+		})
+		if err != nil {
+			return err
+		}
+		return os.Remove(r.spool.Name())
+	}
+
+	if r.buf.Len() > 0 {
+		if err := r.uploadPart(r.buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	_, err := client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(r.storage.bucket),
+		Key:             aws.String(r.key),
+		UploadId:        r.uploadID,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: r.parts},
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(r.spool.Name())
+}
+
+func (r *s3Recording) uploadPart(data []byte) error {
+	ctx := context.WithoutCancel(r.ctx)
+	client := r.storage.client
+
+	if r.uploadID == nil {
+		output, err := client.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+			Bucket: aws.String(r.storage.bucket),
+			Key:    aws.String(r.key),
+		})
+		if err != nil {
+			return err
+		}
+		r.uploadID = output.UploadId
+	}
+
+	partNumber := int64(len(r.parts) + 1)
+	output, err := client.UploadPartWithContext(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(r.storage.bucket),
+		Key:        aws.String(r.key),
+		UploadId:   r.uploadID,
+		PartNumber: aws.Int64(partNumber),
+		Body:       bytes.NewReader(data),
+	})
+	if err != nil {
+		return err
+	}
+
+	r.parts = append(r.parts, &s3.CompletedPart{ETag: output.ETag, PartNumber: aws.Int64(partNumber)})
+
+	return nil
+}
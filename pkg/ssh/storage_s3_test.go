@@ -0,0 +1,367 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestS3Storage_Keys(t *testing.T) {
+	tests := []struct {
+		prefix    string
+		keyPrefix string
+		key       string
+	}{
+		{prefix: "", keyPrefix: "", key: "node1/a.cast"},
+		{prefix: "recordings", keyPrefix: "recordings/", key: "recordings/node1/a.cast"},
+		{prefix: "recordings/", keyPrefix: "recordings/", key: "recordings/node1/a.cast"},
+		{prefix: "/recordings/ssh/", keyPrefix: "recordings/ssh/", key: "recordings/ssh/node1/a.cast"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			s := &s3Storage{prefix: tt.prefix}
+			assert.Equal(t, tt.keyPrefix, s.keyPrefix())
+			assert.Equal(t, tt.key, s.key("node1/a.cast"))
+		})
+	}
+}
+
+func TestS3Storage_SpoolPath(t *testing.T) {
+	dir := t.TempDir()
+	s := &s3Storage{prefix: "recordings", spoolDir: dir}
+
+	key := s.key("node1/2024-01-01T00:00:00Z.cast")
+	spool := s.spoolPath(key)
+
+	// the spool file is stored directly in the spool directory, and the key is restored from its name
+	assert.Equal(t, dir, path.Dir(spool))
+	restored, err := url.PathUnescape(path.Base(spool))
+	require.NoError(t, err)
+	assert.Equal(t, key, restored)
+}
+
+func TestS3Storage_Retention(t *testing.T) {
+	now := time.Now()
+
+	fake := newFakeS3()
+	fake.put("recordings/node1/a.cast", 10, now.Add(-3*time.Hour))
+	fake.put("recordings/node2/b.cast", 10, now.Add(-2*time.Hour))
+	fake.put("recordings/node1/c.cast", 10, now.Add(-1*time.Hour))
+	fake.put("other/node1/d.cast", 10, now.Add(-4*time.Hour))
+
+	var removed []string
+	s := newFakeS3Storage(t, fake, t.TempDir(), 150*time.Minute, 15, func(name string) { removed = append(removed, name) })
+
+	require.NoError(t, s.applyRetention(context.Background(), now))
+
+	assert.Equal(t, []string{"node1/a.cast", "node2/b.cast"}, removed)
+	assert.ElementsMatch(t, []string{"recordings/node1/c.cast", "other/node1/d.cast"}, fake.keys())
+}
+
+func TestS3Storage_RunRetriesRecovery(t *testing.T) {
+	spoolDir := t.TempDir()
+
+	fake := newFakeS3()
+	fake.failPuts = 1
+
+	s := newFakeS3Storage(t, fake, spoolDir, 0, 0, nil)
+
+	spool := s.spoolPath(s.key("node1/crashed.cast"))
+	require.NoError(t, os.WriteFile(spool, []byte("crashed recording"), 0600))
+	require.NoError(t, os.Chtimes(spool, s.started.Add(-time.Minute), s.started.Add(-time.Minute)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	// the first upload fails, the recorder keeps running and the upload is retried
+	assert.Eventually(t, func() bool {
+		return slices.Contains(fake.keys(), "recordings/node1/crashed.cast")
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.NoFileExists(t, spool)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+// TestS3Storage runs against an S3 compatible object storage, like MinIO, when IONSCALE_TEST_S3_ENDPOINT is set.
+// The credentials are taken from the default AWS environment variables.
+func TestS3Storage(t *testing.T) {
+	endpoint := os.Getenv("IONSCALE_TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("IONSCALE_TEST_S3_ENDPOINT not set")
+	}
+
+	bucket := os.Getenv("IONSCALE_TEST_S3_BUCKET")
+	if bucket == "" {
+		bucket = "ionscale-test"
+	}
+
+	ctx := context.Background()
+	config := S3Config{
+		Bucket:    bucket,
+		Prefix:    fmt.Sprintf("test-%d", time.Now().UnixNano()),
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		PathStyle: true,
+	}
+	spoolDir := t.TempDir()
+
+	s, err := newS3Storage(config, spoolDir, 0, 0, nil)
+	require.NoError(t, err)
+
+	_, err = s.client.CreateBucketWithContext(ctx, &s3.CreateBucketInput{Bucket: aws.String(bucket)})
+	if aerr, ok := err.(awserr.Error); !ok || (aerr.Code() != s3.ErrCodeBucketAlreadyOwnedByYou && aerr.Code() != s3.ErrCodeBucketAlreadyExists) {
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		for _, key := range listS3Keys(t, s) {
+			_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+			assert.NoError(t, err)
+		}
+		assert.Empty(t, listS3Keys(t, s))
+	})
+
+	small := []byte("small recording")
+	large := bytes.Repeat([]byte("x"), s3PartSize+1024)
+
+	t.Run("create", func(t *testing.T) {
+		w, err := s.Create(ctx, "node1/small.cast")
+		require.NoError(t, err)
+		_, err = w.Write(small)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, small, readS3Recording(t, s, "node1/small.cast"))
+		assert.NoFileExists(t, s.spoolPath(s.key("node1/small.cast")))
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		w, err := s.Create(ctx, "node1/large.cast")
+		require.NoError(t, err)
+		_, err = w.Write(large)
+		require.NoError(t, err)
+
+		// the first part is uploaded while the session is still running
+		recording := w.(*s3Recording)
+		assert.NotNil(t, recording.uploadID)
+		assert.Len(t, recording.parts, 1)
+
+		require.NoError(t, w.Close())
+		assert.Equal(t, large, readS3Recording(t, s, "node1/large.cast"))
+	})
+
+	t.Run("recover spooled", func(t *testing.T) {
+		w, err := s.Create(ctx, "node2/crashed.cast")
+		require.NoError(t, err)
+		_, err = w.Write(large)
+		require.NoError(t, err)
+		require.NoError(t, w.(*s3Recording).spool.Close())
+
+		// the recorder is restarted, the spooled recording is uploaded and the multipart upload aborted
+		restarted := restartS3Storage(t, config, spoolDir)
+		require.NoError(t, restarted.recoverInterrupted(ctx))
+
+		assert.Equal(t, large, readS3Recording(t, s, "node2/crashed.cast"))
+		assert.Empty(t, listS3Uploads(t, s))
+	})
+
+	t.Run("recover without spool", func(t *testing.T) {
+		w, err := s.Create(ctx, "node2/lost.cast")
+		require.NoError(t, err)
+		_, err = w.Write(large)
+		require.NoError(t, err)
+		recording := w.(*s3Recording)
+		require.NoError(t, recording.spool.Close())
+		require.NoError(t, os.Remove(recording.spool.Name()))
+
+		// only the uploaded parts are kept
+		restarted := restartS3Storage(t, config, spoolDir)
+		require.NoError(t, restarted.recoverInterrupted(ctx))
+
+		assert.Equal(t, large[:s3PartSize], readS3Recording(t, s, "node2/lost.cast"))
+		assert.Empty(t, listS3Uploads(t, s))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{
+			s.key("node1/small.cast"),
+			s.key("node1/large.cast"),
+			s.key("node2/crashed.cast"),
+			s.key("node2/lost.cast"),
+		}, listS3Keys(t, s))
+	})
+}
+
+func restartS3Storage(t *testing.T, config S3Config, spoolDir string) *s3Storage {
+	time.Sleep(10 * time.Millisecond)
+	s, err := newS3Storage(config, spoolDir, 0, 0, nil)
+	require.NoError(t, err)
+	return s
+}
+
+func readS3Recording(t *testing.T, s *s3Storage, name string) []byte {
+	r, err := s.Open(context.Background(), name)
+	require.NoError(t, err)
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return content
+}
+
+func listS3Keys(t *testing.T, s *s3Storage) []string {
+	var keys []string
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(s.bucket), Prefix: aws.String(s.keyPrefix())}
+	err := s.client.ListObjectsV2PagesWithContext(context.Background(), input, func(output *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range output.Contents {
+			keys = append(keys, aws.StringValue(o.Key))
+		}
+		return true
+	})
+	require.NoError(t, err)
+	return keys
+}
+
+func listS3Uploads(t *testing.T, s *s3Storage) []string {
+	var keys []string
+	input := &s3.ListMultipartUploadsInput{Bucket: aws.String(s.bucket), Prefix: aws.String(s.keyPrefix())}
+	err := s.client.ListMultipartUploadsPagesWithContext(context.Background(), input, func(output *s3.ListMultipartUploadsOutput, _ bool) bool {
+		for _, u := range output.Uploads {
+			keys = append(keys, aws.StringValue(u.Key))
+		}
+		return true
+	})
+	require.NoError(t, err)
+	return keys
+}
+
+func newFakeS3Storage(t *testing.T, fake *fakeS3, spoolDir string, maxAge time.Duration, maxSize uint64, onRemove func(string)) *s3Storage {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	cfg := aws.NewConfig().
+		WithEndpoint(server.URL).
+		WithRegion("us-east-1").
+		WithS3ForcePathStyle(true).
+		WithCredentials(credentials.NewStaticCredentials("key", "secret", "")).
+		WithMaxRetries(0)
+
+	sess, err := session.NewSession(cfg)
+	require.NoError(t, err)
+
+	return &s3Storage{
+		client:   s3.New(sess),
+		bucket:   "recordings-bucket",
+		prefix:   "recordings",
+		spoolDir: spoolDir,
+		started:  time.Now(),
+		spooled:  map[string]bool{},
+		maxAge:   maxAge,
+		maxSize:  maxSize,
+		onRemove: onRemove,
+	}
+}
+
+type fakeS3Object struct {
+	size    int64
+	modTime time.Time
+}
+
+// fakeS3 implements the object operations of the S3 API used by the storage, for a single bucket.
+type fakeS3 struct {
+	sync.Mutex
+	objects  map[string]fakeS3Object
+	failPuts int
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string]fakeS3Object{}}
+}
+
+func (f *fakeS3) put(key string, size int64, modTime time.Time) {
+	f.Lock()
+	defer f.Unlock()
+	f.objects[key] = fakeS3Object{size: size, modTime: modTime}
+}
+
+func (f *fakeS3) keys() []string {
+	f.Lock()
+	defer f.Unlock()
+	var keys []string
+	for k := range f.objects {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	_, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodGet && query.Has("uploads"):
+		_ = xml.NewEncoder(w).Encode(struct {
+			XMLName     xml.Name `xml:"ListMultipartUploadsResult"`
+			IsTruncated bool
+		}{})
+	case r.Method == http.MethodGet && key == "":
+		type content struct {
+			Key          string
+			Size         int64
+			LastModified string
+		}
+		var contents []content
+		for k, o := range f.objects {
+			if strings.HasPrefix(k, query.Get("prefix")) {
+				contents = append(contents, content{Key: k, Size: o.size, LastModified: o.modTime.UTC().Format(time.RFC3339)})
+			}
+		}
+		_ = xml.NewEncoder(w).Encode(struct {
+			XMLName     xml.Name `xml:"ListBucketResult"`
+			IsTruncated bool
+			Contents    []content
+		}{Contents: contents})
+	case r.Method == http.MethodPut:
+		if f.failPuts > 0 {
+			f.failPuts--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`<Error><Code>ServiceUnavailable</Code><Message>unavailable</Message></Error>`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = fakeS3Object{size: int64(len(body)), modTime: time.Now()}
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}